@@ -0,0 +1,307 @@
+package otters
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CSVAggregation is one result column of AggregateCSV: Op applied to Column
+// within each group. Op is "sum", "mean", "min", "max", or "count". A count
+// without a Column counts the group's rows; with one it counts the rows
+// where the column has a value.
+type CSVAggregation struct {
+	Column string
+	Op     string
+}
+
+// csvGroup is the running state of one group in AggregateCSV.
+type csvGroup struct {
+	keys   []string
+	rows   int64
+	states []csvAggState
+}
+
+// csvAggState is the running state of one aggregation within a group.
+type csvAggState struct {
+	sum      float64
+	n        int64
+	min, max float64
+}
+
+// AggregateCSV groups a CSV file by groupBy and computes the aggregations
+// while streaming it row by row, so memory grows with the number of groups
+// rather than the size of the file:
+//
+//	totals, err := otters.AggregateCSV("events.csv", []string{"region"}, []otters.CSVAggregation{
+//		{Column: "amount", Op: "sum"},
+//		{Column: "amount", Op: "mean"},
+//		{Op: "count"},
+//	}, otters.CSVOptions{HasHeader: true, Delimiter: ','})
+//
+// Result columns are named "<column>_<op>", or "count" for a row count,
+// and follow the group columns; groups are sorted by key. Group columns are
+// typed by the same inference as ReadCSV. Aggregated columns must hold
+// numbers: empty cells and NaN are missing values that sum, mean, min and
+// max skip (a group with no values gets NaN, or 0 for sum).
+func AggregateCSV(filename string, groupBy []string, aggregations []CSVAggregation, options CSVOptions) (*DataFrame, error) {
+	if len(groupBy) == 0 {
+		return nil, newOpError("AggregateCSV", "at least one group column must be specified")
+	}
+	if len(aggregations) == 0 {
+		return nil, newOpError("AggregateCSV", "at least one aggregation must be specified")
+	}
+	for _, agg := range aggregations {
+		switch agg.Op {
+		case "sum", "mean", "min", "max":
+			if agg.Column == "" {
+				return nil, newOpError("AggregateCSV", fmt.Sprintf("%s needs a column", agg.Op))
+			}
+		case "count":
+		default:
+			return nil, newOpError("AggregateCSV", fmt.Sprintf("unsupported aggregation: %s", agg.Op))
+		}
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, wrapError("AggregateCSV", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, wrapError("AggregateCSV", err)
+	}
+
+	reader := csv.NewReader(file)
+	reader.Comma = options.delimiter()
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	if err := skipRows(reader, options.SkipRows, "AggregateCSV"); err != nil {
+		return nil, err
+	}
+
+	tick := csvTick(nil, reader, options.Progress, int(info.Size()), "AggregateCSV")
+	result, err := aggregateCSVRows(reader, groupBy, aggregations, options, tick)
+	if err != nil {
+		return nil, err
+	}
+	reportDone(options.Progress, int(info.Size()))
+	return result, nil
+}
+
+// aggregateCSVRows does the work of AggregateCSV once the reader is
+// positioned at the header (or first data row).
+func aggregateCSVRows(reader *csv.Reader, groupBy []string, aggregations []CSVAggregation, options CSVOptions, tick func() error) (*DataFrame, error) {
+	first, err := reader.Read()
+	if err == io.EOF {
+		return nil, newOpError("AggregateCSV", "file has no rows")
+	}
+	if err != nil {
+		return nil, wrapError("AggregateCSV", err)
+	}
+
+	var headers []string
+	pending := true // first holds a data row still to aggregate
+	if options.HasHeader {
+		headers = make([]string, len(first))
+		for i, header := range first {
+			headers[i] = cleanHeader(header)
+		}
+		pending = false
+	} else {
+		headers = generateHeaders(len(first))
+	}
+
+	columnIndex := func(column string) (int, error) {
+		if i := slices.Index(headers, column); i >= 0 {
+			return i, nil
+		}
+		return 0, &OtterError{Op: "AggregateCSV", Column: column, Message: "column does not exist", Row: -1, Cause: ErrColumnNotFound}
+	}
+	keyCols := make([]int, len(groupBy))
+	for k, column := range groupBy {
+		if keyCols[k], err = columnIndex(column); err != nil {
+			return nil, err
+		}
+	}
+	aggCols := make([]int, len(aggregations))
+	for k, agg := range aggregations {
+		aggCols[k] = -1
+		if agg.Column != "" {
+			if aggCols[k], err = columnIndex(agg.Column); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	groups := make(map[string]*csvGroup)
+	var order []*csvGroup
+	var key strings.Builder
+	add := func(row []string, rowNum int) error {
+		if len(row) != len(headers) {
+			return newOpError("AggregateCSV",
+				fmt.Sprintf("row %d has %d columns, expected %d", rowNum, len(row), len(headers)))
+		}
+
+		// Length-prefix each value so no separator can make two keys collide
+		key.Reset()
+		for _, c := range keyCols {
+			key.WriteString(strconv.Itoa(len(row[c])))
+			key.WriteByte(':')
+			key.WriteString(row[c])
+		}
+		g, ok := groups[key.String()]
+		if !ok {
+			g = &csvGroup{keys: make([]string, len(keyCols)), states: make([]csvAggState, len(aggregations))}
+			for k, c := range keyCols {
+				g.keys[k] = strings.Clone(row[c]) // the reader reuses row
+			}
+			for k := range g.states {
+				g.states[k].min, g.states[k].max = math.Inf(1), math.Inf(-1)
+			}
+			groups[key.String()] = g
+			order = append(order, g)
+		}
+
+		g.rows++
+		for k, c := range aggCols {
+			if c < 0 {
+				continue
+			}
+			cell := strings.TrimSpace(row[c])
+			if cell == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return &OtterError{Op: "AggregateCSV", Column: headers[c], Row: rowNum,
+					Message: fmt.Sprintf("cannot parse %q as a number", cell)}
+			}
+			if math.IsNaN(v) {
+				continue
+			}
+			s := &g.states[k]
+			s.sum += v
+			s.n++
+			s.min = math.Min(s.min, v)
+			s.max = math.Max(s.max, v)
+		}
+		return nil
+	}
+
+	rowNum := 0
+	if pending {
+		rowNum++
+		if err := add(first, rowNum); err != nil {
+			return nil, err
+		}
+	}
+	for options.MaxRows <= 0 || rowNum < options.MaxRows {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, wrapError("AggregateCSV", err)
+		}
+		rowNum++
+		if err := add(row, rowNum); err != nil {
+			return nil, err
+		}
+		if rowNum%checkRows == 0 {
+			if err := tick(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(order) == 0 {
+		return nil, newOpError("AggregateCSV", "file has no rows")
+	}
+
+	return buildCSVAggregates(order, groupBy, aggregations)
+}
+
+// buildCSVAggregates turns the group states into the result frame.
+func buildCSVAggregates(groups []*csvGroup, groupBy []string, aggregations []CSVAggregation) (*DataFrame, error) {
+	result := make([]*Series, 0, len(groupBy)+len(aggregations))
+	for k, column := range groupBy {
+		values := make([]string, len(groups))
+		for i, g := range groups {
+			values[i] = g.keys[k]
+		}
+		data, err := convertStringSliceToType(values, InferType(values))
+		if err != nil {
+			return nil, wrapColumnError("AggregateCSV", column, err)
+		}
+		s, err := newSeriesOwned(column, data)
+		if err != nil {
+			return nil, wrapColumnError("AggregateCSV", column, err)
+		}
+		result = append(result, s)
+	}
+
+	for k, agg := range aggregations {
+		name := agg.Column + "_" + agg.Op
+		var data any
+		switch agg.Op {
+		case "count":
+			counts := make([]int64, len(groups))
+			for i, g := range groups {
+				counts[i] = g.rows
+				if agg.Column != "" {
+					counts[i] = g.states[k].n
+				}
+			}
+			if agg.Column == "" {
+				name = "count"
+			}
+			data = counts
+		default:
+			values := make([]float64, len(groups))
+			for i, g := range groups {
+				s := g.states[k]
+				switch {
+				case agg.Op == "sum":
+					values[i] = s.sum
+				case s.n == 0:
+					values[i] = math.NaN()
+				case agg.Op == "mean":
+					values[i] = s.sum / float64(s.n)
+				case agg.Op == "min":
+					values[i] = s.min
+				case agg.Op == "max":
+					values[i] = s.max
+				}
+			}
+			data = values
+		}
+
+		if slices.ContainsFunc(result, func(s *Series) bool { return s.Name == name }) {
+			return nil, newColumnError("AggregateCSV", name, "result column name used more than once")
+		}
+		s, err := newSeriesOwned(name, data)
+		if err != nil {
+			return nil, wrapColumnError("AggregateCSV", name, err)
+		}
+		result = append(result, s)
+	}
+
+	df, err := NewDataFrameFromSeries(result...)
+	if err != nil {
+		return nil, err
+	}
+	ascending := make([]bool, len(groupBy))
+	for i := range ascending {
+		ascending[i] = true
+	}
+	sorted := df.SortBy(groupBy, ascending)
+	return sorted, sorted.Error()
+}
@@ -0,0 +1,111 @@
+package otters
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeAggCSV(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAggregateCSV(t *testing.T) {
+	path := writeAggCSV(t, "region,year,amount\n"+
+		"west,2024,10\n"+
+		"east,2024,4\n"+
+		"west,2023,\n"+
+		"west,2024,2.5\n"+
+		"east,2024,NaN\n")
+
+	result, err := AggregateCSV(path, []string{"region", "year"}, []CSVAggregation{
+		{Column: "amount", Op: "sum"},
+		{Column: "amount", Op: "mean"},
+		{Column: "amount", Op: "max"},
+		{Column: "amount", Op: "count"},
+		{Op: "count"},
+	}, CSVOptions{HasHeader: true, Delimiter: ','})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		region         string
+		year           int64
+		sum, mean, max float64
+		values, rows   int64
+	}{
+		{"east", 2024, 4, 4, 4, 1, 2},
+		{"west", 2023, 0, math.NaN(), math.NaN(), 0, 1},
+		{"west", 2024, 12.5, 6.25, 10, 2, 2},
+	}
+	if result.Len() != len(want) {
+		t.Fatalf("got %d groups, want %d", result.Len(), len(want))
+	}
+	for i, w := range want {
+		region, _ := result.Get(i, "region")
+		year, _ := result.Get(i, "year")
+		sum, _ := result.Get(i, "amount_sum")
+		mean, _ := result.Get(i, "amount_mean")
+		maxV, _ := result.Get(i, "amount_max")
+		values, _ := result.Get(i, "amount_count")
+		rows, _ := result.Get(i, "count")
+		if region != w.region || year != w.year || values != w.values || rows != w.rows {
+			t.Errorf("row %d: got %v %v values=%v rows=%v", i, region, year, values, rows)
+		}
+		for _, pair := range [][2]float64{{sum.(float64), w.sum}, {mean.(float64), w.mean}, {maxV.(float64), w.max}} {
+			if pair[0] != pair[1] && !(math.IsNaN(pair[0]) && math.IsNaN(pair[1])) {
+				t.Errorf("row %d: got %v, want %v", i, pair[0], pair[1])
+			}
+		}
+	}
+}
+
+func TestAggregateCSVDefaultDelimiter(t *testing.T) {
+	path := writeAggCSV(t, "region,amount\nwest,10\neast,4\nwest,2\n")
+
+	result, err := AggregateCSV(path, []string{"region"}, []CSVAggregation{{Column: "amount", Op: "sum"}}, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum, _ := result.Get(1, "amount_sum"); result.Len() != 2 || sum != 12.0 {
+		t.Errorf("got %d groups, west sum %v; want 2 groups, 12", result.Len(), sum)
+	}
+}
+
+func TestAggregateCSVErrors(t *testing.T) {
+	path := writeAggCSV(t, "k,v\na,1\nb,oops\n")
+	options := CSVOptions{HasHeader: true, Delimiter: ','}
+
+	_, err := AggregateCSV(path, []string{"k"}, []CSVAggregation{{Column: "v", Op: "sum"}}, options)
+	var otterErr *OtterError
+	if !errors.As(err, &otterErr) || otterErr.Row != 2 || otterErr.Column != "v" {
+		t.Errorf("expected a parse error at row 2 of v, got %v", err)
+	}
+
+	_, err = AggregateCSV(path, []string{"missing"}, []CSVAggregation{{Op: "count"}}, options)
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Errorf("expected ErrColumnNotFound, got %v", err)
+	}
+
+	_, err = AggregateCSV(path, []string{"k"}, []CSVAggregation{{Column: "v", Op: "median"}}, options)
+	if err == nil {
+		t.Error("expected error for an unsupported aggregation")
+	}
+
+	counts, err := AggregateCSV(path, []string{"Column_0"}, []CSVAggregation{{Op: "count"}},
+		CSVOptions{Delimiter: ',', MaxRows: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts.Len() != 2 {
+		t.Errorf("headerless read with MaxRows 2 found %d groups, want 2 (k, a)", counts.Len())
+	}
+}
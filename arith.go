@@ -0,0 +1,371 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+)
+
+// Element-wise Column Methods
+
+// Round returns a copy of the DataFrame with a numeric column rounded to the
+// given number of decimal places, with halves rounded away from zero.
+// Negative decimals round to the left of the decimal point (-2 rounds to the
+// nearest hundred). Int64 columns stay Int64; they only change when decimals
+// is negative.
+func (df *DataFrame) Round(column string, decimals int) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return df.setOpError("Round", err)
+	}
+
+	series := df.columns[column]
+	var data any
+	switch series.Type {
+	case Float64Type:
+		values := series.Data.([]float64)
+		rounded := make([]float64, len(values))
+		for i, v := range values {
+			rounded[i] = roundFloat64(v, decimals)
+		}
+		data = rounded
+	case Int64Type:
+		values := series.Data.([]int64)
+		rounded := make([]int64, len(values))
+		for i, v := range values {
+			r, err := roundInt64(v, decimals)
+			if err != nil {
+				return df.setError(wrapColumnError("Round", column, err))
+			}
+			rounded[i] = r
+		}
+		data = rounded
+	default:
+		return df.setError(newColumnError("Round", column,
+			fmt.Sprintf("cannot round column of type %s", series.Type)))
+	}
+
+	return df.withReplacedColumn(column, data, "Round")
+}
+
+// Abs returns a copy of the DataFrame with a numeric column replaced by its
+// absolute values. Int64 columns stay Int64.
+func (df *DataFrame) Abs(column string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return df.setOpError("Abs", err)
+	}
+
+	series := df.columns[column]
+	var data any
+	switch series.Type {
+	case Float64Type:
+		values := series.Data.([]float64)
+		abs := make([]float64, len(values))
+		for i, v := range values {
+			abs[i] = math.Abs(v)
+		}
+		data = abs
+	case Int64Type:
+		values := series.Data.([]int64)
+		abs := make([]int64, len(values))
+		for i, v := range values {
+			if v == math.MinInt64 {
+				return df.setError(&OtterError{
+					Op:      "Abs",
+					Column:  column,
+					Row:     i,
+					Message: "absolute value overflows int64",
+				})
+			}
+			if v < 0 {
+				v = -v
+			}
+			abs[i] = v
+		}
+		data = abs
+	default:
+		return df.setError(newColumnError("Abs", column,
+			fmt.Sprintf("cannot take absolute value of column of type %s", series.Type)))
+	}
+
+	return df.withReplacedColumn(column, data, "Abs")
+}
+
+// roundFloat64 rounds v to the given number of decimal places. Values whose
+// scaled form is out of float64 range are already exact and returned as is.
+func roundFloat64(v float64, decimals int) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+	if decimals < 0 {
+		p := math.Pow10(-decimals)
+		if math.IsInf(p, 0) {
+			return math.Copysign(0, v)
+		}
+		return math.Round(v/p) * p
+	}
+	p := math.Pow10(decimals)
+	scaled := v * p
+	if math.IsInf(p, 0) || math.IsInf(scaled, 0) {
+		return v
+	}
+	return math.Round(scaled) / p
+}
+
+// roundInt64 rounds v to the nearest multiple of 10^-decimals, halves away
+// from zero. Non-negative decimals leave v unchanged.
+func roundInt64(v int64, decimals int) (int64, error) {
+	if decimals >= 0 {
+		return v, nil
+	}
+	if decimals < -18 {
+		return 0, nil
+	}
+
+	p := int64(1)
+	for i := 0; i < -decimals; i++ {
+		p *= 10
+	}
+	rem := v % p
+	base := v - rem
+	if rem < 0 {
+		rem = -rem
+	}
+	if rem*2 < p {
+		return base, nil
+	}
+	if v < 0 {
+		if base < math.MinInt64+p {
+			return 0, fmt.Errorf("rounding %d overflows int64", v)
+		}
+		return base - p, nil
+	}
+	if base > math.MaxInt64-p {
+		return 0, fmt.Errorf("rounding %d overflows int64", v)
+	}
+	return base + p, nil
+}
+
+// Column Arithmetic
+
+// ArithPolicy decides what column arithmetic does with a row whose result
+// is undefined (division by zero) or out of range (overflow).
+type ArithPolicy int
+
+const (
+	// ArithNaN stores NaN, the float64 missing value. An Int64 result with
+	// an overflowing row becomes a Float64 column so the NaN can be stored.
+	ArithNaN ArithPolicy = iota
+	// ArithError fails the operation, reporting the offending row.
+	ArithError
+	// ArithClamp stores the largest representable value with the result's
+	// sign. 0/0 has no sign and is stored as NaN.
+	ArithClamp
+)
+
+// ArithOptions selects the policies used by ArithWithOptions
+type ArithOptions struct {
+	DivideByZero ArithPolicy
+	Overflow     ArithPolicy
+}
+
+// Arith computes left op right row by row and stores it in the result
+// column, replacing it if it already exists. op is one of "+", "-", "*" or
+// "/". Two Int64 columns produce an Int64 result, except for "/" which
+// always produces Float64. Division by zero and overflow store NaN; use
+// ArithWithOptions to choose other policies.
+func (df *DataFrame) Arith(result, left, op, right string) *DataFrame {
+	return df.ArithWithOptions(result, left, op, right, ArithOptions{})
+}
+
+// ArithWithOptions is Arith with custom division-by-zero and overflow policies
+func (df *DataFrame) ArithWithOptions(result, left, op, right string, options ArithOptions) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if err := df.validateColumnsExist([]string{left, right}); err != nil {
+		return df.setOpError("Arith", err)
+	}
+	switch op {
+	case "+", "-", "*", "/":
+	default:
+		return df.setError(newOpError("Arith", fmt.Sprintf("unsupported operator: %s", op)))
+	}
+
+	a, b := df.columns[left], df.columns[right]
+	for _, s := range []*Series{a, b} {
+		if !isNumericType(s.Type) {
+			return df.setError(newColumnError("Arith", s.Name,
+				fmt.Sprintf("cannot use column of type %s in arithmetic", s.Type)))
+		}
+	}
+
+	var data any
+	var err error
+	if a.Type == Int64Type && b.Type == Int64Type && op != "/" {
+		data, err = arithInt64(a.Data.([]int64), op, b.Data.([]int64), options)
+	} else {
+		data, err = arithFloat64(numericAsFloat64(a), op, numericAsFloat64(b), options)
+	}
+	if err != nil {
+		if oe, ok := err.(*OtterError); ok {
+			oe.Column = result
+			return df.setError(oe)
+		}
+		return df.setError(wrapColumnError("Arith", result, err))
+	}
+
+	if df.HasColumn(result) {
+		return df.withReplacedColumn(result, data, "Arith")
+	}
+	series, err := newSeriesOwned(result, data)
+	if err != nil {
+		return df.setError(wrapColumnError("Arith", result, err))
+	}
+	return df.withAppendedColumn(series, "Arith")
+}
+
+// arithFloat64 applies op to each pair of values. Only results produced from
+// finite operands are checked, so NaN and Inf inputs propagate unchanged.
+func arithFloat64(a []float64, op string, b []float64, options ArithOptions) ([]float64, error) {
+	out := make([]float64, len(a))
+	for i := range a {
+		x, y := a[i], b[i]
+		var v float64
+		switch op {
+		case "+":
+			v = x + y
+		case "-":
+			v = x - y
+		case "*":
+			v = x * y
+		case "/":
+			if y == 0 && !math.IsNaN(x) {
+				// x/y is ±Inf, or NaN for 0/0, which gives a clamped result its sign
+				r, err := applyArithPolicy(options.DivideByZero, i, "division by zero", x/y)
+				if err != nil {
+					return nil, err
+				}
+				out[i] = r
+				continue
+			}
+			v = x / y
+		}
+
+		if math.IsInf(v, 0) && !math.IsInf(x, 0) && !math.IsInf(y, 0) {
+			r, err := applyArithPolicy(options.Overflow, i, "result overflows float64", v)
+			if err != nil {
+				return nil, err
+			}
+			v = r
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// arithInt64 applies "+", "-" or "*" to each pair of values. Overflowing rows
+// under ArithNaN turn the whole result into a Float64 column.
+func arithInt64(a []int64, op string, b []int64, options ArithOptions) (any, error) {
+	out := make([]int64, len(a))
+	var overflowed []int
+	for i := range a {
+		v, ok := checkedInt64(a[i], op, b[i])
+		if ok {
+			out[i] = v
+			continue
+		}
+
+		switch options.Overflow {
+		case ArithError:
+			return nil, arithRowError(i, "result overflows int64")
+		case ArithClamp:
+			if int64Sign(a[i], op, b[i]) < 0 {
+				out[i] = math.MinInt64
+			} else {
+				out[i] = math.MaxInt64
+			}
+		default:
+			overflowed = append(overflowed, i)
+		}
+	}
+
+	if overflowed == nil {
+		return out, nil
+	}
+	floats := make([]float64, len(out))
+	for i, v := range out {
+		floats[i] = float64(v)
+	}
+	for _, i := range overflowed {
+		floats[i] = math.NaN()
+	}
+	return floats, nil
+}
+
+// checkedInt64 computes a op b, reporting false if the result overflows.
+func checkedInt64(a int64, op string, b int64) (int64, bool) {
+	switch op {
+	case "+":
+		v := a + b
+		return v, (v > a) == (b > 0)
+	case "-":
+		v := a - b
+		return v, (v < a) == (b > 0)
+	default:
+		if a == 0 || b == 0 {
+			return 0, true
+		}
+		v := a * b
+		if v/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+			return v, false
+		}
+		return v, true
+	}
+}
+
+// int64Sign returns the sign of the exact (unbounded) result of a op b.
+func int64Sign(a int64, op string, b int64) int {
+	switch op {
+	case "+":
+		if a < 0 {
+			return -1
+		}
+	case "-":
+		if a < b {
+			return -1
+		}
+	default:
+		if (a < 0) != (b < 0) {
+			return -1
+		}
+	}
+	return 1
+}
+
+// applyArithPolicy resolves a row whose IEEE result was v.
+func applyArithPolicy(policy ArithPolicy, row int, message string, v float64) (float64, error) {
+	switch policy {
+	case ArithError:
+		return 0, arithRowError(row, message)
+	case ArithClamp:
+		if math.IsNaN(v) {
+			return v, nil
+		}
+		return math.Copysign(math.MaxFloat64, v), nil
+	default:
+		return math.NaN(), nil
+	}
+}
+
+// arithRowError reports an arithmetic failure at a row.
+func arithRowError(row int, message string) error {
+	return &OtterError{Op: "Arith", Row: row, Message: message}
+}
@@ -0,0 +1,149 @@
+package otters
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "price", []float64{1.005, 2.345, -2.5, 1234.5678, math.NaN()}),
+		mustSeries(t, "qty", []int64{1234, -1250, 49, 50, -49}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.Round("price", 2)
+	if result.Error() != nil {
+		t.Fatalf("Round error: %v", result.Error())
+	}
+	assertFloatColumn(t, result, "price", []float64{1, 2.35, -2.5, 1234.57, math.NaN()})
+
+	hundreds := df.Round("price", -2)
+	assertFloatColumn(t, hundreds, "price", []float64{0, 0, -0, 1200, math.NaN()})
+
+	tens := df.Round("qty", -2)
+	if colType, _ := tens.GetColumnType("qty"); colType != Int64Type {
+		t.Fatalf("qty type = %v, want int64", colType)
+	}
+	want := []int64{1200, -1300, 0, 100, 0}
+	for i, w := range want {
+		if v, _ := tens.Get(i, "qty"); v != w {
+			t.Errorf("row %d: rounded qty = %v, want %d", i, v, w)
+		}
+	}
+
+	if v, _ := df.Get(1, "price"); v != 2.345 {
+		t.Errorf("Round modified the source frame: price = %v", v)
+	}
+}
+
+func TestAbs(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "pnl", []float64{-1.5, 2, math.Inf(-1)}),
+		mustSeries(t, "delta", []int64{-3, 0, 7}),
+		mustSeries(t, "name", []string{"a", "b", "c"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.Abs("pnl").Abs("delta")
+	if result.Error() != nil {
+		t.Fatalf("Abs error: %v", result.Error())
+	}
+	assertFloatColumn(t, result, "pnl", []float64{1.5, 2, math.Inf(1)})
+	if v, _ := result.Get(0, "delta"); v != int64(3) {
+		t.Errorf("abs delta = %v, want 3", v)
+	}
+
+	if df.Abs("name").Error() == nil {
+		t.Error("expected error taking Abs of a string column")
+	}
+	if df.Round("missing", 1).Error() == nil {
+		t.Error("expected error rounding a missing column")
+	}
+
+	minInt, _ := NewDataFrameFromSeries(mustSeries(t, "v", []int64{math.MinInt64}))
+	if minInt.Abs("v").Error() == nil {
+		t.Error("expected overflow error for Abs(MinInt64)")
+	}
+}
+
+func arithTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "revenue", []float64{100, -50, 0, 30}),
+		mustSeries(t, "units", []int64{4, 0, 0, 3}),
+		mustSeries(t, "big", []int64{math.MaxInt64, 1, -2, math.MinInt64}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestArith(t *testing.T) {
+	df := arithTestFrame(t)
+
+	perUnit := df.Arith("per_unit", "revenue", "/", "units")
+	if perUnit.Error() != nil {
+		t.Fatalf("Arith error: %v", perUnit.Error())
+	}
+	assertFloatColumn(t, perUnit, "per_unit", []float64{25, math.NaN(), math.NaN(), 10})
+
+	sum := df.Arith("total", "units", "+", "units")
+	if colType, _ := sum.GetColumnType("total"); colType != Int64Type {
+		t.Errorf("int64 + int64 type = %v, want int64", colType)
+	}
+
+	replaced := df.Arith("revenue", "revenue", "*", "units")
+	if replaced.Width() != df.Width() {
+		t.Errorf("writing to an existing column should replace it, got columns %v", replaced.Columns())
+	}
+	assertFloatColumn(t, replaced, "revenue", []float64{400, 0, 0, 90})
+
+	if df.Arith("x", "revenue", "%", "units").Error() == nil {
+		t.Error("expected error for unsupported operator")
+	}
+	if df.Arith("x", "revenue", "+", "missing").Error() == nil {
+		t.Error("expected error for missing column")
+	}
+}
+
+func TestArithPolicies(t *testing.T) {
+	df := arithTestFrame(t)
+
+	clamped := df.ArithWithOptions("r", "revenue", "/", "units", ArithOptions{DivideByZero: ArithClamp})
+	assertFloatColumn(t, clamped, "r", []float64{25, -math.MaxFloat64, math.NaN(), 10})
+
+	failed := df.ArithWithOptions("r", "revenue", "/", "units", ArithOptions{DivideByZero: ArithError})
+	oe, ok := failed.Error().(*OtterError)
+	if !ok || oe.Row != 1 || oe.Column != "r" {
+		t.Errorf("expected division error at row 1, got %v", failed.Error())
+	}
+
+	// Overflow with the default policy turns the int result into float64 with NaN
+	overflow := df.Arith("r", "big", "+", "units")
+	assertFloatColumn(t, overflow, "r", []float64{math.NaN(), 1, -2, float64(math.MinInt64) + 3})
+
+	clampedInt := df.ArithWithOptions("r", "big", "*", "big", ArithOptions{Overflow: ArithClamp})
+	want := []int64{math.MaxInt64, 1, 4, math.MaxInt64}
+	for i, w := range want {
+		if v, _ := clampedInt.Get(i, "r"); v != w {
+			t.Errorf("row %d: clamped product = %v, want %d", i, v, w)
+		}
+	}
+
+	underflow := df.ArithWithOptions("r", "big", "-", "units", ArithOptions{Overflow: ArithError})
+	if oe, ok := underflow.Error().(*OtterError); !ok || oe.Row != 3 {
+		t.Errorf("expected overflow error at row 3, got %v", underflow.Error())
+	}
+	if df.ArithWithOptions("r", "big", "-", "big", ArithOptions{Overflow: ArithError}).Error() != nil {
+		t.Error("x - x should not overflow")
+	}
+	if df.ArithWithOptions("r", "big", "*", "units", ArithOptions{Overflow: ArithError}).Error() == nil {
+		t.Error("expected overflow error for MaxInt64 * 4")
+	}
+}
@@ -0,0 +1,214 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Cut assigns each value of a numeric column to a bin and appends the bin
+// labels as a string column "<column>_bin". bins holds the increasing bin
+// edges; bin i covers (bins[i], bins[i+1]], except that the first bin also
+// includes its left edge. labels names the bins and must have one entry per
+// bin; nil labels bins by their interval, e.g. "(10, 20]". Values outside
+// every bin, and missing values, get an empty label.
+func (df *DataFrame) Cut(column string, bins []float64, labels []string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	values, err := df.numericColumn(column, "Cut")
+	if err != nil {
+		return df.setOpError("Cut", err)
+	}
+
+	if len(bins) < 2 {
+		return df.setError(newColumnError("Cut", column, "need at least 2 bin edges"))
+	}
+	for i := 1; i < len(bins); i++ {
+		if !(bins[i] > bins[i-1]) {
+			return df.setError(newColumnError("Cut", column, "bin edges must be strictly increasing"))
+		}
+	}
+
+	return df.appendBins(column, values, bins, labels, "Cut")
+}
+
+// QCut bins a numeric column into q groups of (roughly) equal size, using
+// the column's quantiles as bin edges, and appends the labels as a string
+// column "<column>_bin" like Cut.
+func (df *DataFrame) QCut(column string, q int) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	values, err := df.numericColumn(column, "QCut")
+	if err != nil {
+		return df.setOpError("QCut", err)
+	}
+
+	if q < 1 {
+		return df.setError(newColumnError("QCut", column, "q must be positive"))
+	}
+
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	if len(sorted) == 0 {
+		return df.setError(newColumnError("QCut", column, "column has no values to bin"))
+	}
+	sort.Float64s(sorted)
+
+	bins := make([]float64, q+1)
+	for i := range bins {
+		bins[i] = quantileSorted(sorted, float64(i)/float64(q), "linear")
+	}
+	for i := 1; i < len(bins); i++ {
+		if bins[i] == bins[i-1] {
+			return df.setError(newColumnError("QCut", column,
+				fmt.Sprintf("bin edge %v repeats; too many bins for the distinct values", bins[i])))
+		}
+	}
+
+	return df.appendBins(column, values, bins, nil, "QCut")
+}
+
+// Histogram counts the values of a numeric column in nbins equal-width
+// bins spanning its minimum to maximum. The result has one row per bin
+// with float64 "lower" and "upper" edges and an int64 "count"; the last
+// bin includes its upper edge. Missing values are not counted; an infinite
+// value is an error, since no finite bins can span it (see ReplaceInf).
+func (df *DataFrame) Histogram(column string, nbins int) (*DataFrame, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	values, err := df.numericColumn(column, "Histogram")
+	if err != nil {
+		return nil, err
+	}
+
+	if nbins < 1 {
+		return nil, newColumnError("Histogram", column, "nbins must be positive")
+	}
+	if err := df.infError([]string{column}, "Histogram"); err != nil {
+		return nil, err
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	if lo > hi {
+		return nil, newColumnError("Histogram", column, "column has no values to count")
+	}
+	if lo == hi {
+		// A single distinct value still gets a bin of non-zero width
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	width := (hi - lo) / float64(nbins)
+	lower := make([]float64, nbins)
+	upper := make([]float64, nbins)
+	for i := range lower {
+		lower[i] = lo + float64(i)*width
+		upper[i] = lo + float64(i+1)*width
+	}
+	upper[nbins-1] = hi
+
+	counts := make([]int64, nbins)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		// The last lower edge not above v opens v's bin
+		bin := sort.SearchFloat64s(lower, v)
+		if bin == nbins || lower[bin] > v {
+			bin--
+		}
+		counts[bin]++
+	}
+
+	lowerSeries, err := newSeriesOwned("lower", lower)
+	if err != nil {
+		return nil, wrapColumnError("Histogram", column, err)
+	}
+	upperSeries, err := newSeriesOwned("upper", upper)
+	if err != nil {
+		return nil, wrapColumnError("Histogram", column, err)
+	}
+	countSeries, err := newSeriesOwned("count", counts)
+	if err != nil {
+		return nil, wrapColumnError("Histogram", column, err)
+	}
+	return NewDataFrameFromSeries(lowerSeries, upperSeries, countSeries)
+}
+
+// numericColumn returns a numeric column's values as float64. Float64 data
+// is not copied and must not be modified.
+func (df *DataFrame) numericColumn(column, operation string) ([]float64, error) {
+	if err := df.validateColumnExists(column); err != nil {
+		return nil, err
+	}
+	series := df.columns[column]
+	if !isNumericType(series.Type) {
+		return nil, newColumnError(operation, column, "column must be numeric (int64 or float64)")
+	}
+	return numericAsFloat64(series), nil
+}
+
+// appendBins labels each value with its bin and appends "<column>_bin".
+func (df *DataFrame) appendBins(column string, values, bins []float64, labels []string, operation string) *DataFrame {
+	nbins := len(bins) - 1
+	if labels == nil {
+		labels = intervalLabels(bins)
+	} else if len(labels) != nbins {
+		return df.setError(newColumnError(operation, column,
+			fmt.Sprintf("got %d labels for %d bins", len(labels), nbins)))
+	}
+
+	out := make([]string, len(values))
+	for i, v := range values {
+		if math.IsNaN(v) || v < bins[0] || v > bins[nbins] {
+			continue
+		}
+		// The first edge not below v closes v's bin
+		bin := sort.SearchFloat64s(bins, v) - 1
+		if bin < 0 {
+			bin = 0
+		}
+		out[i] = labels[bin]
+	}
+
+	series, err := newSeriesOwned(column+"_bin", out)
+	if err != nil {
+		return df.setError(wrapColumnError(operation, column, err))
+	}
+	return df.withAppendedColumn(series, operation)
+}
+
+// intervalLabels names the bins between consecutive edges by their
+// intervals, e.g. "[0, 10]" and "(10, 20]".
+func intervalLabels(edges []float64) []string {
+	labels := make([]string, len(edges)-1)
+	for i := range labels {
+		open := "("
+		if i == 0 {
+			open = "["
+		}
+		labels[i] = open + formatBinEdge(edges[i]) + ", " + formatBinEdge(edges[i+1]) + "]"
+	}
+	return labels
+}
+
+// formatBinEdge formats a bin edge for a default label.
+func formatBinEdge(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,104 @@
+package otters
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCut(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "age", []float64{0, 15, 18, 30, 65, 90, math.NaN()}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labeled := df.Cut("age", []float64{0, 18, 65}, []string{"minor", "adult"})
+	if labeled.Error() != nil {
+		t.Fatalf("Cut error: %v", labeled.Error())
+	}
+	want := []string{"minor", "minor", "minor", "adult", "adult", "", ""}
+	if got := labeled.columns["age_bin"].StringSlice(); !slices.Equal(got, want) {
+		t.Errorf("Cut labels = %v, want %v", got, want)
+	}
+
+	intervals := df.Cut("age", []float64{0, 18, 100}, nil)
+	if got := intervals.columns["age_bin"].StringSlice()[:4]; !slices.Equal(got, []string{"[0, 18]", "[0, 18]", "[0, 18]", "(18, 100]"}) {
+		t.Errorf("Cut default labels = %v", got)
+	}
+
+	if df.Cut("age", []float64{10, 5}, nil).Error() == nil {
+		t.Error("expected error for decreasing edges")
+	}
+	if df.Cut("age", []float64{0, 18, 65}, []string{"one"}).Error() == nil {
+		t.Error("expected error for label count mismatch")
+	}
+}
+
+func TestQCut(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "x", []int64{1, 2, 3, 4, 5, 6, 7, 8}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.QCut("x", 4)
+	if result.Error() != nil {
+		t.Fatalf("QCut error: %v", result.Error())
+	}
+	counts := map[string]int{}
+	for _, label := range result.columns["x_bin"].StringSlice() {
+		counts[label]++
+	}
+	if len(counts) != 4 {
+		t.Fatalf("expected 4 bins, got %v", counts)
+	}
+	for label, n := range counts {
+		if n != 2 {
+			t.Errorf("bin %s has %d values, want 2", label, n)
+		}
+	}
+
+	constant, _ := NewDataFrameFromSeries(mustSeries(t, "x", []int64{1, 1, 1}))
+	if constant.QCut("x", 2).Error() == nil {
+		t.Error("expected error for repeated bin edges")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "x", []float64{0, 1, 2, 3, 4, 10, math.NaN()}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hist, err := df.Histogram("x", 2)
+	if err != nil {
+		t.Fatalf("Histogram error: %v", err)
+	}
+	if got := hist.columns["count"].Int64Slice(); !slices.Equal(got, []int64{5, 1}) {
+		t.Errorf("counts = %v, want [5 1]", got)
+	}
+	if got := hist.columns["upper"].Float64Slice(); !slices.Equal(got, []float64{5, 10}) {
+		t.Errorf("upper edges = %v, want [5 10]", got)
+	}
+
+	if _, err := df.Histogram("x", 0); err == nil {
+		t.Error("expected error for nbins = 0")
+	}
+	// Values on an inner edge open the upper bin
+	edges, _ := NewDataFrameFromSeries(mustSeries(t, "x", []float64{0, 1, 2, 3, 4}))
+	if hist, err := edges.Histogram("x", 4); err != nil || !slices.Equal(hist.columns["count"].Int64Slice(), []int64{1, 1, 1, 2}) {
+		t.Errorf("edge counts = %v (%v), want [1 1 1 2]", hist, err)
+	}
+
+	inf, _ := NewDataFrameFromSeries(mustSeries(t, "x", []float64{1, 2, math.Inf(1)}))
+	var oe *OtterError
+	if _, err := inf.Histogram("x", 2); !errors.As(err, &oe) || oe.Column != "x" || oe.Row != 2 {
+		t.Errorf("error = %v, want one at the infinite value", err)
+	}
+}
@@ -0,0 +1,286 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// DataFrameBuilder collects rows into growing typed columns and turns them
+// into a DataFrame once, instead of rebuilding a frame per record. Appending
+// is amortized O(1) per value:
+//
+//	b := otters.NewDataFrameBuilder(otters.Schema{
+//		{Name: "user", Type: otters.StringType},
+//		{Name: "latency_ms", Type: otters.Float64Type},
+//	})
+//	for event := range events {
+//		if err := b.AddRow(event.User, event.Latency); err != nil {
+//			return err
+//		}
+//	}
+//	df, err := b.Build()
+//
+// The typed Append methods add single values column by column without
+// boxing; every column must end up with the same number of values.
+type DataFrameBuilder struct {
+	schema  Schema
+	columns []any // one pointer to a typed slice per field, so appends do not box
+	err     error
+}
+
+// NewDataFrameBuilder creates a builder for frames with the given columns
+func NewDataFrameBuilder(schema Schema) *DataFrameBuilder {
+	b := &DataFrameBuilder{schema: append(Schema(nil), schema...)}
+	seen := make(map[string]bool, len(schema))
+	for _, field := range schema {
+		if field.Name == "" {
+			b.err = newOpError("NewDataFrameBuilder", "column name cannot be empty")
+			return b
+		}
+		if seen[field.Name] {
+			b.err = newColumnError("NewDataFrameBuilder", field.Name, "column specified more than once")
+			return b
+		}
+		seen[field.Name] = true
+		if getZeroValue(field.Type) == nil {
+			b.err = newColumnError("NewDataFrameBuilder", field.Name, "unsupported column type")
+			return b
+		}
+	}
+	b.reset()
+	return b
+}
+
+// reset starts new, empty columns.
+func (b *DataFrameBuilder) reset() {
+	b.columns = make([]any, len(b.schema))
+	for i, field := range b.schema {
+		switch field.Type {
+		case StringType:
+			b.columns[i] = new([]string)
+		case Int64Type:
+			b.columns[i] = new([]int64)
+		case Float64Type:
+			b.columns[i] = new([]float64)
+		case BoolType:
+			b.columns[i] = new([]bool)
+		case TimeType:
+			b.columns[i] = new([]time.Time)
+		}
+	}
+}
+
+// Grow makes room for n more rows without further allocation
+func (b *DataFrameBuilder) Grow(n int) {
+	if b.err != nil {
+		return
+	}
+	for _, column := range b.columns {
+		switch data := column.(type) {
+		case *[]string:
+			*data = slices.Grow(*data, n)
+		case *[]int64:
+			*data = slices.Grow(*data, n)
+		case *[]float64:
+			*data = slices.Grow(*data, n)
+		case *[]bool:
+			*data = slices.Grow(*data, n)
+		case *[]time.Time:
+			*data = slices.Grow(*data, n)
+		}
+	}
+}
+
+// Len returns the number of complete rows added so far: the length of the
+// shortest column.
+func (b *DataFrameBuilder) Len() int {
+	if len(b.columns) == 0 {
+		return 0
+	}
+	rows := math.MaxInt
+	for _, column := range b.columns {
+		rows = min(rows, builderColumnLen(column))
+	}
+	return rows
+}
+
+// builderColumnLen returns the number of values in a builder column.
+func builderColumnLen(column any) int {
+	switch data := column.(type) {
+	case *[]string:
+		return len(*data)
+	case *[]int64:
+		return len(*data)
+	case *[]float64:
+		return len(*data)
+	case *[]bool:
+		return len(*data)
+	case *[]time.Time:
+		return len(*data)
+	}
+	return 0
+}
+
+// builderColumnData returns the slice a builder column points to.
+func builderColumnData(column any) any {
+	switch data := column.(type) {
+	case *[]string:
+		return *data
+	case *[]int64:
+		return *data
+	case *[]float64:
+		return *data
+	case *[]bool:
+		return *data
+	case *[]time.Time:
+		return *data
+	}
+	return nil
+}
+
+// AddRow appends one row, with one value per column in schema order. Values
+// must have the column's Go type (string, int64, float64, bool or
+// time.Time), except that nil adds a missing value (NaN, zero time) to
+// float64 and time columns. A row that does not fit is rejected whole.
+func (b *DataFrameBuilder) AddRow(values ...any) error {
+	if b.err != nil {
+		return b.err
+	}
+	row := b.Len()
+	if len(values) != len(b.schema) {
+		return newRowError("DataFrameBuilder.AddRow", row,
+			fmt.Sprintf("got %d values, expected %d", len(values), len(b.schema)))
+	}
+
+	// Check every value before appending any, so a bad row leaves no trace
+	for i, field := range b.schema {
+		if !builderValueFits(field.Type, values[i]) {
+			return &OtterError{
+				Op:      "DataFrameBuilder.AddRow",
+				Column:  field.Name,
+				Row:     row,
+				Message: fmt.Sprintf("expected %s, got %T", field.Type, values[i]),
+			}
+		}
+	}
+
+	for i, value := range values {
+		switch data := b.columns[i].(type) {
+		case *[]string:
+			*data = append(*data, value.(string))
+		case *[]int64:
+			*data = append(*data, value.(int64))
+		case *[]float64:
+			v, ok := value.(float64)
+			if !ok {
+				v = math.NaN()
+			}
+			*data = append(*data, v)
+		case *[]bool:
+			*data = append(*data, value.(bool))
+		case *[]time.Time:
+			v, _ := value.(time.Time)
+			*data = append(*data, v)
+		}
+	}
+	return nil
+}
+
+// builderValueFits reports whether AddRow accepts value for a column of
+// type t.
+func builderValueFits(t ColumnType, value any) bool {
+	switch t {
+	case StringType:
+		_, ok := value.(string)
+		return ok
+	case Int64Type:
+		_, ok := value.(int64)
+		return ok
+	case Float64Type:
+		_, ok := value.(float64)
+		return ok || value == nil
+	case BoolType:
+		_, ok := value.(bool)
+		return ok
+	case TimeType:
+		_, ok := value.(time.Time)
+		return ok || value == nil
+	}
+	return false
+}
+
+// AppendString appends v to the string column at index column
+func (b *DataFrameBuilder) AppendString(column int, v string) {
+	appendBuilderValue(b, column, StringType, v)
+}
+
+// AppendInt64 appends v to the int64 column at index column
+func (b *DataFrameBuilder) AppendInt64(column int, v int64) {
+	appendBuilderValue(b, column, Int64Type, v)
+}
+
+// AppendFloat64 appends v to the float64 column at index column
+func (b *DataFrameBuilder) AppendFloat64(column int, v float64) {
+	appendBuilderValue(b, column, Float64Type, v)
+}
+
+// AppendBool appends v to the bool column at index column
+func (b *DataFrameBuilder) AppendBool(column int, v bool) {
+	appendBuilderValue(b, column, BoolType, v)
+}
+
+// AppendTime appends v to the time column at index column
+func (b *DataFrameBuilder) AppendTime(column int, v time.Time) {
+	appendBuilderValue(b, column, TimeType, v)
+}
+
+// appendBuilderValue backs the typed Append methods. A wrong column index
+// or type is recorded on the builder and reported by Build.
+func appendBuilderValue[T any](b *DataFrameBuilder, column int, t ColumnType, v T) {
+	if b.err != nil {
+		return
+	}
+	if column < 0 || column >= len(b.schema) {
+		b.err = newOpError("DataFrameBuilder.Append", fmt.Sprintf("column index %d out of range [0:%d]", column, len(b.schema)))
+		return
+	}
+	data, ok := b.columns[column].(*[]T)
+	if !ok {
+		b.err = newColumnError("DataFrameBuilder.Append", b.schema[column].Name,
+			fmt.Sprintf("column is %s, not %s", b.schema[column].Type, t))
+		return
+	}
+	*data = append(*data, v)
+}
+
+// Build returns the collected rows as a DataFrame and empties the builder
+// for reuse. The DataFrame takes over the builder's slices without copying.
+func (b *DataFrameBuilder) Build() (*DataFrame, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	series := make([]*Series, len(b.schema))
+	for i, field := range b.schema {
+		s, err := newSeriesOwned(field.Name, builderColumnData(b.columns[i]))
+		if err != nil {
+			return nil, wrapColumnError("DataFrameBuilder.Build", field.Name, err)
+		}
+		series[i] = s
+	}
+	for i := 1; i < len(series); i++ {
+		if series[i].Length != series[0].Length {
+			return nil, newColumnError("DataFrameBuilder.Build", series[i].Name,
+				fmt.Sprintf("column has %d values, expected %d", series[i].Length, series[0].Length))
+		}
+	}
+
+	df, err := NewDataFrameFromSeries(series...)
+	if err != nil {
+		return nil, err
+	}
+	b.reset()
+	return df, nil
+}
@@ -0,0 +1,110 @@
+package otters
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDataFrameBuilder(t *testing.T) {
+	b := NewDataFrameBuilder(Schema{
+		{Name: "name", Type: StringType},
+		{Name: "age", Type: Int64Type},
+		{Name: "score", Type: Float64Type},
+		{Name: "active", Type: BoolType},
+		{Name: "joined", Type: TimeType},
+	})
+	b.Grow(3)
+	joined := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if err := b.AddRow("Alice", int64(30), 91.5, true, joined); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddRow("Bob", int64(25), nil, false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	err := b.AddRow("Carol", 41, 70.0, true, joined)
+	var oe *OtterError
+	if !errors.As(err, &oe) || oe.Column != "age" || oe.Row != 2 {
+		t.Errorf("expected a row 2 age error, got %v", err)
+	}
+	if err := b.AddRow("Carol"); err == nil {
+		t.Error("expected error for a short row")
+	}
+	if b.Len() != 2 {
+		t.Errorf("rejected rows should not be added, Len() = %d", b.Len())
+	}
+
+	b.AppendString(0, "Dan")
+	b.AppendInt64(1, 52)
+	b.AppendFloat64(2, 64.25)
+	b.AppendBool(3, true)
+	b.AppendTime(4, joined)
+
+	df, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if df.Len() != 3 || df.Width() != 5 {
+		t.Fatalf("expected 3x5 frame, got %dx%d", df.Len(), df.Width())
+	}
+	if v, _ := df.Get(2, "name"); v != "Dan" {
+		t.Errorf("name[2] = %v, want Dan", v)
+	}
+	if v, _ := df.Get(1, "score"); !math.IsNaN(v.(float64)) {
+		t.Errorf("nil score should be NaN, got %v", v)
+	}
+	if v, _ := df.Get(1, "joined"); !v.(time.Time).IsZero() {
+		t.Errorf("nil time should be zero, got %v", v)
+	}
+
+	// Build hands its columns to the frame and starts afresh
+	if b.Len() != 0 {
+		t.Errorf("builder should be empty after Build, Len() = %d", b.Len())
+	}
+	if err := b.AddRow("Eve", int64(33), 88.0, false, joined); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := df.Get(0, "name"); v != "Alice" {
+		t.Errorf("reusing the builder changed a built frame: %v", v)
+	}
+}
+
+func TestDataFrameBuilderErrors(t *testing.T) {
+	if _, err := NewDataFrameBuilder(Schema{{Name: "a", Type: Int64Type}, {Name: "a", Type: StringType}}).Build(); err == nil {
+		t.Error("expected error for a duplicate column")
+	}
+
+	b := NewDataFrameBuilder(Schema{{Name: "a", Type: Int64Type}, {Name: "b", Type: StringType}})
+	b.AppendInt64(0, 1)
+	if _, err := b.Build(); err == nil {
+		t.Error("expected error for columns of different lengths")
+	}
+
+	b = NewDataFrameBuilder(Schema{{Name: "a", Type: Int64Type}})
+	b.AppendString(0, "x")
+	if _, err := b.Build(); err == nil {
+		t.Error("expected error appending a string to an int64 column")
+	}
+
+	b = NewDataFrameBuilder(Schema{{Name: "a", Type: Int64Type}})
+	b.AppendInt64(1, 1)
+	if _, err := b.Build(); err == nil {
+		t.Error("expected error for an out-of-range column index")
+	}
+}
+
+func BenchmarkDataFrameBuilder(b *testing.B) {
+	schema := Schema{{Name: "id", Type: Int64Type}, {Name: "value", Type: Float64Type}}
+	for i := 0; i < b.N; i++ {
+		builder := NewDataFrameBuilder(schema)
+		for j := range 10000 {
+			builder.AppendInt64(0, int64(j))
+			builder.AppendFloat64(1, float64(j))
+		}
+		if _, err := builder.Build(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package otters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// frameCache is the process-wide cache behind CachedRead.
+var frameCache = struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}{entries: make(map[string]*cacheEntry)}
+
+// cacheEntry holds one parsed file. ready is closed once df/err are set, so
+// concurrent callers wait for a single parse instead of starting their own.
+type cacheEntry struct {
+	path     string
+	ready    chan struct{}
+	df       *DataFrame
+	err      error
+	modTime  time.Time
+	size     int64
+	loadedAt time.Time
+}
+
+// CachedRead reads a CSV file through a process-wide in-memory cache, so
+// several callers share one parse of the same file. Entries are keyed by the
+// file path and options, and are re-read when the file's modification time
+// or size changes or when they are older than ttl (ttl <= 0 never expires).
+//
+// Each call returns its own copy of the cached DataFrame, so callers can
+// modify their result without affecting each other or the cache.
+func CachedRead(path string, options CSVOptions, ttl time.Duration) (*DataFrame, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, wrapError("CachedRead", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, wrapError("CachedRead", err)
+	}
+	key := fmt.Sprintf("%s|%+v", abs, CSVOptions{
+		HasHeader:        options.HasHeader,
+		Delimiter:        options.Delimiter,
+		SkipRows:         options.SkipRows,
+		MaxRows:          options.MaxRows,
+		Schema:           options.Schema,
+		DuplicateColumns: options.DuplicateColumns,
+	}) // Progress does not change the result
+
+	frameCache.mu.Lock()
+	entry, exists := frameCache.entries[key]
+	if exists && entry.stale(info, ttl) {
+		exists = false
+	}
+	if !exists {
+		entry = &cacheEntry{
+			path:    abs,
+			ready:   make(chan struct{}),
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		}
+		frameCache.entries[key] = entry
+	}
+	frameCache.mu.Unlock()
+
+	if !exists {
+		entry.df, entry.err = ReadCSVWithOptions(abs, options)
+		entry.loadedAt = time.Now()
+		close(entry.ready)
+
+		if entry.err != nil {
+			// Don't cache failures; the next call retries the read.
+			frameCache.mu.Lock()
+			if frameCache.entries[key] == entry {
+				delete(frameCache.entries, key)
+			}
+			frameCache.mu.Unlock()
+		}
+	}
+
+	<-entry.ready
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return entry.df.Copy(), nil
+}
+
+// stale reports whether the entry no longer reflects the file on disk or has
+// outlived the ttl. Entries still loading are never stale.
+func (e *cacheEntry) stale(info os.FileInfo, ttl time.Duration) bool {
+	select {
+	case <-e.ready:
+	default:
+		return false
+	}
+	if !info.ModTime().Equal(e.modTime) || info.Size() != e.size {
+		return true
+	}
+	return ttl > 0 && time.Since(e.loadedAt) > ttl
+}
+
+// InvalidateCache drops every cached entry for the given file.
+func InvalidateCache(path string) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return
+	}
+
+	frameCache.mu.Lock()
+	defer frameCache.mu.Unlock()
+	for key, entry := range frameCache.entries {
+		if entry.path == abs {
+			delete(frameCache.entries, key)
+		}
+	}
+}
+
+// ClearCache drops all cached entries.
+func ClearCache() {
+	frameCache.mu.Lock()
+	defer frameCache.mu.Unlock()
+	frameCache.entries = make(map[string]*cacheEntry)
+}
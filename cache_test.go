@@ -0,0 +1,136 @@
+package otters
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rewriteKeepingStat replaces file content with same-size data and restores
+// the modification time, so only a re-parse could observe the change.
+func rewriteKeepingStat(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCachedReadSharesParse(t *testing.T) {
+	defer ClearCache()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	rewriteKeepingStat(t, path, "id,v\n1,10\n", modTime)
+
+	first, err := CachedRead(path, CSVOptions{HasHeader: true, Delimiter: ','}, 0)
+	if err != nil {
+		t.Fatalf("CachedRead error: %v", err)
+	}
+
+	rewriteKeepingStat(t, path, "id,v\n2,20\n", modTime)
+	second, err := CachedRead(path, CSVOptions{HasHeader: true, Delimiter: ','}, 0)
+	if err != nil {
+		t.Fatalf("CachedRead error: %v", err)
+	}
+	if v, _ := second.Get(0, "id"); v != int64(1) {
+		t.Errorf("expected cached parse (id 1), got %v", v)
+	}
+
+	// Each caller gets an independent copy
+	if err := first.Set(0, "v", int64(99)); err != nil {
+		t.Fatal(err)
+	}
+	third, _ := CachedRead(path, CSVOptions{HasHeader: true, Delimiter: ','}, 0)
+	if v, _ := third.Get(0, "v"); v != int64(10) {
+		t.Errorf("mutating a returned frame leaked into the cache: %v", v)
+	}
+
+	// Different options are cached separately
+	noHeader, err := CachedRead(path, CSVOptions{HasHeader: false, Delimiter: ','}, 0)
+	if err != nil {
+		t.Fatalf("CachedRead error: %v", err)
+	}
+	if v, _ := noHeader.Get(1, "Column_0"); v != "2" {
+		t.Errorf("expected fresh parse for new options, got %v", v)
+	}
+}
+
+func TestCachedReadInvalidation(t *testing.T) {
+	defer ClearCache()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	opts := CSVOptions{HasHeader: true, Delimiter: ','}
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	rewriteKeepingStat(t, path, "id\n1\n", modTime)
+
+	if _, err := CachedRead(path, opts, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new modification time triggers a re-read
+	rewriteKeepingStat(t, path, "id\n2\n", modTime.Add(time.Minute))
+	df, _ := CachedRead(path, opts, 0)
+	if v, _ := df.Get(0, "id"); v != int64(2) {
+		t.Errorf("expected re-read after mtime change, got %v", v)
+	}
+
+	// Expired entries are re-read
+	rewriteKeepingStat(t, path, "id\n3\n", modTime.Add(time.Minute))
+	df, _ = CachedRead(path, opts, time.Nanosecond)
+	if v, _ := df.Get(0, "id"); v != int64(3) {
+		t.Errorf("expected re-read after ttl expiry, got %v", v)
+	}
+
+	// Explicit invalidation
+	rewriteKeepingStat(t, path, "id\n4\n", modTime.Add(time.Minute))
+	InvalidateCache(path)
+	df, _ = CachedRead(path, opts, 0)
+	if v, _ := df.Get(0, "id"); v != int64(4) {
+		t.Errorf("expected re-read after InvalidateCache, got %v", v)
+	}
+}
+
+func TestCachedReadErrors(t *testing.T) {
+	defer ClearCache()
+	if _, err := CachedRead(filepath.Join(t.TempDir(), "missing.csv"), CSVOptions{HasHeader: true, Delimiter: ','}, 0); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.csv")
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	rewriteKeepingStat(t, path, "a,b\n1\n", modTime)
+	opts := CSVOptions{HasHeader: true, Delimiter: ','}
+	if _, err := CachedRead(path, opts, 0); err == nil {
+		t.Fatal("expected parse error")
+	}
+
+	// Failures are not cached
+	rewriteKeepingStat(t, path, "a,b\n1,2", modTime)
+	if _, err := CachedRead(path, opts, 0); err != nil {
+		t.Errorf("expected retry after failed read, got %v", err)
+	}
+}
+
+func TestCachedReadConcurrent(t *testing.T) {
+	defer ClearCache()
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte("id\n1\n2\n3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			df, err := CachedRead(path, CSVOptions{HasHeader: true, Delimiter: ','}, time.Minute)
+			if err != nil || df.Len() != 3 {
+				t.Errorf("concurrent CachedRead = (%v, %v)", df, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
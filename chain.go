@@ -0,0 +1,77 @@
+package otters
+
+// Pipe applies a user-defined step within a fluent chain, so custom
+// transformations read in order with the built-in ones:
+//
+//	result := df.Filter("status", "==", "active").
+//		Pipe(addMargins).
+//		Sort("margin", false)
+//
+// fn is skipped if the DataFrame carries an error, which then propagates;
+// a nil result from fn becomes an error.
+func (df *DataFrame) Pipe(fn func(*DataFrame) *DataFrame) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+	return applyTransform(df, fn, "Pipe")
+}
+
+// Conditional is a branch in a fluent pipeline, started with
+// DataFrame.When. The first branch whose predicate holds has its transform
+// applied; Else or End finish the chain and return the resulting DataFrame.
+type Conditional struct {
+	df      *DataFrame
+	pending bool // the last predicate held and awaits its Then
+	matched bool // a branch has already been taken
+	result  *DataFrame
+}
+
+// When starts a conditional pipeline. pred is called with the DataFrame to
+// decide whether the following Then applies, e.g.
+//
+//	df.When(hasDuplicates).Then(dedup).Else(identity)
+//
+// Predicates and transforms are skipped if the DataFrame carries an error.
+func (df *DataFrame) When(pred func(*DataFrame) bool) *Conditional {
+	c := &Conditional{df: df, result: df}
+	return c.ElseWhen(pred)
+}
+
+// ElseWhen adds another branch, checked only if no earlier branch was taken
+func (c *Conditional) ElseWhen(pred func(*DataFrame) bool) *Conditional {
+	c.pending = !c.matched && c.df.err == nil && pred(c.df)
+	return c
+}
+
+// Then sets the transform for the preceding When or ElseWhen
+func (c *Conditional) Then(transform func(*DataFrame) *DataFrame) *Conditional {
+	if c.pending {
+		c.result = applyTransform(c.df, transform, "Then")
+		c.matched = true
+		c.pending = false
+	}
+	return c
+}
+
+// Else applies transform if no branch was taken and returns the result
+func (c *Conditional) Else(transform func(*DataFrame) *DataFrame) *DataFrame {
+	if !c.matched && c.df.err == nil {
+		return applyTransform(c.df, transform, "Else")
+	}
+	return c.result
+}
+
+// End finishes the chain, returning the DataFrame unchanged if no branch
+// was taken
+func (c *Conditional) End() *DataFrame {
+	return c.result
+}
+
+// applyTransform runs a pipeline step, turning a nil result into an error.
+func applyTransform(df *DataFrame, transform func(*DataFrame) *DataFrame, operation string) *DataFrame {
+	result := transform(df)
+	if result == nil {
+		return df.setError(newOpError(operation, "transform returned nil DataFrame"))
+	}
+	return result
+}
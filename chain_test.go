@@ -0,0 +1,88 @@
+package otters
+
+import "testing"
+
+func TestWhenThenElse(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"value": []int64{3, 1, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	small := func(d *DataFrame) bool { return d.Len() < 10 }
+	large := func(d *DataFrame) bool { return d.Len() >= 10 }
+	sorted := func(d *DataFrame) *DataFrame { return d.Sort("value", true) }
+	head := func(d *DataFrame) *DataFrame { return d.Head(1) }
+
+	result := df.When(small).Then(sorted).Else(head)
+	if result.Len() != 3 {
+		t.Fatalf("expected Then branch, got %d rows", result.Len())
+	}
+	if v, _ := result.Get(0, "value"); v != int64(1) {
+		t.Errorf("first value = %v, want 1", v)
+	}
+
+	if got := df.When(large).Then(sorted).Else(head); got.Len() != 1 {
+		t.Errorf("expected Else branch, got %d rows", got.Len())
+	}
+
+	chained := df.When(large).Then(head).ElseWhen(small).Then(sorted).End()
+	if v, _ := chained.Get(0, "value"); v != int64(1) {
+		t.Errorf("expected ElseWhen branch, first value = %v", v)
+	}
+
+	if got := df.When(large).Then(head).End(); got != df {
+		t.Error("End with no branch taken should return the DataFrame unchanged")
+	}
+
+	calls := 0
+	first := df.When(small).Then(head).ElseWhen(func(d *DataFrame) bool { calls++; return true }).Then(sorted).End()
+	if first.Len() != 1 || calls != 0 {
+		t.Errorf("only the first matching branch should run (rows %d, later predicate calls %d)", first.Len(), calls)
+	}
+}
+
+func TestWhenPropagatesErrors(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{"value": []int64{1}})
+
+	failed := df.Select("missing")
+	called := false
+	result := failed.When(func(*DataFrame) bool { called = true; return true }).
+		Then(func(d *DataFrame) *DataFrame { return d }).End()
+	if called || result.Error() == nil {
+		t.Error("predicates must not run on an error frame and the error must propagate")
+	}
+
+	inBranch := df.When(func(*DataFrame) bool { return true }).
+		Then(func(d *DataFrame) *DataFrame { return d.Select("missing") }).End()
+	if inBranch.Error() == nil {
+		t.Error("expected error from the branch transform")
+	}
+
+	if df.When(func(*DataFrame) bool { return false }).Else(func(*DataFrame) *DataFrame { return nil }).Error() == nil {
+		t.Error("expected error when a transform returns nil")
+	}
+}
+
+func TestPipe(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{"value": []int64{3, 1, 2}})
+	double := func(d *DataFrame) *DataFrame { return d.Arith("double", "value", "+", "value") }
+
+	result := df.Pipe(double).Sort("double", true)
+	if err := result.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if result.MustGet(0, "double") != int64(2) {
+		t.Errorf("first double = %v, want 2", result.MustGet(0, "double"))
+	}
+
+	called := false
+	failed := df.Select("missing").Pipe(func(d *DataFrame) *DataFrame { called = true; return d })
+	if called || failed.Error() == nil {
+		t.Error("Pipe must not run on an error frame and the error must propagate")
+	}
+	if df.Pipe(func(*DataFrame) *DataFrame { return nil }).Error() == nil {
+		t.Error("expected error when the step returns nil")
+	}
+}
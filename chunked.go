@@ -0,0 +1,333 @@
+package otters
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// ChunkSource loads one chunk of a ChunkedDataFrame. It is called each time
+// the chunk is needed, so a source that reads from disk keeps only the
+// chunk being processed in memory.
+type ChunkSource func() (*DataFrame, error)
+
+// ChunkedDataFrame is an ordered list of DataFrame chunks processed one at a
+// time, for datasets too large to hold in memory at once. Filter and Select
+// are recorded and applied to each chunk as it is loaded; GroupBy
+// aggregates each chunk separately and combines the partial results, so
+// only one chunk and the (small) aggregates are ever resident:
+//
+//	sales := otters.ReadCSVChunked("2023.csv", "2024.csv", "2025.csv")
+//	totals, err := sales.Filter("amount", ">", 0).GroupBy("region").Sum()
+//
+// The chunks must share the same columns; int64 and float64 columns combine
+// as float64 when results are stacked.
+type ChunkedDataFrame struct {
+	sources []ChunkSource
+	steps   []func(*DataFrame) *DataFrame
+	err     error
+}
+
+// NewChunkedDataFrame wraps DataFrames that are already in memory as chunks
+func NewChunkedDataFrame(chunks ...*DataFrame) *ChunkedDataFrame {
+	sources := make([]ChunkSource, len(chunks))
+	for i, chunk := range chunks {
+		sources[i] = func() (*DataFrame, error) { return chunk, chunk.Error() }
+	}
+	return &ChunkedDataFrame{sources: sources}
+}
+
+// NewChunkedDataFrameFromSources creates a ChunkedDataFrame whose chunks are
+// loaded on demand, in order, by sources.
+func NewChunkedDataFrameFromSources(sources ...ChunkSource) *ChunkedDataFrame {
+	return &ChunkedDataFrame{sources: slices.Clone(sources)}
+}
+
+// ReadCSVChunked treats each CSV file as one chunk. Files are read when
+// their chunk is processed, not up front.
+func ReadCSVChunked(filenames ...string) *ChunkedDataFrame {
+	sources := make([]ChunkSource, len(filenames))
+	for i, filename := range filenames {
+		sources[i] = func() (*DataFrame, error) { return ReadCSV(filename) }
+	}
+	return &ChunkedDataFrame{sources: sources}
+}
+
+// Chunk splits the DataFrame into chunks of at most rows rows. The chunks
+// are views sharing the frame's data (see View).
+func (df *DataFrame) Chunk(rows int) *ChunkedDataFrame {
+	if df.err != nil {
+		return &ChunkedDataFrame{err: df.err}
+	}
+	if rows <= 0 {
+		return &ChunkedDataFrame{err: newOpError("Chunk", "rows must be positive")}
+	}
+
+	chunks := make([]*DataFrame, 0, (df.length+rows-1)/rows)
+	for start := 0; start < df.length; start += rows {
+		chunks = append(chunks, df.View(start, min(start+rows, df.length)))
+	}
+	return NewChunkedDataFrame(chunks...)
+}
+
+// Chunks returns an iterator over successive frames of at most rows rows,
+// for feeding batch APIs or writing paginated output. Like Chunk, the
+// frames are views sharing the DataFrame's data:
+//
+//	for batch := range df.Chunks(500) {
+//		if err := upload(batch); err != nil {
+//			return err
+//		}
+//	}
+//
+// A DataFrame carrying an error, or a rows below 1, yields a single frame
+// carrying the error.
+func (df *DataFrame) Chunks(rows int) iter.Seq[*DataFrame] {
+	return func(yield func(*DataFrame) bool) {
+		if df.err != nil {
+			yield(df)
+			return
+		}
+		if rows <= 0 {
+			yield(df.setError(newOpError("Chunks", "rows must be positive")))
+			return
+		}
+		for start := 0; start < df.length; start += rows {
+			if !yield(df.View(start, min(start+rows, df.length))) {
+				return
+			}
+		}
+	}
+}
+
+// Error returns the error carried by the ChunkedDataFrame, if any. Errors in
+// chunks themselves surface when the chunks are processed.
+func (c *ChunkedDataFrame) Error() error {
+	return c.err
+}
+
+// NumChunks returns the number of chunks
+func (c *ChunkedDataFrame) NumChunks() int {
+	return len(c.sources)
+}
+
+// Filter keeps the rows of each chunk matching the condition (see Filter)
+func (c *ChunkedDataFrame) Filter(column, operator string, value any) *ChunkedDataFrame {
+	return c.withStep(func(df *DataFrame) *DataFrame { return df.Filter(column, operator, value) })
+}
+
+// Select keeps only the given columns of each chunk (see Select)
+func (c *ChunkedDataFrame) Select(columns ...string) *ChunkedDataFrame {
+	columns = slices.Clone(columns)
+	return c.withStep(func(df *DataFrame) *DataFrame { return df.Select(columns...) })
+}
+
+// withStep returns a copy of c that applies step to every chunk.
+func (c *ChunkedDataFrame) withStep(step func(*DataFrame) *DataFrame) *ChunkedDataFrame {
+	if c.err != nil {
+		return c
+	}
+	return &ChunkedDataFrame{
+		sources: c.sources,
+		steps:   append(slices.Clip(c.steps), step),
+	}
+}
+
+// Each loads the chunks in order, applies the recorded operations, and calls
+// fn with each result. It stops at the first error.
+func (c *ChunkedDataFrame) Each(fn func(i int, chunk *DataFrame) error) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	for i, source := range c.sources {
+		chunk, err := source()
+		for _, step := range c.steps {
+			if err != nil {
+				break
+			}
+			chunk = step(chunk)
+			err = chunk.Error()
+		}
+		if err == nil {
+			err = fn(i, chunk)
+		}
+		if err != nil {
+			return &OtterError{Op: "Chunked", Message: fmt.Sprintf("chunk %d: %v", i, err), Cause: err, Row: -1}
+		}
+	}
+	return nil
+}
+
+// Count returns the total number of rows across all chunks
+func (c *ChunkedDataFrame) Count() (int, error) {
+	total := 0
+	err := c.Each(func(_ int, chunk *DataFrame) error {
+		total += chunk.Len()
+		return nil
+	})
+	return total, err
+}
+
+// Collect stacks every chunk into one in-memory DataFrame
+func (c *ChunkedDataFrame) Collect() (*DataFrame, error) {
+	var frames []*DataFrame
+	err := c.Each(func(_ int, chunk *DataFrame) error {
+		frames = append(frames, chunk)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return concatFrames(frames, "Collect")
+}
+
+// ChunkedGroupBy groups the rows of a ChunkedDataFrame
+type ChunkedGroupBy struct {
+	chunked *ChunkedDataFrame
+	columns []string
+}
+
+// GroupBy groups rows across all chunks by the given columns. Aggregations
+// run per chunk and combine the partial results, giving the same values as
+// grouping the collected frame. Like GroupBy, non-numeric columns other
+// than the group columns are dropped.
+func (c *ChunkedDataFrame) GroupBy(columns ...string) *ChunkedGroupBy {
+	return &ChunkedGroupBy{chunked: c, columns: slices.Clone(columns)}
+}
+
+// Sum sums each numeric column per group
+func (cg *ChunkedGroupBy) Sum() (*DataFrame, error) {
+	partials, err := cg.partials("sum")
+	if err != nil {
+		return nil, err
+	}
+	return partials[0].GroupBy(cg.columns...).Sum()
+}
+
+// Min returns the minimum of each numeric column per group
+func (cg *ChunkedGroupBy) Min() (*DataFrame, error) {
+	partials, err := cg.partials("min")
+	if err != nil {
+		return nil, err
+	}
+	return partials[0].GroupBy(cg.columns...).Min()
+}
+
+// Max returns the maximum of each numeric column per group
+func (cg *ChunkedGroupBy) Max() (*DataFrame, error) {
+	partials, err := cg.partials("max")
+	if err != nil {
+		return nil, err
+	}
+	return partials[0].GroupBy(cg.columns...).Max()
+}
+
+// Count returns the number of rows per group
+func (cg *ChunkedGroupBy) Count() (*DataFrame, error) {
+	partials, err := cg.partials("count")
+	if err != nil {
+		return nil, err
+	}
+	return sumCounts(partials[0], cg.columns)
+}
+
+// Mean averages each numeric column per group, from per-chunk sums and
+// row counts.
+func (cg *ChunkedGroupBy) Mean() (*DataFrame, error) {
+	partials, err := cg.partials("sum", "count")
+	if err != nil {
+		return nil, err
+	}
+
+	sums, err := partials[0].GroupBy(cg.columns...).Sum()
+	if err != nil {
+		return nil, err
+	}
+	counts, err := sumCounts(partials[1], cg.columns)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both results hold the same groups in key order
+	n := counts.columns[counts.order[len(counts.order)-1]].Data.([]int64)
+	result := make([]*Series, 0, len(sums.order))
+	for _, colName := range sums.order {
+		series := sums.columns[colName]
+		if slices.Contains(cg.columns, colName) {
+			result = append(result, series)
+			continue
+		}
+		means := make([]float64, series.Length)
+		for i, sum := range series.Data.([]float64) {
+			means[i] = sum / float64(n[i])
+		}
+		mean, err := newSeriesOwned(colName, means)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, mean)
+	}
+	return NewDataFrameFromSeries(result...)
+}
+
+// partials aggregates every non-empty chunk with each operation and stacks
+// the per-chunk results, one frame per operation.
+func (cg *ChunkedGroupBy) partials(operations ...string) ([]*DataFrame, error) {
+	if len(cg.columns) == 0 {
+		return nil, newOpError("GroupBy", "at least one column must be specified")
+	}
+
+	frames := make([][]*DataFrame, len(operations))
+	err := cg.chunked.Each(func(_ int, chunk *DataFrame) error {
+		if chunk.Len() == 0 {
+			return nil
+		}
+		gb := chunk.GroupBy(cg.columns...)
+		for k, op := range operations {
+			var partial *DataFrame
+			var err error
+			switch op {
+			case "sum":
+				partial, err = gb.Sum()
+			case "min":
+				partial, err = gb.Min()
+			case "max":
+				partial, err = gb.Max()
+			case "count":
+				partial, err = gb.Count()
+			}
+			if err != nil {
+				return err
+			}
+			frames[k] = append(frames[k], partial)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(frames[0]) == 0 {
+		return nil, newOpError("GroupBy", "no rows to group")
+	}
+
+	stacked := make([]*DataFrame, len(operations))
+	for k := range operations {
+		if stacked[k], err = concatFrames(frames[k], "GroupBy"); err != nil {
+			return nil, err
+		}
+	}
+	return stacked, nil
+}
+
+// sumCounts combines stacked per-chunk Count results into total counts.
+func sumCounts(partial *DataFrame, columns []string) (*DataFrame, error) {
+	countName := partial.order[len(partial.order)-1]
+	return partial.GroupBy(columns...).AggFuncInt64(countName, countName, func(counts []int64) int64 {
+		var total int64
+		for _, c := range counts {
+			total += c
+		}
+		return total
+	})
+}
@@ -0,0 +1,169 @@
+package otters
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func chunkedTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	df, err := NewDataFrameFromMap(map[string]any{
+		"region": []string{"east", "west", "east", "north", "west", "east", "north"},
+		"units":  []int64{1, 2, 3, 4, 5, 6, 7},
+		"price":  []float64{1.5, 2, 2.5, 3, 3.5, 4, 4.5},
+		"note":   []string{"a", "b", "c", "d", "e", "f", "g"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func assertFramesClose(t *testing.T, name string, got, want *DataFrame) {
+	t.Helper()
+	if got.Len() != want.Len() || strings.Join(got.Columns(), ",") != strings.Join(want.Columns(), ",") {
+		t.Fatalf("%s: got %v with %d rows, want %v with %d rows", name, got.Columns(), got.Len(), want.Columns(), want.Len())
+	}
+	for _, col := range want.Columns() {
+		for i := range want.Len() {
+			g, _ := got.Get(i, col)
+			w, _ := want.Get(i, col)
+			gf, gNum := toFloat64(g)
+			wf, wNum := toFloat64(w)
+			if gNum && wNum && math.Abs(gf-wf) < 1e-9 {
+				continue
+			}
+			if g != w {
+				t.Errorf("%s: row %d %s = %v, want %v", name, i, col, g, w)
+			}
+		}
+	}
+}
+
+func TestChunkedGroupByMatchesCollected(t *testing.T) {
+	df := chunkedTestFrame(t)
+	chunked := df.Chunk(3)
+	if chunked.NumChunks() != 3 {
+		t.Fatalf("NumChunks = %d, want 3", chunked.NumChunks())
+	}
+
+	aggs := map[string]func(*ChunkedGroupBy) (*DataFrame, error){
+		"Sum":   (*ChunkedGroupBy).Sum,
+		"Mean":  (*ChunkedGroupBy).Mean,
+		"Min":   (*ChunkedGroupBy).Min,
+		"Max":   (*ChunkedGroupBy).Max,
+		"Count": (*ChunkedGroupBy).Count,
+	}
+	eager := map[string]func(*GroupBy) (*DataFrame, error){
+		"Sum":   (*GroupBy).Sum,
+		"Mean":  (*GroupBy).Mean,
+		"Min":   (*GroupBy).Min,
+		"Max":   (*GroupBy).Max,
+		"Count": (*GroupBy).Count,
+	}
+	for name, agg := range aggs {
+		got, err := agg(chunked.GroupBy("region"))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		want, err := eager[name](df.GroupBy("region"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertFramesClose(t, name, got, want)
+	}
+}
+
+func TestChunkedFilterSelectCollect(t *testing.T) {
+	df := chunkedTestFrame(t)
+	chunked := df.Chunk(2).Filter("units", ">", 2).Select("region", "units")
+
+	n, err := chunked.Count()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Errorf("Count = %d, want 5", n)
+	}
+
+	collected, err := chunked.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFramesClose(t, "Collect", collected, df.Filter("units", ">", 2).Select("region", "units"))
+
+	// A chunk with no rows left after filtering is skipped by GroupBy
+	sums, err := df.Chunk(2).Filter("units", ">", 4).GroupBy("region").Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sums.Len() != 3 {
+		t.Errorf("Sum over filtered chunks has %d groups, want 3", sums.Len())
+	}
+}
+
+func TestReadCSVChunked(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")}
+	contents := []string{"k,v\nx,1\ny,2\n", "k,v\nx,3.5\n"}
+	for i, f := range files {
+		if err := os.WriteFile(f, []byte(contents[i]), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sums, err := ReadCSVChunked(files...).GroupBy("k").Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := sums.Get(0, "v"); v != 4.5 {
+		t.Errorf("sum for x = %v, want 4.5", v)
+	}
+
+	_, err = ReadCSVChunked(files[0], filepath.Join(dir, "missing.csv")).Count()
+	var otterErr *OtterError
+	if !errors.As(err, &otterErr) || !strings.Contains(err.Error(), "chunk 1") {
+		t.Errorf("expected an error naming chunk 1, got %v", err)
+	}
+}
+
+func TestChunksIterator(t *testing.T) {
+	df := chunkedTestFrame(t)
+
+	var sizes []int
+	var batches []*DataFrame
+	for batch := range df.Chunks(3) {
+		if err := batch.Error(); err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, batch.Len())
+		batches = append(batches, batch)
+	}
+	if len(sizes) != 3 || sizes[0] != 3 || sizes[2] != 1 {
+		t.Errorf("chunk sizes = %v, want [3 3 1]", sizes)
+	}
+	joined, err := concatFrames(batches, "Chunks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFramesClose(t, "Chunks", joined, df)
+
+	seen := 0
+	for range df.Chunks(2) {
+		seen++
+		break
+	}
+	if seen != 1 {
+		t.Errorf("break should stop iteration, saw %d chunks", seen)
+	}
+
+	for batch := range df.Chunks(0) {
+		if batch.Error() == nil {
+			t.Error("expected error for non-positive rows")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package otters
+
+import "sync"
+
+// Concurrency
+//
+// A DataFrame may be read by any number of goroutines at once as long as
+// none of them modifies it. Only Set and AddColumn modify their receiver;
+// every other method leaves it untouched and returns a new DataFrame (or a
+// value), so filtering, sorting, grouping and exporting one shared frame
+// from many goroutines is safe without locking. Column data is shared
+// copy-on-write between frames, so writes to one frame never show through
+// in another.
+//
+// When goroutines must also write, share a ConcurrentDataFrame instead,
+// which guards a frame with a read/write lock.
+
+// ConcurrentDataFrame guards a DataFrame for use by goroutines that both
+// read and write it, such as a web service updating a frame it also serves
+// queries from. Reads run in parallel; writes get exclusive access.
+type ConcurrentDataFrame struct {
+	mu sync.RWMutex
+	df *DataFrame
+}
+
+// Concurrent returns a ConcurrentDataFrame holding a copy of the DataFrame,
+// so later changes to df itself do not bypass the lock.
+func (df *DataFrame) Concurrent() *ConcurrentDataFrame {
+	return &ConcurrentDataFrame{df: df.Copy()}
+}
+
+// Read calls fn with the frame while holding the read lock. fn may run
+// alongside other readers, so it must not call Set or AddColumn on the
+// frame; any frames it derives are its own.
+func (c *ConcurrentDataFrame) Read(fn func(df *DataFrame) error) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return fn(c.df)
+}
+
+// Write calls fn with the frame while holding the write lock, so fn may
+// modify it freely.
+func (c *ConcurrentDataFrame) Write(fn func(df *DataFrame) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fn(c.df)
+}
+
+// Snapshot returns an independent copy of the current frame that can be
+// used without any locking. Copying is cheap: column data is shared
+// copy-on-write.
+func (c *ConcurrentDataFrame) Snapshot() *DataFrame {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.df.Copy()
+}
+
+// Replace swaps in a copy of df as the guarded frame, for example after
+// reloading the data it was read from.
+func (c *ConcurrentDataFrame) Replace(df *DataFrame) {
+	replacement := df.Copy()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.df = replacement
+}
+
+// Get returns the value at the specified row and column
+func (c *ConcurrentDataFrame) Get(row int, column string) (any, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.df.Get(row, column)
+}
+
+// Set updates the value at the specified row and column
+func (c *ConcurrentDataFrame) Set(row int, column string, value any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.df.Set(row, column, value)
+}
+
+// AddColumn adds a new Series as a column
+func (c *ConcurrentDataFrame) AddColumn(series *Series) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// AddColumn reports failure on the frame it returns, leaving c.df intact
+	return c.df.AddColumn(series).Error()
+}
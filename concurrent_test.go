@@ -0,0 +1,64 @@
+package otters
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentDataFrame(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"id":    []int64{1, 2, 3, 4},
+		"score": []float64{1, 2, 3, 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared := df.Concurrent()
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				if w%2 == 0 {
+					if err := shared.Set(i%4, "score", float64(i)); err != nil {
+						t.Error(err)
+					}
+					continue
+				}
+				err := shared.Read(func(df *DataFrame) error {
+					return df.Filter("score", ">=", 0.0).Sort("id", false).Error()
+				})
+				if err != nil {
+					t.Error(err)
+				}
+				snapshot := shared.Snapshot()
+				if _, err := snapshot.Sum("score"); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if v, _ := df.Get(0, "score"); v != 1.0 {
+		t.Errorf("writes through the wrapper leaked into the source frame: %v", v)
+	}
+
+	before := shared.Snapshot()
+	if err := shared.AddColumn(mustSeries(t, "flag", []bool{true, false, true, false})); err != nil {
+		t.Fatal(err)
+	}
+	if err := shared.AddColumn(mustSeries(t, "flag", []bool{true, false, true, false})); err == nil {
+		t.Error("expected error adding a duplicate column")
+	}
+	if before.HasColumn("flag") || !shared.Snapshot().HasColumn("flag") {
+		t.Error("AddColumn should only affect the guarded frame")
+	}
+
+	shared.Replace(df)
+	if v, _ := shared.Get(0, "score"); v != 1.0 {
+		t.Errorf("Replace: score = %v, want 1", v)
+	}
+}
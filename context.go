@@ -0,0 +1,58 @@
+package otters
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Cancellation
+//
+// ReadCSVContext, SortByContext and GroupByContext take a context and give
+// up once it is done, so long operations started for a request can be
+// abandoned when the client goes away. The error they return is an
+// OtterError that matches ctx.Err() (context.Canceled or
+// context.DeadlineExceeded) under errors.Is.
+
+// checkRows is how many rows (or groups) a loop processes between
+// checks of its context and progress reports.
+const checkRows = 1024
+
+// contextError returns an OtterError wrapping ctx.Err() once ctx is done,
+// and nil otherwise or for a nil ctx.
+func contextError(op string, ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return wrapError(op, err)
+	}
+	return nil
+}
+
+// cancellableLess wraps a sort comparison so that once ctx is done it
+// degrades to comparing row indices, letting a running sort finish quickly
+// with an order the caller then discards. stop releases the watch on ctx.
+func cancellableLess(ctx context.Context, less func(a, b int) bool) (wrapped func(a, b int) bool, stop func() bool) {
+	var canceled atomic.Bool
+	stop = context.AfterFunc(ctx, func() { canceled.Store(true) })
+	return func(a, b int) bool {
+		if canceled.Load() {
+			return a < b
+		}
+		return less(a, b)
+	}, stop
+}
+
+// SortByContext is SortByWithOptions that abandons the sort once ctx is
+// done, leaving the error on the DataFrame.
+func (df *DataFrame) SortByContext(ctx context.Context, columns []string, ascending []bool, options SortOptions) *DataFrame {
+	return df.sortBy(ctx, columns, ascending, options)
+}
+
+// GroupByContext is GroupBy whose aggregations (Sum, Mean, Count, Min, Max,
+// Median, Std, Var) and Apply stop with an error once ctx is done.
+func (df *DataFrame) GroupByContext(ctx context.Context, columns ...string) *GroupBy {
+	gb := df.GroupBy(columns...)
+	gb.ctx = ctx
+	return gb
+}
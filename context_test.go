@@ -0,0 +1,71 @@
+package otters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContextCancellation(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var csvData strings.Builder
+	csvData.WriteString("id,group\n")
+	for i := range 3000 {
+		fmt.Fprintf(&csvData, "%d,g%d\n", i, i%5)
+	}
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(csvData.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	options := CSVOptions{HasHeader: true, Delimiter: ','}
+
+	if _, err := ReadCSVContext(canceled, path, options); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadCSVContext: expected context.Canceled, got %v", err)
+	}
+	df, err := ReadCSVContext(context.Background(), path, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := df.Copy().SortByContext(canceled, []string{"group"}, []bool{true}, SortOptions{}).Error(); !errors.Is(err, context.Canceled) {
+		t.Errorf("SortByContext: expected context.Canceled, got %v", err)
+	}
+	if sorted := df.SortByContext(context.Background(), []string{"group"}, []bool{true}, SortOptions{}); sorted.Error() != nil || sorted.Len() != 3000 {
+		t.Errorf("SortByContext with a live context: %v", sorted.Error())
+	}
+
+	if _, err := df.GroupByContext(canceled, "group").Sum(); !errors.Is(err, context.Canceled) {
+		t.Errorf("GroupByContext Sum: expected context.Canceled, got %v", err)
+	}
+	_, err = df.GroupByContext(canceled, "group").Apply(func(_ []string, g *DataFrame) (*DataFrame, error) { return g, nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GroupByContext Apply: expected context.Canceled, got %v", err)
+	}
+	if sums, err := df.GroupByContext(context.Background(), "group").Sum(); err != nil || sums.Len() != 5 {
+		t.Errorf("GroupByContext with a live context: %v", err)
+	}
+}
+
+func TestCancellableLessStopsComparing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	descending := func(a, b int) bool { return a > b }
+	less, stop := cancellableLess(ctx, descending)
+	defer stop()
+
+	if !less(2, 1) {
+		t.Fatal("comparison should use the wrapped less before cancellation")
+	}
+	cancel()
+	// AfterFunc sets the flag asynchronously; spin until it lands
+	for less(2, 1) {
+	}
+	if !less(1, 2) {
+		t.Error("comparison should fall back to row order after cancellation")
+	}
+}
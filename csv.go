@@ -1,10 +1,14 @@
 package otters
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,28 +24,60 @@ func ReadCSV(filename string) (*DataFrame, error) {
 	})
 }
 
-// ReadCSVWithOptions reads a CSV file with custom options
+// ReadCSVWithOptions reads a CSV file with custom options.
+//
+// With options.Schema set, the columns are parsed as the declared types
+// instead of inferred ones. A header row must name exactly the schema's
+// columns, in any order; without one, the schema names the columns in
+// order. A cell that does not parse is an error naming its column and row
+// (counting data rows from 0), and an empty cell is only allowed in a
+// string column or a Nullable float64 or time column.
 func ReadCSVWithOptions(filename string, options CSVOptions) (*DataFrame, error) {
+	return ReadCSVContext(context.Background(), filename, options)
+}
+
+// ReadCSVContext is ReadCSVWithOptions that stops reading once ctx is done,
+// returning an error that matches ctx.Err() under errors.Is.
+func ReadCSVContext(ctx context.Context, filename string, options CSVOptions) (*DataFrame, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, wrapError("ReadCSV", err)
 	}
 	defer file.Close()
 
+	info, err := file.Stat()
+	if err != nil {
+		return nil, wrapError("ReadCSV", err)
+	}
+
 	reader := csv.NewReader(file)
-	reader.Comma = options.Delimiter
+	reader.Comma = options.delimiter()
 	reader.TrimLeadingSpace = true
 
 	if err := skipRows(reader, options.SkipRows, "ReadCSV"); err != nil {
 		return nil, err
 	}
 
-	headers, rows, err := readCSVData(reader, options, "ReadCSV")
+	tick := csvTick(ctx, reader, options.Progress, int(info.Size()), "ReadCSV")
+	headers, rows, err := readCSVData(reader, options, "ReadCSV", tick)
 	if err != nil {
 		return nil, err
 	}
+	reportDone(options.Progress, int(info.Size()))
 
-	return buildDataFrameFromRows(headers, rows)
+	return buildCSVFrame(headers, rows, options, "ReadCSV")
+}
+
+// csvTick returns the callback the CSV row loops make every checkRows
+// rows: it reports the bytes read so far to progress (if set) and fails
+// once ctx is done.
+func csvTick(ctx context.Context, reader *csv.Reader, progress ProgressFunc, total int, operation string) func() error {
+	return func() error {
+		if progress != nil {
+			progress(int(reader.InputOffset()), total)
+		}
+		return contextError(operation, ctx)
+	}
 }
 
 func skipRows(reader *csv.Reader, skipCount int, operation string) error {
@@ -56,14 +92,14 @@ func skipRows(reader *csv.Reader, skipCount int, operation string) error {
 	return nil
 }
 
-func readCSVData(reader *csv.Reader, options CSVOptions, operation string) ([]string, [][]string, error) {
+func readCSVData(reader *csv.Reader, options CSVOptions, operation string, tick func() error) ([]string, [][]string, error) {
 	if options.HasHeader {
-		return readCSVWithHeaders(reader, options, operation)
+		return readCSVWithHeaders(reader, options, operation, tick)
 	}
-	return readCSVWithoutHeaders(reader, options, operation)
+	return readCSVWithoutHeaders(reader, options, operation, tick)
 }
 
-func readCSVWithHeaders(reader *csv.Reader, options CSVOptions, operation string) ([]string, [][]string, error) {
+func readCSVWithHeaders(reader *csv.Reader, options CSVOptions, operation string, tick func() error) ([]string, [][]string, error) {
 	headers, err := reader.Read()
 	if err != nil {
 		if err == io.EOF {
@@ -75,12 +111,34 @@ func readCSVWithHeaders(reader *csv.Reader, options CSVOptions, operation string
 	for i, header := range headers {
 		headers[i] = cleanHeader(header)
 	}
+	names, kept, err := resolveColumnNames(headers, options.DuplicateColumns, operation)
+	if err != nil {
+		return nil, nil, err
+	}
+	if options.Schema != nil {
+		if err := checkCSVHeaders(names, options.Schema, operation); err != nil {
+			return nil, nil, err
+		}
+	}
 
-	rows, err := readDataRows(reader, headers, options, operation)
-	return headers, rows, err
+	rows, err := readDataRows(reader, headers, options, operation, tick)
+	if err != nil {
+		return nil, nil, err
+	}
+	if kept != nil {
+		// Drop the cells of repeated columns, in place
+		for i, row := range rows {
+			cells := row[:0]
+			for _, col := range kept {
+				cells = append(cells, row[col])
+			}
+			rows[i] = cells
+		}
+	}
+	return names, rows, nil
 }
 
-func readCSVWithoutHeaders(reader *csv.Reader, options CSVOptions, operation string) ([]string, [][]string, error) {
+func readCSVWithoutHeaders(reader *csv.Reader, options CSVOptions, operation string, tick func() error) ([]string, [][]string, error) {
 	firstRow, err := reader.Read()
 	if err != nil {
 		if err == io.EOF {
@@ -90,6 +148,16 @@ func readCSVWithoutHeaders(reader *csv.Reader, options CSVOptions, operation str
 	}
 
 	headers := generateHeaders(len(firstRow))
+	if options.Schema != nil {
+		// Without a header row the schema names the columns, in order
+		if len(firstRow) != len(options.Schema) {
+			return nil, nil, newOpError(operation,
+				fmt.Sprintf("row 1 has %d columns, schema has %d", len(firstRow), len(options.Schema)))
+		}
+		for i, field := range options.Schema {
+			headers[i] = field.Name
+		}
+	}
 	allRows := [][]string{firstRow}
 
 	for {
@@ -101,6 +169,11 @@ func readCSVWithoutHeaders(reader *csv.Reader, options CSVOptions, operation str
 			return nil, nil, wrapError(operation, err)
 		}
 		allRows = append(allRows, row)
+		if len(allRows)%checkRows == 0 {
+			if err := tick(); err != nil {
+				return nil, nil, err
+			}
+		}
 
 		if options.MaxRows > 0 && len(allRows) >= options.MaxRows {
 			break
@@ -118,7 +191,7 @@ func generateHeaders(count int) []string {
 	return headers
 }
 
-func readDataRows(reader *csv.Reader, headers []string, options CSVOptions, operation string) ([][]string, error) {
+func readDataRows(reader *csv.Reader, headers []string, options CSVOptions, operation string, tick func() error) ([][]string, error) {
 	var rows [][]string
 	rowCount := 0
 
@@ -138,6 +211,11 @@ func readDataRows(reader *csv.Reader, headers []string, options CSVOptions, oper
 
 		rows = append(rows, row)
 		rowCount++
+		if rowCount%checkRows == 0 {
+			if err := tick(); err != nil {
+				return nil, err
+			}
+		}
 
 		if options.MaxRows > 0 && rowCount >= options.MaxRows {
 			break
@@ -155,23 +233,40 @@ func (df *DataFrame) WriteCSV(filename string) error {
 	})
 }
 
-// WriteCSVWithOptions writes a DataFrame to CSV with custom options
+// WriteCSVWithOptions writes a DataFrame to CSV with custom options. With
+// options.Atomic the file is replaced only once it has been written in
+// full; see CSVOptions.
 func (df *DataFrame) WriteCSVWithOptions(filename string, options CSVOptions) error {
 	if df.err != nil {
 		return df.err
 	}
 
+	if options.Atomic {
+		return writeFileAtomic(filename, "WriteCSV", func(w io.Writer) error {
+			return df.writeCSV(w, options)
+		})
+	}
+
 	// Create the file
 	file, err := os.Create(filename)
 	if err != nil {
 		return wrapError("WriteCSV", err)
 	}
-	defer file.Close()
+	if err := df.writeCSV(file, options); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return wrapError("WriteCSV", err)
+	}
+	return nil
+}
 
+// writeCSV writes the header and rows of a CSV file to w.
+func (df *DataFrame) writeCSV(w io.Writer, options CSVOptions) error {
 	// Create CSV writer
-	writer := csv.NewWriter(file)
-	writer.Comma = options.Delimiter
-	defer writer.Flush()
+	writer := csv.NewWriter(w)
+	writer.Comma = options.delimiter()
 
 	// Write headers if requested
 	if options.HasHeader {
@@ -188,7 +283,7 @@ func (df *DataFrame) WriteCSVWithOptions(filename string, options CSVOptions) er
 			if err != nil {
 				return wrapColumnError("WriteCSV", colName, err)
 			}
-			row = append(row, formatValueForCSV(value))
+			row = append(row, formatCSVCell(value, options))
 		}
 
 		if err := writer.Write(row); err != nil {
@@ -196,6 +291,51 @@ func (df *DataFrame) WriteCSVWithOptions(filename string, options CSVOptions) er
 		}
 	}
 
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return wrapError("WriteCSV", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes filename through a temporary file in the same
+// directory that is renamed over it once write succeeds, so readers see
+// either the old file or the complete new one. The new file keeps the
+// permissions of the file it replaces, or gets 0644.
+func writeFileAtomic(filename, operation string, write func(w io.Writer) error) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return wrapError(operation, err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err := write(tmp); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		return wrapError(operation, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return wrapError(operation, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return wrapError(operation, err)
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return wrapError(operation, err)
+	}
+	committed = true
 	return nil
 }
 
@@ -212,23 +352,127 @@ func ReadCSVFromString(data string) (*DataFrame, error) {
 // ReadCSVFromStringWithOptions reads CSV data from a string with options
 func ReadCSVFromStringWithOptions(data string, options CSVOptions) (*DataFrame, error) {
 	reader := csv.NewReader(strings.NewReader(data))
-	reader.Comma = options.Delimiter
+	reader.Comma = options.delimiter()
 	reader.TrimLeadingSpace = true
 
 	if err := skipRows(reader, options.SkipRows, "ReadCSVFromString"); err != nil {
 		return nil, err
 	}
 
-	headers, rows, err := readCSVData(reader, options, "ReadCSVFromString")
+	tick := csvTick(nil, reader, options.Progress, len(data), "ReadCSVFromString")
+	headers, rows, err := readCSVData(reader, options, "ReadCSVFromString", tick)
 	if err != nil {
 		return nil, err
 	}
+	reportDone(options.Progress, len(data))
 
-	return buildDataFrameFromRows(headers, rows)
+	return buildCSVFrame(headers, rows, options, "ReadCSVFromString")
 }
 
 // Helper functions
 
+// buildCSVFrame builds the DataFrame for a CSV read, with the types from
+// options.Schema if one is given and inferred ones otherwise.
+func buildCSVFrame(headers []string, rows [][]string, options CSVOptions, operation string) (*DataFrame, error) {
+	if options.Schema == nil {
+		df, err := buildDataFrameFromRows(headers, rows)
+		if err != nil {
+			return nil, err
+		}
+		df.warnings = emptyCellWarnings(df, rows, operation)
+		if err := strictEmptyCells(df.warnings); err != nil {
+			return nil, err
+		}
+		return df, nil
+	}
+	if headers == nil {
+		// Empty input: the schema's columns, with no rows
+		for _, field := range options.Schema {
+			headers = append(headers, field.Name)
+		}
+	}
+
+	fields := make(map[string]Field, len(options.Schema))
+	for _, field := range options.Schema {
+		fields[field.Name] = field
+	}
+	series := make([]*Series, len(headers))
+	for col, header := range headers {
+		field := fields[header]
+		var data any
+		var err error
+		switch field.Type {
+		case StringType:
+			data, err = parseCSVColumn(rows, col, field, "", operation)
+		case Int64Type:
+			data, err = parseCSVColumn(rows, col, field, int64(0), operation)
+		case Float64Type:
+			data, err = parseCSVColumn(rows, col, field, math.NaN(), operation)
+		case BoolType:
+			data, err = parseCSVColumn(rows, col, field, false, operation)
+		case TimeType:
+			data, err = parseCSVColumn(rows, col, field, time.Time{}, operation)
+		default:
+			err = newColumnError(operation, header, "unsupported column type in schema")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if series[col], err = newSeriesOwned(header, data); err != nil {
+			return nil, wrapColumnError(operation, header, err)
+		}
+	}
+	return NewDataFrameFromSeries(series...)
+}
+
+// parseCSVColumn converts column col of the rows to field's type. An empty
+// cell is the missing value in a Nullable float64 or time column and an
+// error in any other non-string column.
+func parseCSVColumn[T any](rows [][]string, col int, field Field, missing T, operation string) ([]T, error) {
+	canBeMissing := field.Nullable && (field.Type == Float64Type || field.Type == TimeType)
+	data := make([]T, len(rows))
+	for r, row := range rows {
+		cell := strings.TrimSpace(row[col])
+		if cell == "" && field.Type != StringType {
+			if !canBeMissing {
+				return nil, &OtterError{Op: operation, Column: field.Name, Row: r, Message: "missing value"}
+			}
+			data[r] = missing
+			continue
+		}
+		value, err := ConvertValue(cell, field.Type)
+		if err != nil {
+			return nil, &OtterError{Op: operation, Column: field.Name, Row: r,
+				Message: fmt.Sprintf("cannot parse %q as %s", cell, field.Type), Cause: err}
+		}
+		data[r] = value.(T)
+	}
+	return data, nil
+}
+
+// checkCSVHeaders rejects a header row that does not have exactly the
+// schema's columns, before any data is read.
+func checkCSVHeaders(headers []string, schema Schema, operation string) error {
+	var problems []string
+	for i, field := range schema {
+		if slices.ContainsFunc(schema[:i], func(f Field) bool { return f.Name == field.Name }) {
+			return newColumnError(operation, field.Name, "column specified more than once in schema")
+		}
+		if !slices.Contains(headers, field.Name) {
+			problems = append(problems, fmt.Sprintf("missing column %s", field.Name))
+		}
+	}
+	for _, header := range headers {
+		if !slices.ContainsFunc(schema, func(f Field) bool { return f.Name == header }) {
+			problems = append(problems, fmt.Sprintf("unexpected column %s", header))
+		}
+	}
+	if len(problems) > 0 {
+		return newOpError(operation, "header does not match schema: "+strings.Join(problems, "; "))
+	}
+	return nil
+}
+
 // buildDataFrameFromRows constructs a DataFrame from headers and string data rows
 func buildDataFrameFromRows(headers []string, rows [][]string) (*DataFrame, error) {
 	if len(headers) == 0 {
@@ -387,6 +631,29 @@ func formatValueForCSV(value any) string {
 	}
 }
 
+// formatCSVCell formats a value for WriteCSVWithOptions, applying the
+// float and time formats of options.
+func formatCSVCell(value any, options CSVOptions) string {
+	switch v := value.(type) {
+	case float64:
+		if options.FloatPrecision > 0 || options.FloatScientific {
+			format, precision := byte('f'), options.FloatPrecision
+			if options.FloatScientific {
+				format = 'e'
+			}
+			if precision == 0 {
+				precision = -1
+			}
+			return strconv.FormatFloat(v, format, precision, 64)
+		}
+	case time.Time:
+		if options.TimeLayout != "" && !v.IsZero() {
+			return v.Format(options.TimeLayout)
+		}
+	}
+	return formatValueForCSV(value)
+}
+
 // CSV utility functions for advanced use cases
 
 // DetectDelimiter attempts to detect the delimiter used in a CSV file
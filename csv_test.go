@@ -1,8 +1,10 @@
 package otters
 
 import (
+	"errors"
 	"math"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -718,3 +720,181 @@ func TestCSVFileOperations(t *testing.T) {
 		t.Errorf("MaxRows: got %d rows, want 2", maxRows)
 	}
 }
+
+func TestReadCSVWithSchema(t *testing.T) {
+	schema := Schema{
+		{Name: "zip", Type: StringType},
+		{Name: "count", Type: Int64Type},
+		{Name: "score", Type: Float64Type, Nullable: true},
+	}
+	options := CSVOptions{HasHeader: true, Delimiter: ',', Schema: schema}
+
+	// Columns may come in any order; "007" stays a string, "3" a float
+	df, err := ReadCSVFromStringWithOptions("count,zip,score\n1,007,3\n2,010,\n", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := df.Columns(); len(got) != 3 || got[0] != "count" || got[1] != "zip" {
+		t.Errorf("columns = %v, want file order", got)
+	}
+	for _, field := range schema {
+		if colType, _ := df.GetColumnType(field.Name); colType != field.Type {
+			t.Errorf("%s type = %v, want %v", field.Name, colType, field.Type)
+		}
+	}
+	if zip, _ := df.Get(0, "zip"); zip != "007" {
+		t.Errorf("zip = %v, want 007", zip)
+	}
+	if score, _ := df.Get(1, "score"); !math.IsNaN(score.(float64)) {
+		t.Errorf("empty nullable score = %v, want NaN", score)
+	}
+
+	// Without a header row the schema names the columns
+	noHeader := options
+	noHeader.HasHeader = false
+	df, err = ReadCSVFromStringWithOptions("007,1,2.5\n", noHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := df.Get(0, "count"); count != int64(1) {
+		t.Errorf("count = %v, want 1", count)
+	}
+
+	// An empty input gives the schema's columns with no rows
+	df, err = ReadCSVFromStringWithOptions("", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if df.Len() != 0 || df.Width() != 3 {
+		t.Errorf("empty input: %d rows, %d columns; want 0, 3", df.Len(), df.Width())
+	}
+
+	var otterErr *OtterError
+	_, err = ReadCSVFromStringWithOptions("zip,count,score\n1,2,3\n4,x,6\n", options)
+	if !errors.As(err, &otterErr) || otterErr.Column != "count" || otterErr.Row != 1 {
+		t.Errorf("bad cell: got %v, want an error at column count, row 1", err)
+	}
+	_, err = ReadCSVFromStringWithOptions("zip,count,score\n1,,3\n", options)
+	if !errors.As(err, &otterErr) || otterErr.Column != "count" || otterErr.Row != 0 {
+		t.Errorf("empty int cell: got %v, want an error at column count, row 0", err)
+	}
+
+	for _, data := range []string{
+		"zip,count\n1,2\n",                 // missing column
+		"zip,count,score,extra\n1,2,3,4\n", // unexpected column
+	} {
+		if _, err := ReadCSVFromStringWithOptions(data, options); err == nil {
+			t.Errorf("header %q: expected an error", data[:strings.IndexByte(data, '\n')])
+		}
+	}
+	if _, err := ReadCSVFromStringWithOptions("1,2\n", noHeader); err == nil {
+		t.Error("short row without header: expected an error")
+	}
+}
+
+func TestReadCSVDuplicateHeaders(t *testing.T) {
+	data := "id,val,val\n1,a,b\n2,c,d\n"
+	options := CSVOptions{HasHeader: true, Delimiter: ','}
+
+	if _, err := ReadCSVFromStringWithOptions(data, options); err == nil {
+		t.Error("expected an error for a repeated header")
+	}
+
+	options.DuplicateColumns = DuplicateSuffix
+	df, err := ReadCSVFromStringWithOptions(data, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := df.Get(1, "val_1"); v != "d" {
+		t.Errorf("val_1 = %v, want d", v)
+	}
+
+	options.DuplicateColumns = DuplicateKeepFirst
+	df, err = ReadCSVFromStringWithOptions(data, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if df.Width() != 2 {
+		t.Errorf("keep-first: %d columns, want 2", df.Width())
+	}
+	if v, _ := df.Get(1, "val"); v != "c" {
+		t.Errorf("val = %v, want c", v)
+	}
+}
+
+func TestWriteCSVFormatting(t *testing.T) {
+	day := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "amount", []float64{1.5, 1234567.891, math.NaN()}),
+		mustSeries(t, "at", []time.Time{day, day, {}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		options CSVOptions
+		want    string
+	}{
+		{"default", CSVOptions{},
+			"1.5,2024-05-01 09:30:00\n1234567.891,2024-05-01 09:30:00\nNaN,\n"},
+		{"precision", CSVOptions{FloatPrecision: 2, TimeLayout: "2006-01-02"},
+			"1.50,2024-05-01\n1234567.89,2024-05-01\nNaN,\n"},
+		{"scientific", CSVOptions{FloatScientific: true, FloatPrecision: 3, TimeLayout: time.RFC3339},
+			"1.500e+00,2024-05-01T09:30:00Z\n1.235e+06,2024-05-01T09:30:00Z\nNaN,\n"},
+		{"shortest scientific", CSVOptions{FloatScientific: true},
+			"1.5e+00,2024-05-01 09:30:00\n1.234567891e+06,2024-05-01 09:30:00\nNaN,\n"},
+	}
+	for _, c := range cases {
+		path := t.TempDir() + "/out.csv"
+		c.options.Delimiter = ','
+		if err := df.WriteCSVWithOptions(path, c.options); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != c.want {
+			t.Errorf("%s:\n got %q\nwant %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestWriteCSVAtomic(t *testing.T) {
+	df, err := NewDataFrameFromSeries(mustSeries(t, "id", []int64{1, 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	path := dir + "/out.csv"
+	if err := os.WriteFile(path, []byte("old\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// An invalid delimiter fails the write after the file would have been
+	// created; the previous contents must survive
+	err = df.WriteCSVWithOptions(path, CSVOptions{HasHeader: true, Delimiter: '"', Atomic: true})
+	if err == nil {
+		t.Fatal("expected an error for an invalid delimiter")
+	}
+	if got, _ := os.ReadFile(path); string(got) != "old\n" {
+		t.Errorf("after a failed write the file holds %q, want the old contents", got)
+	}
+
+	if err := df.WriteCSVWithOptions(path, CSVOptions{HasHeader: true, Delimiter: ',', Atomic: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "id\n1\n2\n" {
+		t.Errorf("file holds %q, want the new contents", got)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want the replaced file's 0600", info.Mode().Perm())
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d entries, want only the CSV (no temporary files)", len(entries))
+	}
+}
@@ -2,13 +2,39 @@ package otters
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 )
 
-// NewDataFrameFromSeries creates a DataFrame from a collection of Series
+// NewDataFrameFromSeries creates a DataFrame from a collection of Series.
+// Two Series with the same name are an error; see
+// NewDataFrameFromSeriesWithPolicy for other ways to handle them.
 func NewDataFrameFromSeries(series ...*Series) (*DataFrame, error) {
+	return NewDataFrameFromSeriesWithPolicy(DuplicateError, series...)
+}
+
+// DuplicateColumnPolicy decides what happens when columns given to a
+// constructor, or named by a CSV header row, share a name.
+type DuplicateColumnPolicy int
+
+const (
+	// DuplicateError fails, naming the repeated column.
+	DuplicateError DuplicateColumnPolicy = iota
+	// DuplicateSuffix renames each repeat of a name to name_1, name_2 and so
+	// on, skipping names already in use.
+	DuplicateSuffix
+	// DuplicateKeepFirst keeps the first column of each name and drops the
+	// repeats.
+	DuplicateKeepFirst
+)
+
+// NewDataFrameFromSeriesWithPolicy creates a DataFrame from a collection
+// of Series, handling Series with the same name according to policy. The
+// given Series are never renamed; a renamed column shares their data.
+func NewDataFrameFromSeriesWithPolicy(policy DuplicateColumnPolicy, series ...*Series) (*DataFrame, error) {
 	if len(series) == 0 {
 		return NewDataFrame(), nil
 	}
@@ -18,10 +44,27 @@ func NewDataFrameFromSeries(series ...*Series) (*DataFrame, error) {
 		return nil, err
 	}
 
+	names := make([]string, len(series))
+	for i, s := range series {
+		names[i] = s.Name
+	}
+	names, kept, err := resolveColumnNames(names, policy, "NewDataFrameFromSeries")
+	if err != nil {
+		return nil, err
+	}
+
 	df := NewDataFrame()
 	df.length = series[0].Length
 
-	for _, s := range series {
+	for i, name := range names {
+		s := series[i]
+		if kept != nil {
+			s = series[kept[i]]
+		}
+		if s.Name != name {
+			s = s.share()
+			s.Name = name
+		}
 		if err := df.addSeriesUnsafe(s); err != nil {
 			return nil, err
 		}
@@ -30,6 +73,46 @@ func NewDataFrameFromSeries(series ...*Series) (*DataFrame, error) {
 	return df, nil
 }
 
+// resolveColumnNames applies policy to a list of column names, returning
+// the names to use. If repeats were dropped, kept holds the positions of
+// the remaining columns; otherwise it is nil and the names line up with
+// the input.
+func resolveColumnNames(names []string, policy DuplicateColumnPolicy, operation string) (resolved []string, kept []int, err error) {
+	taken := make(map[string]bool, len(names))
+	for _, name := range names {
+		taken[name] = true
+	}
+
+	seen := make(map[string]bool, len(names))
+	dropped := false
+	for i, name := range names {
+		if seen[name] {
+			switch policy {
+			case DuplicateSuffix:
+				for n := 1; ; n++ {
+					if candidate := fmt.Sprintf("%s_%d", name, n); !taken[candidate] {
+						name = candidate
+						taken[name] = true
+						break
+					}
+				}
+			case DuplicateKeepFirst:
+				dropped = true
+				continue
+			default:
+				return nil, nil, newColumnError(operation, name, "column name used more than once")
+			}
+		}
+		seen[name] = true
+		resolved = append(resolved, name)
+		kept = append(kept, i)
+	}
+	if !dropped {
+		kept = nil
+	}
+	return resolved, kept, nil
+}
+
 // NewDataFrameFromMap creates a DataFrame from a map of column data
 func NewDataFrameFromMap(data map[string]any) (*DataFrame, error) {
 	if len(data) == 0 {
@@ -173,7 +256,9 @@ func (df *DataFrame) Get(row int, column string) (any, error) {
 	return df.columns[column].Get(row)
 }
 
-// Set updates the value at the specified row and column
+// Set updates the value at the specified row and column. It modifies the
+// DataFrame in place, so it must not run while other goroutines use the
+// frame (see ConcurrentDataFrame).
 func (df *DataFrame) Set(row int, column string, value any) error {
 	if df.err != nil {
 		return df.err
@@ -205,7 +290,9 @@ func (df *DataFrame) GetSeries(name string) (*Series, error) {
 
 // DataFrame Manipulation Methods
 
-// Copy creates a deep copy of the DataFrame
+// Copy creates an independent copy of the DataFrame. Column data is shared
+// copy-on-write, so copying is cheap and changes to either frame do not
+// affect the other.
 func (df *DataFrame) Copy() *DataFrame {
 	if df.err != nil {
 		// Return a new DataFrame with the same error
@@ -216,18 +303,23 @@ func (df *DataFrame) Copy() *DataFrame {
 
 	newDf := NewDataFrame()
 	newDf.length = df.length
+	newDf.index = df.copyIndex()
+	newDf.warnings = df.warnings
 
-	// Deep copy all series
+	// Columns share their data copy-on-write (see Series)
 	for _, colName := range df.order {
 		series := df.columns[colName]
-		newDf.columns[colName] = series.Copy()
+		newDf.columns[colName] = series.share()
 		newDf.order = append(newDf.order, colName)
 	}
+	maps.Copy(newDf.hashIndexes, df.hashIndexes)
 
 	return newDf
 }
 
-// AddColumn adds a new Series as a column to the DataFrame
+// AddColumn adds a new Series as a column to the DataFrame. The column
+// shares the Series' data copy-on-write. Like Set, it modifies the
+// DataFrame in place.
 func (df *DataFrame) AddColumn(series *Series) *DataFrame {
 	if df.err != nil {
 		return df
@@ -246,13 +338,125 @@ func (df *DataFrame) AddColumn(series *Series) *DataFrame {
 		return df.setError(newColumnError("AddColumn", series.Name, "column already exists"))
 	}
 
-	if err := df.addSeriesUnsafe(series.Copy()); err != nil {
+	if err := df.addSeriesUnsafe(series.share()); err != nil {
 		return df.setError(err)
 	}
 
 	return df
 }
 
+// Assign returns a copy of the DataFrame with several computed columns
+// added at once. Every function is called with the original DataFrame, so
+// no column sees another's result and the outcome does not depend on
+// evaluation order:
+//
+//	df = df.Assign(map[string]func(*otters.DataFrame) (*otters.Series, error){
+//		"total": func(d *otters.DataFrame) (*otters.Series, error) {
+//			return d.Arith("total", "price", "*", "qty").GetSeries("total")
+//		},
+//		"price": func(d *otters.DataFrame) (*otters.Series, error) {
+//			return d.Round("price", 2).GetSeries("price")
+//		},
+//	})
+//
+// Here "total" uses the unrounded price. Each result takes its map key as
+// its name. A key naming an existing column replaces it in place; new
+// columns are appended in name order. The functions must not modify the
+// DataFrame they are given.
+func (df *DataFrame) Assign(columns map[string]func(*DataFrame) (*Series, error)) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	names := slices.Sorted(maps.Keys(columns))
+	results := make([]*Series, len(names))
+	for i, name := range names {
+		if name == "" {
+			return df.setError(newOpError("Assign", "column name cannot be empty"))
+		}
+		series, err := columns[name](df)
+		if err != nil {
+			return df.setError(wrapColumnError("Assign", name, err))
+		}
+		if series == nil {
+			return df.setError(newColumnError("Assign", name, "function returned no series"))
+		}
+		if series.Length != df.length {
+			return df.setError(newColumnError("Assign", name,
+				fmt.Sprintf("series length %d does not match DataFrame length %d", series.Length, df.length)))
+		}
+		results[i] = series.share()
+		results[i].Name = name
+	}
+
+	newDf := df.Copy()
+	for _, series := range results {
+		if _, exists := newDf.columns[series.Name]; !exists {
+			newDf.order = append(newDf.order, series.Name)
+		}
+		newDf.columns[series.Name] = series
+	}
+	return newDf
+}
+
+// View returns rows start to end (exclusive) as a DataFrame that shares the
+// underlying arrays instead of copying them, for cheap read-only windows
+// over large frames. Writes through Set on either frame copy the column
+// first, so they never leak into the other. A view keeps its source's
+// full arrays alive for as long as it is reachable.
+func (df *DataFrame) View(start, end int) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if start < 0 || end > df.length || start > end {
+		return df.setError(newOpError("View",
+			fmt.Sprintf("invalid view range [%d:%d] for length %d", start, end, df.length)))
+	}
+
+	newDf := NewDataFrame()
+	newDf.length = end - start
+	for _, level := range df.index {
+		newDf.index = append(newDf.index, level.view(start, end))
+	}
+	newDf.warnings = df.warnings
+	for _, colName := range df.order {
+		newDf.addSeriesUnsafe(df.columns[colName].view(start, end))
+	}
+	return newDf
+}
+
+// ILoc selects rows rowStart to rowEnd (exclusive) by position, and
+// optionally the given columns, in one call:
+//
+//	df.ILoc(0, 10)                       // first 10 rows
+//	df.ILoc(-5, df.Len(), "name", "age") // last 5 rows of two columns
+//
+// As with Python slices, negative positions count back from the end,
+// positions past either end are clamped, and an empty range gives an empty
+// frame. The rows are a view sharing the frame's data (see View).
+func (df *DataFrame) ILoc(rowStart, rowEnd int, cols ...string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	start, end := slicePosition(rowStart, df.length), slicePosition(rowEnd, df.length)
+	rows := df.View(start, max(start, end))
+	if len(cols) == 0 {
+		return rows
+	}
+	return rows.Select(cols...)
+}
+
+// slicePosition resolves a possibly negative slice position against length,
+// clamped to [0, length].
+func slicePosition(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	return min(max(i, 0), length)
+}
+
 // DropColumn removes a column from the DataFrame
 func (df *DataFrame) DropColumn(name string) *DataFrame {
 	if df.err != nil {
@@ -260,11 +464,12 @@ func (df *DataFrame) DropColumn(name string) *DataFrame {
 	}
 
 	if err := df.validateColumnExists(name); err != nil {
-		return df.setError(err)
+		return df.setOpError("DropColumn", err)
 	}
 
 	newDf := df.Copy()
 	delete(newDf.columns, name)
+	delete(newDf.hashIndexes, name)
 
 	// Remove from order slice
 	for i, colName := range newDf.order {
@@ -277,6 +482,22 @@ func (df *DataFrame) DropColumn(name string) *DataFrame {
 	return newDf
 }
 
+// PopColumn removes a column, returning it alongside the reduced
+// DataFrame, for processing a column separately before reattaching it with
+// AddColumn. Like GetSeries, the Series is a copy. If the column does not
+// exist, the Series is nil and the DataFrame carries the error.
+func (df *DataFrame) PopColumn(name string) (*Series, *DataFrame) {
+	if df.err != nil {
+		return nil, df
+	}
+
+	rest := df.DropColumn(name)
+	if rest.err != nil {
+		return nil, rest
+	}
+	return df.columns[name].Copy(), rest
+}
+
 // RenameColumn renames a column in the DataFrame
 func (df *DataFrame) RenameColumn(oldName, newName string) *DataFrame {
 	if df.err != nil {
@@ -284,7 +505,7 @@ func (df *DataFrame) RenameColumn(oldName, newName string) *DataFrame {
 	}
 
 	if err := df.validateColumnExists(oldName); err != nil {
-		return df.setError(err)
+		return df.setOpError("RenameColumn", err)
 	}
 
 	// Check if new name already exists
@@ -301,6 +522,10 @@ func (df *DataFrame) RenameColumn(oldName, newName string) *DataFrame {
 	// Update maps and order
 	newDf.columns[newName] = series
 	delete(newDf.columns, oldName)
+	if idx, ok := newDf.hashIndexes[oldName]; ok {
+		delete(newDf.hashIndexes, oldName)
+		newDf.hashIndexes[newName] = idx
+	}
 
 	// Update order slice
 	for i, colName := range newDf.order {
@@ -313,45 +538,132 @@ func (df *DataFrame) RenameColumn(oldName, newName string) *DataFrame {
 	return newDf
 }
 
-// Display and String Methods
+// RenameColumns renames several columns at once, mapping old names to new
+// ones. Renames apply together, so two columns can swap names; columns not
+// in the map keep theirs.
+func (df *DataFrame) RenameColumns(names map[string]string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
 
-// String returns a string representation of the DataFrame
-func (df *DataFrame) String() string {
+	for oldName := range names {
+		if err := df.validateColumnExists(oldName); err != nil {
+			return df.setOpError("RenameColumns", err)
+		}
+	}
+	newNames := make([]string, len(df.order))
+	for i, name := range df.order {
+		newNames[i] = name
+		if newName, ok := names[name]; ok {
+			newNames[i] = newName
+		}
+	}
+	return df.withColumnNames(newNames, "RenameColumns")
+}
+
+// RenameFunc renames every column to fn(name), for cleaning up headers in
+// one call:
+//
+//	df = df.RenameFunc(strings.ToLower)
+func (df *DataFrame) RenameFunc(fn func(string) string) *DataFrame {
 	if df.err != nil {
-		return fmt.Sprintf("DataFrame(error: %v)", df.err)
+		return df
 	}
 
-	if df.IsEmpty() {
-		return "DataFrame(empty)"
+	newNames := make([]string, len(df.order))
+	for i, name := range df.order {
+		newNames[i] = fn(name)
 	}
+	return df.withColumnNames(newNames, "RenameFunc")
+}
 
-	var sb strings.Builder
+// withColumnNames returns a copy of the DataFrame with its columns renamed
+// to newNames, given in column order.
+func (df *DataFrame) withColumnNames(newNames []string, operation string) *DataFrame {
+	seen := make(map[string]bool, len(newNames))
+	for i, name := range newNames {
+		if name == "" {
+			return df.setError(newColumnError(operation, df.order[i], "new column name cannot be empty"))
+		}
+		if seen[name] {
+			return df.setError(newColumnError(operation, name, "column already exists"))
+		}
+		seen[name] = true
+	}
 
-	// Write header
-	sb.WriteString(strings.Join(df.order, "\t"))
-	sb.WriteString("\n")
+	newDf := df.Copy()
+	columns := make(map[string]*Series, len(newNames))
+	hashIndexes := make(map[string]*hashIndex)
+	for i, oldName := range df.order {
+		series := newDf.columns[oldName]
+		series.Name = newNames[i]
+		columns[newNames[i]] = series
+		if idx, ok := newDf.hashIndexes[oldName]; ok {
+			hashIndexes[newNames[i]] = idx
+		}
+	}
+	newDf.columns, newDf.hashIndexes, newDf.order = columns, hashIndexes, slices.Clone(newNames)
+	return newDf
+}
 
-	// Write data (show first 10 rows max for display)
-	maxRows := df.length
-	if maxRows > 10 {
-		maxRows = 10
+// ReorderColumns returns a copy of the DataFrame with the named columns
+// first, in the given order, followed by the remaining columns in their
+// current order. It controls the column order of WriteCSV and other output
+// without selecting every column by hand.
+func (df *DataFrame) ReorderColumns(names ...string) *DataFrame {
+	if df.err != nil {
+		return df
 	}
 
-	for i := 0; i < maxRows; i++ {
-		var row []string
-		for _, colName := range df.order {
-			value, _ := df.columns[colName].Get(i)
-			row = append(row, fmt.Sprintf("%v", value))
+	if err := df.validateColumnsExist(names); err != nil {
+		return df.setOpError("ReorderColumns", err)
+	}
+	order := make([]string, 0, len(df.order))
+	for i, name := range names {
+		if slices.Contains(names[:i], name) {
+			return df.setError(newColumnError("ReorderColumns", name, "column specified more than once"))
+		}
+		order = append(order, name)
+	}
+	for _, name := range df.order {
+		if !slices.Contains(names, name) {
+			order = append(order, name)
 		}
-		sb.WriteString(strings.Join(row, "\t"))
-		sb.WriteString("\n")
 	}
 
-	if df.length > 10 {
-		sb.WriteString(fmt.Sprintf("... (%d more rows)\n", df.length-10))
+	newDf := df.Copy()
+	newDf.order = order
+	return newDf
+}
+
+// MoveColumn returns a copy of the DataFrame with the named column moved to
+// position pos (0 is first), shifting the columns in between.
+func (df *DataFrame) MoveColumn(name string, pos int) *DataFrame {
+	if df.err != nil {
+		return df
 	}
 
-	return sb.String()
+	if err := df.validateColumnExists(name); err != nil {
+		return df.setOpError("MoveColumn", err)
+	}
+	if pos < 0 || pos >= len(df.order) {
+		return df.setError(newColumnError("MoveColumn", name,
+			fmt.Sprintf("position %d out of range [0:%d]", pos, len(df.order))))
+	}
+
+	order := slices.DeleteFunc(slices.Clone(df.order), func(c string) bool { return c == name })
+	newDf := df.Copy()
+	newDf.order = slices.Insert(order, pos, name)
+	return newDf
+}
+
+// Display and String Methods
+
+// String returns a string representation of the DataFrame: a
+// tab-separated header and rows, formatted with DefaultDisplayOptions (see
+// StringWith).
+func (df *DataFrame) String() string {
+	return df.StringWith(DefaultDisplayOptions)
 }
 
 // Info returns basic information about the DataFrame
@@ -391,6 +703,10 @@ func (df *DataFrame) slice(start, end int, operation string) *DataFrame {
 
 	newDf := NewDataFrame()
 	newDf.length = end - start
+	if df.index != nil {
+		newDf.index = df.indexRows(rangeIndices(start, end))
+	}
+	newDf.warnings = df.warnings
 
 	for _, colName := range df.order {
 		series := df.columns[colName]
@@ -432,3 +748,240 @@ func (df *DataFrame) slice(start, end int, operation string) *DataFrame {
 
 	return newDf
 }
+
+// concatFrames stacks frames with the same columns vertically, in the first
+// frame's column order. Int64 and float64 columns combine as float64; frames
+// with no rows impose no column types (a header-only CSV reads as strings).
+func concatFrames(frames []*DataFrame, operation string) (*DataFrame, error) {
+	if len(frames) == 0 {
+		return NewDataFrame(), nil
+	}
+
+	first := frames[0]
+	total := 0
+	for i, frame := range frames {
+		if frame.err != nil {
+			return nil, frame.err
+		}
+		if len(frame.order) != len(first.order) {
+			return nil, newOpError(operation,
+				fmt.Sprintf("input %d has %d columns, expected %d", i, len(frame.order), len(first.order)))
+		}
+		for _, colName := range first.order {
+			if _, exists := frame.columns[colName]; !exists {
+				return nil, newColumnError(operation, colName, fmt.Sprintf("column missing from input %d", i))
+			}
+		}
+		total += frame.length
+	}
+
+	series := make([]*Series, 0, len(first.order))
+	for _, colName := range first.order {
+		colType, err := concatColumnType(frames, colName, operation)
+		if err != nil {
+			return nil, err
+		}
+
+		var data any
+		switch colType {
+		case StringType:
+			out := make([]string, 0, total)
+			for _, frame := range frames {
+				if frame.length > 0 {
+					out = append(out, frame.columns[colName].Data.([]string)...)
+				}
+			}
+			data = out
+		case Int64Type:
+			out := make([]int64, 0, total)
+			for _, frame := range frames {
+				if frame.length > 0 {
+					out = append(out, frame.columns[colName].Data.([]int64)...)
+				}
+			}
+			data = out
+		case Float64Type:
+			out := make([]float64, 0, total)
+			for _, frame := range frames {
+				if frame.length == 0 {
+					continue
+				}
+				s := frame.columns[colName]
+				if s.Type == Int64Type {
+					for _, v := range s.Data.([]int64) {
+						out = append(out, float64(v))
+					}
+				} else {
+					out = append(out, s.Data.([]float64)...)
+				}
+			}
+			data = out
+		case BoolType:
+			out := make([]bool, 0, total)
+			for _, frame := range frames {
+				if frame.length > 0 {
+					out = append(out, frame.columns[colName].Data.([]bool)...)
+				}
+			}
+			data = out
+		case TimeType:
+			out := make([]time.Time, 0, total)
+			for _, frame := range frames {
+				if frame.length > 0 {
+					out = append(out, frame.columns[colName].Data.([]time.Time)...)
+				}
+			}
+			data = out
+		default:
+			return nil, newColumnError(operation, colName, "unsupported column type")
+		}
+
+		s, err := newSeriesOwned(colName, data)
+		if err != nil {
+			return nil, wrapColumnError(operation, colName, err)
+		}
+		series = append(series, s)
+	}
+
+	return NewDataFrameFromSeries(series...)
+}
+
+// concatColumnType resolves the combined type of a column across frames.
+func concatColumnType(frames []*DataFrame, colName, operation string) (ColumnType, error) {
+	colType := frames[0].columns[colName].Type
+	resolved := false
+	for _, frame := range frames {
+		if frame.length == 0 {
+			continue
+		}
+		t := frame.columns[colName].Type
+		switch {
+		case !resolved:
+			colType = t
+			resolved = true
+		case t == colType:
+		case isNumericType(t) && isNumericType(colType):
+			colType = Float64Type
+		default:
+			return colType, newColumnError(operation, colName,
+				fmt.Sprintf("inputs disagree on column type: %s and %s", colType, t))
+		}
+	}
+	return colType, nil
+}
+
+// isNumericType reports whether a column type is int64 or float64.
+func isNumericType(t ColumnType) bool {
+	return t == Int64Type || t == Float64Type
+}
+
+// withAppendedColumn returns a copy of the DataFrame with the series added as
+// the last column, taking ownership of the series.
+func (df *DataFrame) withAppendedColumn(series *Series, operation string) *DataFrame {
+	if _, exists := df.columns[series.Name]; exists {
+		return df.setError(newColumnError(operation, series.Name, "column already exists"))
+	}
+	if series.Length != df.length {
+		return df.setError(newColumnError(operation, series.Name,
+			fmt.Sprintf("series length %d does not match DataFrame length %d", series.Length, df.length)))
+	}
+
+	newDf := df.Copy()
+	if err := newDf.addSeriesUnsafe(series); err != nil {
+		return df.setError(wrapColumnError(operation, series.Name, err))
+	}
+	return newDf
+}
+
+// Row Index Methods
+
+// WithRowIndex returns a copy of the DataFrame carrying a row index: an
+// int64 Series named "index" holding each row's current position. Filter,
+// Sort, Head, Tail, Select and the other row selections keep the index
+// aligned with the rows they return, so results can be traced back to
+// their original positions.
+func (df *DataFrame) WithRowIndex() *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	positions := make([]int64, df.length)
+	for i := range positions {
+		positions[i] = int64(i)
+	}
+	index, err := newSeriesOwned("index", positions)
+	if err != nil {
+		return df.setError(wrapError("WithRowIndex", err))
+	}
+
+	newDf := df.Copy()
+	newDf.index = []*Series{index}
+	return newDf
+}
+
+// Index returns a copy of the DataFrame's row index, or nil if it has none.
+// For a multi-level index it returns the first level (see IndexLevels).
+func (df *DataFrame) Index() *Series {
+	if df.err != nil || df.index == nil {
+		return nil
+	}
+	return df.index[0].Copy()
+}
+
+// indexRows returns the index entries at the given rows as new Series; nil
+// rows select none. Returns nil if the DataFrame has no index.
+func (df *DataFrame) indexRows(rows []int) []*Series {
+	if df.index == nil {
+		return nil
+	}
+
+	levels := make([]*Series, len(df.index))
+	for i, level := range df.index {
+		selected, err := newSeriesOwned(level.Name, selectSeriesRows(level, rows))
+		if err != nil {
+			return nil
+		}
+		levels[i] = selected
+	}
+	return levels
+}
+
+// copyIndex returns a copy of the whole index, or nil if the DataFrame has
+// no index.
+func (df *DataFrame) copyIndex() []*Series {
+	if df.index == nil {
+		return nil
+	}
+
+	levels := make([]*Series, len(df.index))
+	for i, level := range df.index {
+		levels[i] = level.Copy()
+	}
+	return levels
+}
+
+// rangeIndices returns the row indices start, start+1, ..., end-1.
+func rangeIndices(start, end int) []int {
+	indices := make([]int, end-start)
+	for i := range indices {
+		indices[i] = start + i
+	}
+	return indices
+}
+
+// withReplacedColumn returns a copy of the DataFrame with the named column's
+// data replaced, taking ownership of data. The column keeps its position.
+func (df *DataFrame) withReplacedColumn(column string, data any, operation string) *DataFrame {
+	series, err := newSeriesOwned(column, data)
+	if err != nil {
+		return df.setError(wrapColumnError(operation, column, err))
+	}
+	if series.Length != df.length {
+		return df.setError(newColumnError(operation, column,
+			fmt.Sprintf("series length %d does not match DataFrame length %d", series.Length, df.length)))
+	}
+
+	newDf := df.Copy()
+	newDf.columns[column] = series
+	return newDf
+}
@@ -2,6 +2,9 @@ package otters
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -377,6 +380,127 @@ func TestDF_Copy_DeepCopy(t *testing.T) {
 	}
 }
 
+func TestDF_CopyOnWrite(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"id":   []int64{1, 2, 3},
+		"name": []string{"a", "b", "c"},
+	})
+
+	selected := df.Select("id")
+	copied := df.Copy()
+	if &df.columns["id"].Data.([]int64)[0] != &selected.columns["id"].Data.([]int64)[0] ||
+		&df.columns["id"].Data.([]int64)[0] != &copied.columns["id"].Data.([]int64)[0] {
+		t.Fatal("Select and Copy should share column data until a write")
+	}
+
+	if err := selected.Set(0, "id", int64(10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := df.Set(1, "id", int64(20)); err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range map[string]struct {
+		df   *DataFrame
+		want []int64
+	}{
+		"source":   {df, []int64{1, 20, 3}},
+		"selected": {selected, []int64{10, 2, 3}},
+		"copied":   {copied, []int64{1, 2, 3}},
+	} {
+		got := tc.df.columns["id"].Int64Slice()
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s id = %v, want %v", name, got, tc.want)
+				break
+			}
+		}
+	}
+
+	series, _ := NewSeries("extra", []int64{7, 8, 9})
+	withExtra := df.Copy().AddColumn(series)
+	if err := series.Set(0, int64(0)); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := withExtra.Get(0, "extra"); v != int64(7) {
+		t.Errorf("AddColumn column changed with its source Series: %v", v)
+	}
+}
+
+func TestDF_View(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"id":   []int64{1, 2, 3, 4, 5},
+		"name": []string{"a", "b", "c", "d", "e"},
+	})
+	df = df.WithRowIndex()
+
+	view := df.View(1, 4)
+	if view.Error() != nil {
+		t.Fatal(view.Error())
+	}
+	if view.Len() != 3 {
+		t.Fatalf("view has %d rows, want 3", view.Len())
+	}
+	if &view.columns["id"].Data.([]int64)[0] != &df.columns["id"].Data.([]int64)[1] {
+		t.Error("View should re-slice the source arrays")
+	}
+	if v, _ := view.Get(0, "name"); v != "b" {
+		t.Errorf("first view row name = %v, want b", v)
+	}
+	if got := view.Index().Int64Slice(); got[0] != 1 || got[2] != 3 {
+		t.Errorf("view index = %v, want [1 2 3]", got)
+	}
+
+	if err := view.Set(0, "id", int64(99)); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := df.Get(1, "id"); v != int64(2) {
+		t.Errorf("writing to the view changed the source: %v", v)
+	}
+
+	if empty := df.View(2, 2); empty.Error() != nil || empty.Len() != 0 {
+		t.Errorf("empty view: len %d, err %v", empty.Len(), empty.Error())
+	}
+	if df.Copy().View(3, 9).Error() == nil {
+		t.Error("expected error for out-of-range view")
+	}
+}
+
+func TestDF_ILoc(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"id":   []int64{1, 2, 3, 4, 5},
+		"name": []string{"a", "b", "c", "d", "e"},
+	})
+
+	tests := []struct {
+		start, end int
+		want       []int64
+	}{
+		{0, 2, []int64{1, 2}},
+		{-2, 5, []int64{4, 5}},
+		{1, -1, []int64{2, 3, 4}},
+		{-10, 2, []int64{1, 2}},
+		{3, 99, []int64{4, 5}},
+		{4, 2, []int64{}},
+	}
+	for _, tt := range tests {
+		rows := df.ILoc(tt.start, tt.end)
+		if err := rows.Error(); err != nil {
+			t.Fatalf("ILoc(%d, %d): %v", tt.start, tt.end, err)
+		}
+		if got := rows.columns["id"].Int64Slice(); !slices.Equal(got, tt.want) {
+			t.Errorf("ILoc(%d, %d) ids = %v, want %v", tt.start, tt.end, got, tt.want)
+		}
+	}
+
+	cols := df.ILoc(-1, df.Len(), "name")
+	if cols.Width() != 1 || cols.MustGet(0, "name") != "e" {
+		t.Errorf("ILoc with columns: %v", cols.Columns())
+	}
+	if df.ILoc(0, 1, "missing").Error() == nil {
+		t.Error("expected error for a missing column")
+	}
+}
+
 func TestDF_String_SmallAndLarge(t *testing.T) {
 	df1, _ := NewDataFrameFromMap(map[string]any{"col1": []int64{1, 2}})
 	if df1.String() == "" {
@@ -664,6 +788,158 @@ func TestDF_RenameColumnSuccess(t *testing.T) {
 	}
 }
 
+func TestDF_PopColumn(t *testing.T) {
+	df, _ := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{1, 2}),
+		mustSeries(t, "score", []float64{0.5, 0.75}),
+	)
+
+	score, rest := df.PopColumn("score")
+	if err := rest.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if score.Name != "score" || score.Float64Slice()[1] != 0.75 {
+		t.Errorf("popped series = %v", score.Data)
+	}
+	if !slices.Equal(rest.Columns(), []string{"id"}) || !df.HasColumn("score") {
+		t.Errorf("rest columns = %v; source should keep score", rest.Columns())
+	}
+
+	score.Data.([]float64)[0] = 99
+	if df.MustGet(0, "score") != 0.5 {
+		t.Error("the popped series should be a copy")
+	}
+	if back := rest.AddColumn(score); back.Error() != nil || back.Width() != 2 {
+		t.Errorf("reattaching failed: %v", back.Error())
+	}
+
+	if s, bad := df.PopColumn("missing"); s != nil || bad.Error() == nil {
+		t.Error("expected error popping a missing column")
+	}
+}
+
+func TestDF_Assign(t *testing.T) {
+	df, _ := NewDataFrameFromSeries(
+		mustSeries(t, "price", []float64{1.234, 2.5}),
+		mustSeries(t, "qty", []int64{2, 4}),
+	)
+
+	out := df.Assign(map[string]func(*DataFrame) (*Series, error){
+		"total": func(d *DataFrame) (*Series, error) {
+			return d.Arith("total", "price", "*", "qty").GetSeries("total")
+		},
+		"price": func(d *DataFrame) (*Series, error) {
+			return d.Round("price", 1).GetSeries("price")
+		},
+		"double": func(d *DataFrame) (*Series, error) {
+			return d.Arith("x", "qty", "+", "qty").GetSeries("x")
+		},
+	})
+	if err := out.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(out.Columns(), []string{"price", "qty", "double", "total"}) {
+		t.Errorf("columns = %v, want [price qty double total]", out.Columns())
+	}
+	if out.MustGet(0, "price") != 1.2 || out.MustGet(0, "total") != 2.468 {
+		t.Errorf("total should use the original price: price %v, total %v", out.MustGet(0, "price"), out.MustGet(0, "total"))
+	}
+	if df.MustGet(0, "price") != 1.234 || df.Width() != 2 {
+		t.Error("Assign changed the source frame")
+	}
+
+	failing := df.Assign(map[string]func(*DataFrame) (*Series, error){
+		"bad": func(d *DataFrame) (*Series, error) { return d.GetSeries("missing") },
+	})
+	if failing.Error() == nil {
+		t.Error("expected error from a failing column function")
+	}
+	short := df.Assign(map[string]func(*DataFrame) (*Series, error){
+		"short": func(*DataFrame) (*Series, error) { return NewSeries("short", []int64{1}) },
+	})
+	if short.Error() == nil {
+		t.Error("expected error for a series of the wrong length")
+	}
+}
+
+func TestDF_RenameColumnsAndFunc(t *testing.T) {
+	df, _ := NewDataFrameFromSeries(
+		mustSeries(t, "A", []int64{1, 2}),
+		mustSeries(t, "B", []string{"x", "y"}),
+		mustSeries(t, "Price", []float64{1.5, 2.5}),
+	)
+	df = df.CreateIndex("A")
+
+	swapped := df.RenameColumns(map[string]string{"A": "B", "B": "A"})
+	if err := swapped.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(swapped.Columns(), []string{"B", "A", "Price"}) || swapped.MustGet(0, "A") != "x" {
+		t.Errorf("swap failed: columns %v", swapped.Columns())
+	}
+	if !swapped.HasIndex("B") || swapped.HasIndex("A") {
+		t.Error("hash index should follow its column")
+	}
+	if !slices.Equal(df.Columns(), []string{"A", "B", "Price"}) {
+		t.Errorf("RenameColumns changed the source: %v", df.Columns())
+	}
+
+	lower := df.RenameFunc(strings.ToLower)
+	if !slices.Equal(lower.Columns(), []string{"a", "b", "price"}) || lower.MustGet(1, "price") != 2.5 {
+		t.Errorf("RenameFunc columns = %v", lower.Columns())
+	}
+
+	if df.RenameColumns(map[string]string{"missing": "x"}).Error() == nil {
+		t.Error("expected error renaming a missing column")
+	}
+	if df.RenameColumns(map[string]string{"A": "Price"}).Error() == nil {
+		t.Error("expected error for a duplicate result name")
+	}
+	if df.RenameFunc(func(string) string { return "" }).Error() == nil {
+		t.Error("expected error for an empty name")
+	}
+}
+
+func TestDF_ReorderAndMoveColumn(t *testing.T) {
+	df, _ := NewDataFrameFromSeries(
+		mustSeries(t, "a", []int64{1}),
+		mustSeries(t, "b", []int64{2}),
+		mustSeries(t, "c", []int64{3}),
+		mustSeries(t, "d", []int64{4}),
+	)
+
+	if got := df.ReorderColumns("c", "a").Columns(); !slices.Equal(got, []string{"c", "a", "b", "d"}) {
+		t.Errorf("ReorderColumns = %v, want [c a b d]", got)
+	}
+	if got := df.MoveColumn("a", 2).Columns(); !slices.Equal(got, []string{"b", "c", "a", "d"}) {
+		t.Errorf("MoveColumn(a, 2) = %v, want [b c a d]", got)
+	}
+	if got := df.MoveColumn("d", 0).Columns(); !slices.Equal(got, []string{"d", "a", "b", "c"}) {
+		t.Errorf("MoveColumn(d, 0) = %v, want [d a b c]", got)
+	}
+	if !slices.Equal(df.Columns(), []string{"a", "b", "c", "d"}) {
+		t.Errorf("reordering changed the source: %v", df.Columns())
+	}
+
+	path := filepath.Join(t.TempDir(), "out.csv")
+	if err := df.MoveColumn("d", 0).WriteCSV(path); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := os.ReadFile(path); !strings.HasPrefix(string(out), "d,a,b,c\n") {
+		t.Errorf("WriteCSV should follow the new order, got %q", out)
+	}
+
+	if df.ReorderColumns("a", "a").Error() == nil {
+		t.Error("expected error for a repeated column")
+	}
+	if df.ReorderColumns("missing").Error() == nil {
+		t.Error("expected error for a missing column")
+	}
+	if df.MoveColumn("a", 4).Error() == nil {
+		t.Error("expected error for an out-of-range position")
+	}
+}
+
 // Regression: NewSeries/NewDataFrameFromMap used to keep a reference to the
 // caller's slice; mutating the source slice afterwards mutated the DataFrame.
 func TestDataFrameDoesNotAliasCallerSlice(t *testing.T) {
@@ -906,3 +1182,100 @@ func TestDataFrameManipulation(t *testing.T) {
 		t.Error("HasColumn: 'nonexistent' should not exist")
 	}
 }
+
+func TestRowIndexSurvivesRowSelections(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"name":  []string{"a", "b", "c", "d", "e"},
+		"score": []int64{50, 90, 70, 90, 10},
+	})
+
+	if df.Index() != nil {
+		t.Fatal("a new DataFrame should have no index")
+	}
+
+	indexed := df.WithRowIndex()
+	result := indexed.
+		Filter("score", ">", 20).
+		Sort("score", false).
+		Select("name").
+		Head(3)
+	if err := result.Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	index := result.Index()
+	if index == nil {
+		t.Fatal("row index was lost along the chain")
+	}
+	want := []int64{1, 3, 2}
+	got := index.Int64Slice()
+	if len(got) != len(want) {
+		t.Fatalf("index = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("index = %v, want %v", got, want)
+		}
+	}
+
+	tail := indexed.Tail(2).Index().Int64Slice()
+	if len(tail) != 2 || tail[0] != 3 || tail[1] != 4 {
+		t.Errorf("Tail index = %v, want [3 4]", tail)
+	}
+
+	lazy, err := indexed.Lazy().Filter("score", "==", 90).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lazy.Index().Int64Slice(); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("Lazy index = %v, want [1 3]", got)
+	}
+
+	empty := indexed.Filter("score", ">", 1000)
+	if empty.Index() == nil || empty.Index().Length != 0 {
+		t.Error("empty result should keep an empty index")
+	}
+
+	// Index returns a copy
+	index.Set(0, int64(-1))
+	if v, _ := result.Index().GetInt64(0); v != 1 {
+		t.Error("Index() should return a copy")
+	}
+}
+
+func TestNewDataFrameFromSeriesDuplicates(t *testing.T) {
+	a := mustSeries(t, "a", []int64{1, 2})
+	a2 := mustSeries(t, "a", []int64{3, 4})
+	a1 := mustSeries(t, "a_1", []int64{5, 6})
+	b := mustSeries(t, "b", []string{"x", "y"})
+
+	if _, err := NewDataFrameFromSeries(a, b, a2); err == nil {
+		t.Error("expected an error for a repeated column name")
+	}
+
+	// The repeat skips a_1, which is already taken
+	df, err := NewDataFrameFromSeriesWithPolicy(DuplicateSuffix, a, a1, a2, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(df.Columns(), ","); got != "a,a_1,a_2,b" {
+		t.Errorf("suffixed columns = %s, want a,a_1,a_2,b", got)
+	}
+	if v, _ := df.Get(0, "a_2"); v != int64(3) {
+		t.Errorf("a_2 = %v, want 3", v)
+	}
+	if a2.Name != "a" {
+		t.Errorf("input series renamed to %s", a2.Name)
+	}
+
+	df, err = NewDataFrameFromSeriesWithPolicy(DuplicateKeepFirst, a, b, a2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(df.Columns(), ","); got != "a,b" {
+		t.Errorf("kept columns = %s, want a,b", got)
+	}
+	if v, _ := df.Get(1, "a"); v != int64(2) {
+		t.Errorf("a = %v, want the first column's 2", v)
+	}
+}
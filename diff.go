@@ -0,0 +1,165 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// DiffResult reports how DataFrame b differs from a, row by row (see Diff)
+type DiffResult struct {
+	Added   *DataFrame  // Rows of b whose key is not in a
+	Removed *DataFrame  // Rows of a whose key is not in b
+	Changed []RowChange // Rows present in both with different values, in b's order
+}
+
+// RowChange describes a row whose key is in both frames of a Diff but
+// whose values differ.
+type RowChange struct {
+	Key     GroupKey     // The row's key values, formatted as strings
+	OldRow  int          // Position of the row in a
+	NewRow  int          // Position of the row in b
+	Changes []CellChange // The differing cells, in column order
+}
+
+// CellChange is one cell that differs between two versions of a row
+type CellChange struct {
+	Column   string
+	Old, New any
+}
+
+// Diff matches the rows of a and b by the key columns and reports the rows
+// added in b, the rows removed from a, and the rows whose other values
+// changed, cell by cell. It reconciles two snapshots of the same data:
+//
+//	diff, err := otters.Diff(yesterday, today, []string{"account_id"})
+//	for _, change := range diff.Changed {
+//		for _, cell := range change.Changes {
+//			fmt.Println(change.Key, cell.Column, cell.Old, "->", cell.New)
+//		}
+//	}
+//
+// Both frames must have the same columns with the same types, in any order,
+// and each key must identify at most one row per frame. NaN matches NaN.
+func Diff(a, b *DataFrame, keyCols []string) (*DiffResult, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(keyCols) == 0 {
+		return nil, newOpError("Diff", "at least one key column must be specified")
+	}
+	if err := a.validateColumnsExist(keyCols); err != nil {
+		return nil, err
+	}
+	if got, want := slices.Sorted(slices.Values(b.order)), slices.Sorted(slices.Values(a.order)); !slices.Equal(got, want) {
+		return nil, newOpError("Diff", fmt.Sprintf("columns %v differ from %v", b.order, a.order))
+	}
+	for _, column := range a.order {
+		if a.columns[column].Type != b.columns[column].Type {
+			return nil, newColumnError("Diff", column,
+				fmt.Sprintf("type %s differs from %s", b.columns[column].Type, a.columns[column].Type))
+		}
+	}
+
+	oldRows, err := diffKeys(a, keyCols)
+	if err != nil {
+		return nil, err
+	}
+	newRows, err := diffKeys(b, keyCols)
+	if err != nil {
+		return nil, err
+	}
+
+	var valueCols []string
+	for _, column := range a.order {
+		if !slices.Contains(keyCols, column) {
+			valueCols = append(valueCols, column)
+		}
+	}
+
+	result := &DiffResult{}
+	var added, removed []int
+	for key, g := range newRows {
+		oldRow, ok := oldRows[key]
+		if !ok {
+			added = append(added, g.row)
+			continue
+		}
+		var changes []CellChange
+		for _, column := range valueCols {
+			if !cellsEqual(a.columns[column], oldRow.row, b.columns[column], g.row) {
+				old, _ := a.columns[column].Get(oldRow.row)
+				value, _ := b.columns[column].Get(g.row)
+				changes = append(changes, CellChange{Column: column, Old: old, New: value})
+			}
+		}
+		if changes != nil {
+			result.Changed = append(result.Changed, RowChange{Key: g.key, OldRow: oldRow.row, NewRow: g.row, Changes: changes})
+		}
+	}
+	for key, g := range oldRows {
+		if _, ok := newRows[key]; !ok {
+			removed = append(removed, g.row)
+		}
+	}
+
+	slices.Sort(added)
+	slices.Sort(removed)
+	slices.SortFunc(result.Changed, func(x, y RowChange) int { return x.NewRow - y.NewRow })
+	result.Added = b.selectRows(added, "Diff")
+	result.Removed = a.selectRows(removed, "Diff")
+	if err := result.Added.Error(); err != nil {
+		return nil, err
+	}
+	if err := result.Removed.Error(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// diffRow is the row holding one key in a Diff input.
+type diffRow struct {
+	key GroupKey
+	row int
+}
+
+// diffKeys maps each encoded key of df to its row, failing on a repeated
+// key.
+func diffKeys(df *DataFrame, keyCols []string) (map[string]diffRow, error) {
+	keySeries := make([]*Series, len(keyCols))
+	for i, column := range keyCols {
+		keySeries[i] = df.columns[column]
+	}
+	groups, keys := buildGroupsHashed(keySeries, 0, df.length)
+	rows := make(map[string]diffRow, len(groups))
+	for i, g := range groups {
+		if len(g.indices) > 1 {
+			return nil, newRowError("Diff", g.indices[1], fmt.Sprintf("key %v is not unique", g.values))
+		}
+		rows[keys[i]] = diffRow{key: GroupKey(g.values), row: g.indices[0]}
+	}
+	return rows, nil
+}
+
+// cellsEqual reports whether row i of a and row j of b hold the same
+// value. The Series have the same type; NaN matches NaN.
+func cellsEqual(a *Series, i int, b *Series, j int) bool {
+	switch x := a.Data.(type) {
+	case []float64:
+		p, q := x[i], b.Data.([]float64)[j]
+		return p == q || (math.IsNaN(p) && math.IsNaN(q))
+	case []int64:
+		return x[i] == b.Data.([]int64)[j]
+	case []string:
+		return x[i] == b.Data.([]string)[j]
+	case []bool:
+		return x[i] == b.Data.([]bool)[j]
+	case []time.Time:
+		return x[i].Equal(b.Data.([]time.Time)[j])
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package otters
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	yesterday, _ := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{1, 2, 3, 4}),
+		mustSeries(t, "name", []string{"a", "b", "c", "d"}),
+		mustSeries(t, "balance", []float64{10, 20, math.NaN(), 40}),
+	)
+	today, _ := NewDataFrameFromSeries(
+		mustSeries(t, "balance", []float64{45, 10, math.NaN(), 50}),
+		mustSeries(t, "id", []int64{4, 1, 3, 5}),
+		mustSeries(t, "name", []string{"dd", "a", "c", "e"}),
+	)
+
+	diff, err := Diff(yesterday, today, []string{"id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := diff.Added.columns["id"].Int64Slice(); !slices.Equal(got, []int64{5}) {
+		t.Errorf("added ids = %v, want [5]", got)
+	}
+	if got := diff.Removed.columns["id"].Int64Slice(); !slices.Equal(got, []int64{2}) {
+		t.Errorf("removed ids = %v, want [2]", got)
+	}
+	if len(diff.Changed) != 1 {
+		t.Fatalf("expected 1 changed row, got %+v", diff.Changed)
+	}
+	change := diff.Changed[0]
+	if !slices.Equal(change.Key, GroupKey{"4"}) || change.OldRow != 3 || change.NewRow != 0 {
+		t.Errorf("unexpected change: %+v", change)
+	}
+	want := []CellChange{{Column: "name", Old: "d", New: "dd"}, {Column: "balance", Old: 40.0, New: 45.0}}
+	if !slices.Equal(change.Changes, want) {
+		t.Errorf("changes = %+v, want %+v", change.Changes, want)
+	}
+
+	if _, err := Diff(yesterday, today.Drop("name"), []string{"id"}); err == nil {
+		t.Error("expected error for different columns")
+	}
+	dup, _ := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{1, 1, 3, 4}),
+		mustSeries(t, "name", []string{"a", "b", "c", "d"}),
+		mustSeries(t, "balance", []float64{1, 2, 3, 4}),
+	)
+	if _, err := Diff(dup, today, []string{"id"}); err == nil {
+		t.Error("expected error for a repeated key")
+	}
+	if _, err := Diff(yesterday, today, []string{"missing"}); err == nil {
+		t.Error("expected error for a missing key column")
+	}
+}
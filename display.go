@@ -0,0 +1,219 @@
+package otters
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DisplayOptions controls how StringWith and PrintWith render a DataFrame
+type DisplayOptions struct {
+	MaxRows        int  // Rows shown before a "... (n more rows)" line; 0 shows every row
+	MaxColumnWidth int  // Longer headers and cells are cut to this many characters, ending in "…"; 0 means no limit
+	FloatPrecision int  // Digits after the decimal point for float64 values; 0 uses the shortest exact form
+	ShowIndex      bool // Lead each row with its index labels, or its position if the frame has no index
+	Borders        bool // Draw box borders around the table and its cells (Print only)
+}
+
+// DefaultDisplayOptions are the options String and Print use. Change them to
+// configure display for the whole package, e.g. at the start of a
+// notebook-style session:
+//
+//	otters.DefaultDisplayOptions.MaxRows = 50
+//	otters.DefaultDisplayOptions.FloatPrecision = 2
+var DefaultDisplayOptions = DisplayOptions{MaxRows: 10}
+
+// StringWith returns a string representation of the DataFrame like String,
+// with the given options instead of DefaultDisplayOptions:
+//
+//	fmt.Print(df.StringWith(otters.DisplayOptions{MaxRows: 20, MaxColumnWidth: 12, ShowIndex: true}))
+func (df *DataFrame) StringWith(options DisplayOptions) string {
+	if df.err != nil {
+		return fmt.Sprintf("DataFrame(error: %v)", df.err)
+	}
+
+	if df.IsEmpty() {
+		return "DataFrame(empty)"
+	}
+
+	table := df.displayCells(options)
+
+	var sb strings.Builder
+	sb.WriteString(strings.Join(table.header, "\t"))
+	sb.WriteString("\n")
+	for _, row := range table.rows {
+		sb.WriteString(strings.Join(row, "\t"))
+		sb.WriteString("\n")
+	}
+	if table.more > 0 {
+		sb.WriteString(fmt.Sprintf("... (%d more rows)\n", table.more))
+	}
+	return sb.String()
+}
+
+// Print writes the DataFrame to w as a table with aligned columns, formatted
+// with DefaultDisplayOptions (see PrintWith).
+func (df *DataFrame) Print(w io.Writer) error {
+	return df.PrintWith(w, DefaultDisplayOptions)
+}
+
+// PrintWith writes the DataFrame to w as a table for reading in a terminal:
+// column names over their types, then the rows, each column padded to its
+// widest cell, numbers aligned right and everything else left. With
+// Borders the table is drawn in a box:
+//
+//	┌────────┬───────┐
+//	│ name   │   age │
+//	│ string │ int64 │
+//	├────────┼───────┤
+//	│ Alice  │    25 │
+//	└────────┴───────┘
+//
+// See DisplayOptions for the rows and cells shown.
+func (df *DataFrame) PrintWith(w io.Writer, options DisplayOptions) error {
+	if df.err != nil || df.IsEmpty() {
+		_, err := io.WriteString(w, df.StringWith(options)+"\n")
+		return err
+	}
+
+	table := df.displayCells(options)
+	widths := make([]int, len(table.header))
+	for _, row := range append([][]string{table.header, table.types}, table.rows...) {
+		for j, cell := range row {
+			widths[j] = max(widths[j], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var sb strings.Builder
+	rule := func(left, middle, right string) {
+		sb.WriteString(left)
+		for j, width := range widths {
+			if j > 0 {
+				sb.WriteString(middle)
+			}
+			sb.WriteString(strings.Repeat("─", width+2))
+		}
+		sb.WriteString(right + "\n")
+	}
+	line := func(cells []string) {
+		padded := make([]string, len(cells))
+		for j, cell := range cells {
+			padded[j] = padCell(cell, widths[j], table.numeric[j])
+		}
+		if options.Borders {
+			sb.WriteString("│ " + strings.Join(padded, " │ ") + " │\n")
+		} else {
+			sb.WriteString(strings.TrimRight(strings.Join(padded, "  "), " ") + "\n")
+		}
+	}
+
+	if options.Borders {
+		rule("┌", "┬", "┐")
+	}
+	line(table.header)
+	line(table.types)
+	if options.Borders {
+		rule("├", "┼", "┤")
+	} else {
+		dashes := make([]string, len(widths))
+		for j, width := range widths {
+			dashes[j] = strings.Repeat("-", width)
+		}
+		line(dashes)
+	}
+	for _, row := range table.rows {
+		line(row)
+	}
+	if options.Borders {
+		rule("└", "┴", "┘")
+	}
+	if table.more > 0 {
+		sb.WriteString(fmt.Sprintf("... (%d more rows)\n", table.more))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// padCell pads s with spaces to width characters, on the left when right
+// is set.
+func padCell(s string, width int, right bool) string {
+	padding := strings.Repeat(" ", width-utf8.RuneCountInString(s))
+	if right {
+		return padding + s
+	}
+	return s + padding
+}
+
+// displayTable is the formatted content of a DataFrame for display.
+type displayTable struct {
+	header  []string   // Column names, index columns first
+	types   []string   // Column types; empty for the row position column
+	numeric []bool     // Whether a column holds numbers, which align right
+	rows    [][]string // The shown rows
+	more    int        // How many rows were left out
+}
+
+// displayCells formats the header and the shown rows of the DataFrame,
+// index columns first when requested.
+func (df *DataFrame) displayCells(options DisplayOptions) displayTable {
+	shown := df.length
+	if options.MaxRows > 0 && shown > options.MaxRows {
+		shown = options.MaxRows
+	}
+
+	var table displayTable
+	var columns []*Series
+	positions := options.ShowIndex && df.index == nil
+	if positions {
+		table.header = append(table.header, "")
+		table.types = append(table.types, "")
+		table.numeric = append(table.numeric, true)
+	} else if options.ShowIndex {
+		columns = append(columns, df.index...)
+	}
+	for _, colName := range df.order {
+		columns = append(columns, df.columns[colName])
+	}
+	for _, series := range columns {
+		table.header = append(table.header, truncateCell(series.Name, options.MaxColumnWidth))
+		table.types = append(table.types, series.Type.String())
+		table.numeric = append(table.numeric, isNumericType(series.Type))
+	}
+
+	table.rows = make([][]string, shown)
+	for i := range table.rows {
+		row := make([]string, 0, len(table.header))
+		if positions {
+			row = append(row, strconv.Itoa(i))
+		}
+		for _, series := range columns {
+			value, _ := series.Get(i)
+			row = append(row, truncateCell(formatDisplayValue(value, options.FloatPrecision), options.MaxColumnWidth))
+		}
+		table.rows[i] = row
+	}
+	table.more = df.length - shown
+	return table
+}
+
+// formatDisplayValue formats a cell for display, with a fixed number of
+// decimals for float64 values when precision is positive.
+func formatDisplayValue(value any, precision int) string {
+	if f, ok := value.(float64); ok && precision > 0 {
+		return strconv.FormatFloat(f, 'f', precision, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// truncateCell cuts s to width characters, marking the cut with "…". A
+// width of 0 or less leaves s unchanged.
+func truncateCell(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
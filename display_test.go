@@ -0,0 +1,97 @@
+package otters
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestStringWith(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "description", []string{"a very long description", "short", "x"}),
+		mustSeries(t, "price", []float64{1.23456, 2, math.NaN()}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := df.StringWith(DisplayOptions{MaxRows: 2, MaxColumnWidth: 6, FloatPrecision: 2, ShowIndex: true})
+	want := "\tdescr…\tprice\n" +
+		"0\ta ver…\t1.23\n" +
+		"1\tshort\t2.00\n" +
+		"... (1 more rows)\n"
+	if got != want {
+		t.Errorf("StringWith =\n%q\nwant\n%q", got, want)
+	}
+
+	all := df.StringWith(DisplayOptions{})
+	if !strings.Contains(all, "a very long description\t1.23456\n") || !strings.Contains(all, "x\tNaN\n") {
+		t.Errorf("zero options should show every row in full:\n%s", all)
+	}
+
+	labelled := df.SetIndex("description").StringWith(DisplayOptions{ShowIndex: true})
+	if want := "description\tprice\na very long description\t1.23456\nshort\t2\nx\tNaN\n"; labelled != want {
+		t.Errorf("index labels should lead each row:\n%q\nwant\n%q", labelled, want)
+	}
+}
+
+func TestDefaultDisplayOptions(t *testing.T) {
+	defer func(saved DisplayOptions) { DefaultDisplayOptions = saved }(DefaultDisplayOptions)
+
+	values := make([]int64, 15)
+	df, err := NewDataFrameFromSeries(mustSeries(t, "n", values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(df.String(), "... (5 more rows)\n") {
+		t.Errorf("String should show 10 rows by default:\n%s", df.String())
+	}
+
+	DefaultDisplayOptions.MaxRows = 3
+	if got := strings.Count(df.String(), "\n"); got != 5 {
+		t.Errorf("String with MaxRows 3 has %d lines, want 5", got)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", "Bob"}),
+		mustSeries(t, "age", []int64{7, 123}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var plain strings.Builder
+	if err := df.PrintWith(&plain, DisplayOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "name      age\n" +
+		"string  int64\n" +
+		"------  -----\n" +
+		"Alice       7\n" +
+		"Bob       123\n"
+	if plain.String() != want {
+		t.Errorf("PrintWith =\n%s\nwant\n%s", plain.String(), want)
+	}
+
+	var boxed strings.Builder
+	if err := df.PrintWith(&boxed, DisplayOptions{Borders: true, MaxRows: 1, ShowIndex: true}); err != nil {
+		t.Fatal(err)
+	}
+	want = "┌───┬────────┬───────┐\n" +
+		"│   │ name   │   age │\n" +
+		"│   │ string │ int64 │\n" +
+		"├───┼────────┼───────┤\n" +
+		"│ 0 │ Alice  │     7 │\n" +
+		"└───┴────────┴───────┘\n" +
+		"... (1 more rows)\n"
+	if boxed.String() != want {
+		t.Errorf("PrintWith borders =\n%s\nwant\n%s", boxed.String(), want)
+	}
+
+	var failed strings.Builder
+	if err := df.Select("missing").Print(&failed); err != nil || !strings.HasPrefix(failed.String(), "DataFrame(error:") {
+		t.Errorf("Print of an error frame = %q, %v", failed.String(), err)
+	}
+}
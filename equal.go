@@ -0,0 +1,108 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// EqualOptions controls how Equals and CheckEqual compare DataFrames
+type EqualOptions struct {
+	Tolerance         float64 // Largest absolute difference at which float64 values still match
+	IgnoreColumnOrder bool    // Match columns by name regardless of their order
+}
+
+// Equals reports whether other has the same columns, types, values and row
+// index as the DataFrame. NaN matches NaN. Frames carrying an error are
+// never equal.
+func (df *DataFrame) Equals(other *DataFrame, options EqualOptions) bool {
+	return df.CheckEqual(other, options) == nil
+}
+
+// CheckEqual is Equals that describes the first difference found, for
+// asserting on whole frames in tests:
+//
+//	if err := got.CheckEqual(want, otters.EqualOptions{Tolerance: 1e-9}); err != nil {
+//		t.Error(err)
+//	}
+func (df *DataFrame) CheckEqual(other *DataFrame, options EqualOptions) error {
+	if df.err != nil {
+		return df.err
+	}
+	if other.err != nil {
+		return other.err
+	}
+
+	if df.length != other.length {
+		return newOpError("Equals", fmt.Sprintf("row count %d differs from %d", df.length, other.length))
+	}
+	want := other.order
+	if options.IgnoreColumnOrder {
+		want = slices.Sorted(slices.Values(other.order))
+		if got := slices.Sorted(slices.Values(df.order)); !slices.Equal(got, want) {
+			return newOpError("Equals", fmt.Sprintf("columns %v differ from %v", got, want))
+		}
+	} else if !slices.Equal(df.order, want) {
+		return newOpError("Equals", fmt.Sprintf("columns %v differ from %v", df.order, want))
+	}
+	for _, column := range want {
+		if err := equalSeries(df.columns[column], other.columns[column], options, "Equals"); err != nil {
+			return err
+		}
+	}
+
+	if len(df.index) != len(other.index) {
+		return newOpError("Equals", fmt.Sprintf("index has %d levels, expected %d", len(df.index), len(other.index)))
+	}
+	for i, level := range df.index {
+		if level.Name != other.index[i].Name {
+			return newOpError("Equals", fmt.Sprintf("index level %q differs from %q", level.Name, other.index[i].Name))
+		}
+		if err := equalSeries(level, other.index[i], options, "Equals"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// equalSeries returns an error naming the first row where a and b differ.
+func equalSeries(a, b *Series, options EqualOptions, op string) error {
+	if a.Type != b.Type {
+		return newColumnError(op, a.Name, fmt.Sprintf("type %s differs from %s", a.Type, b.Type))
+	}
+
+	row := -1
+	switch x := a.Data.(type) {
+	case []float64:
+		row = firstMismatch(x, b.Data.([]float64), func(p, q float64) bool {
+			return p == q || (math.IsNaN(p) && math.IsNaN(q)) || math.Abs(p-q) <= options.Tolerance
+		})
+	case []int64:
+		row = firstMismatch(x, b.Data.([]int64), func(p, q int64) bool { return p == q })
+	case []string:
+		row = firstMismatch(x, b.Data.([]string), func(p, q string) bool { return p == q })
+	case []bool:
+		row = firstMismatch(x, b.Data.([]bool), func(p, q bool) bool { return p == q })
+	case []time.Time:
+		row = firstMismatch(x, b.Data.([]time.Time), time.Time.Equal)
+	}
+	if row < 0 {
+		return nil
+	}
+
+	got, _ := a.Get(row)
+	want, _ := b.Get(row)
+	return &OtterError{Op: op, Column: a.Name, Row: row, Message: fmt.Sprintf("value %v differs from %v", got, want)}
+}
+
+// firstMismatch returns the first position where a and b do not match, or
+// -1. The slices have the same length.
+func firstMismatch[T any](a, b []T, match func(p, q T) bool) int {
+	for i := range a {
+		if !match(a[i], b[i]) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,51 @@
+package otters
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEquals(t *testing.T) {
+	tenth := 0.1 // not a constant, so the sum is rounded at run time
+	a, _ := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{1, 2}),
+		mustSeries(t, "score", []float64{tenth + 0.2, math.NaN()}),
+	)
+	b, _ := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{1, 2}),
+		mustSeries(t, "score", []float64{0.3, math.NaN()}),
+	)
+
+	if a.Equals(b, EqualOptions{}) {
+		t.Error("0.1+0.2 should differ from 0.3 without a tolerance")
+	}
+	if err := a.CheckEqual(b, EqualOptions{Tolerance: 1e-9}); err != nil {
+		t.Errorf("frames should match within the tolerance: %v", err)
+	}
+
+	swapped := b.ReorderColumns("score")
+	if swapped.Equals(b, EqualOptions{}) {
+		t.Error("column order should matter by default")
+	}
+	if !swapped.Equals(b, EqualOptions{IgnoreColumnOrder: true}) {
+		t.Error("IgnoreColumnOrder should match columns by name")
+	}
+
+	changed := b.Copy()
+	changed.Set(1, "id", int64(5))
+	var oe *OtterError
+	if err := changed.CheckEqual(b, EqualOptions{}); !errors.As(err, &oe) || oe.Column != "id" || oe.Row != 1 {
+		t.Errorf("expected a difference at id row 1, got %v", err)
+	}
+
+	if b.WithRowIndex().Equals(b, EqualOptions{}) {
+		t.Error("an index on one side only should differ")
+	}
+	if b.Head(1).Equals(b, EqualOptions{}) {
+		t.Error("different row counts should differ")
+	}
+	if b.Select("missing").Equals(b.Select("missing"), EqualOptions{}) {
+		t.Error("frames carrying errors are never equal")
+	}
+}
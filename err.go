@@ -11,6 +11,14 @@ type OtterError struct {
 	Row     int    // Row number (if applicable, -1 if not applicable)
 	Message string // Human-readable error message
 	Cause   error  // Underlying error (if any)
+
+	// Errors from parsing a Query or SQL string name the offending token
+	// and its byte offset in the query text. Token is empty when the query
+	// ended too soon; both are zero for other errors.
+	Token  string
+	Offset int
+
+	trace []TraceStep // Operations the error passed through (see ErrorTrace)
 }
 
 // Error implements the error interface
@@ -59,6 +67,18 @@ func newColumnError(op, column, message string) *OtterError {
 	}
 }
 
+// newSyntaxError creates a new error for a query that cannot be parsed,
+// pointing at the offending token
+func newSyntaxError(op string, offset int, token, message string) *OtterError {
+	return &OtterError{
+		Op:      op,
+		Message: fmt.Sprintf("syntax error at position %d: %s", offset, message),
+		Row:     -1,
+		Token:   token,
+		Offset:  offset,
+	}
+}
+
 // newRowError creates a new error for a row-related operation
 func newRowError(op string, row int, message string) *OtterError {
 	return &OtterError{
@@ -211,10 +231,21 @@ func validateSameLength(series []*Series) error {
 	return nil
 }
 
-// setError returns a new DataFrame carrying the error, leaving the receiver untouched.
+// setError returns a new DataFrame carrying the error, leaving the receiver
+// untouched. The receiver is the failed operation's input, whose shape is
+// recorded in the error's trace.
 func (df *DataFrame) setError(err error) *DataFrame {
 	newDf := NewDataFrame()
-	newDf.err = err
+	newDf.err = withTraceStep(err, "", df)
+	return newDf
+}
+
+// setOpError is setError for errors from shared helpers that report a
+// generic Op, such as "ColumnAccess" for a missing column: op names the
+// operation that failed in the error's trace.
+func (df *DataFrame) setOpError(op string, err error) *DataFrame {
+	newDf := NewDataFrame()
+	newDf.err = withTraceStep(err, op, df)
 	return newDf
 }
 
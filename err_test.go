@@ -225,3 +225,45 @@ func TestSentinelErrors(t *testing.T) {
 		})
 	}()
 }
+
+func TestErrorTrace(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "region", []string{"North", "South", "East"}),
+		mustSeries(t, "price", []int64{10, 20, 30}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if df.ErrorTrace() != nil {
+		t.Error("a DataFrame without an error has no trace")
+	}
+
+	// The step that failed is named, not the later ones passing it on
+	result := df.Filter("price", ">", 15).Select("region", "pirce").Sort("region", true)
+	trace := result.ErrorTrace()
+	if len(trace) != 1 {
+		t.Fatalf("trace = %v, want one step", trace)
+	}
+	if got := trace[0].String(); got != "Select(column=pirce) on 2x2" {
+		t.Errorf("step = %s, want Select(column=pirce) on 2x2", got)
+	}
+
+	// An operation wrapping another's error adds a step
+	result = df.Assign(map[string]func(*DataFrame) (*Series, error){
+		"total": func(d *DataFrame) (*Series, error) {
+			return d.Arith("total", "price", "*", "qty").GetSeries("total")
+		},
+	})
+	trace = result.ErrorTrace()
+	if len(trace) != 2 || trace[0].Op != "Arith" || trace[1].Op != "Assign" || trace[1].Args != "column=total" {
+		t.Errorf("trace = %v, want Arith then Assign(column=total)", trace)
+	}
+	if !errors.Is(result.Error(), ErrColumnNotFound) {
+		t.Errorf("tracing changed the error: %v", result.Error())
+	}
+	// Helper errors are traced to the operation that called the helper
+	result = df.Rolling("cost", 2).Mean()
+	if got := fmt.Sprint(result.ErrorTrace()); got != "[Rolling(column=cost) on 3x2]" {
+		t.Errorf("trace = %s, want [Rolling(column=cost) on 3x2]", got)
+	}
+}
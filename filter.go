@@ -0,0 +1,134 @@
+package otters
+
+// Condition is a single column comparison, as taken by Filter
+type Condition struct {
+	Column   string
+	Operator string
+	Value    any
+}
+
+// C builds a Condition for FilterAll and FilterAny
+func C(column, operator string, value any) Condition {
+	return Condition{Column: column, Operator: operator, Value: value}
+}
+
+// FilterAll creates a new DataFrame with rows matching every condition.
+// All conditions are evaluated in a single pass, so no intermediate frames
+// are materialized. With no conditions every row is kept.
+func (df *DataFrame) FilterAll(conditions ...Condition) *DataFrame {
+	return df.filterConditions("FilterAll", conditions, true)
+}
+
+// FilterAny creates a new DataFrame with rows matching at least one
+// condition, e.g.
+//
+//	df.FilterAny(C("region", "==", "North"), C("region", "==", "South"))
+//
+// All conditions are evaluated in a single pass. With no conditions no row
+// is kept.
+func (df *DataFrame) FilterAny(conditions ...Condition) *DataFrame {
+	return df.filterConditions("FilterAny", conditions, false)
+}
+
+// filterConditions combines the conditions with AND (all) or OR (!all).
+func (df *DataFrame) filterConditions(operation string, conditions []Condition, all bool) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if err := df.validateNotEmpty(); err != nil {
+		return df.setOpError(operation, err)
+	}
+
+	preds := make([]func(row int) bool, len(conditions))
+	isNull := make([]func(row int) bool, len(conditions))
+	for i, c := range conditions {
+		if err := df.validateColumnExists(c.Column); err != nil {
+			return df.setOpError(operation, err)
+		}
+		series := df.columns[c.Column]
+		pred, err := typedPredicate(series, c.Operator, c.Value)
+		if err != nil {
+			return df.setError(wrapColumnError(operation, c.Column, err))
+		}
+		preds[i] = pred
+		if holdsMissingValues(series.Type) && c.Operator != "isnull" && c.Operator != "notnull" {
+			isNull[i] = nullPredicate(series)
+		}
+	}
+
+	// Missing values are counted in the same scan, per condition
+	missing := make([]missingRows, len(conditions))
+	for i := range missing {
+		missing[i].first = -1
+	}
+	indices := make([]int, 0, df.length/4)
+	for row := 0; row < df.length; row++ {
+		for i, null := range isNull {
+			if null != nil && null(row) {
+				if missing[i].count == 0 {
+					missing[i].first = row
+				}
+				missing[i].count++
+			}
+		}
+		if matchesConditions(preds, row, all) {
+			indices = append(indices, row)
+		}
+	}
+
+	var warnings []Warning
+	for i, c := range conditions {
+		warnings = append(warnings, filterWarnings(df.columns[c.Column], c.Operator, c.Value, missing[i], operation)...)
+	}
+
+	result := df.selectRows(indices, operation)
+	if result.err == nil {
+		for _, w := range warnings {
+			result.warn(w)
+		}
+	}
+	return result
+}
+
+// matchesConditions reports whether all (or, if !all, any) predicates hold
+// for the row, stopping at the first one that decides the result.
+func matchesConditions(preds []func(row int) bool, row int, all bool) bool {
+	for _, pred := range preds {
+		if pred(row) != all {
+			return !all
+		}
+	}
+	return all
+}
+
+// FilterFunc creates a new DataFrame with the rows for which pred returns
+// true. It handles conditions Filter cannot express, such as comparisons
+// between columns:
+//
+//	df.FilterFunc(func(r Row) bool { return r.Float64("salary") > 2*r.Float64("bonus") })
+//
+// A typed read of a column that does not exist or has another type (see
+// Row.Err) fails the filter.
+func (df *DataFrame) FilterFunc(pred func(row Row) bool) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if err := df.validateNotEmpty(); err != nil {
+		return df.setOpError("FilterFunc", err)
+	}
+
+	var rowErr error
+	indices := make([]int, 0, df.length/4)
+	for i := 0; i < df.length; i++ {
+		if pred(Row{df: df, index: i, err: &rowErr}) {
+			indices = append(indices, i)
+		}
+	}
+	if rowErr != nil {
+		return df.setOpError("FilterFunc", rowErr)
+	}
+
+	return df.selectRows(indices, "FilterFunc")
+}
@@ -0,0 +1,90 @@
+package otters
+
+import "testing"
+
+func filterTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "region", []string{"North", "South", "East", "North", "West"}),
+		mustSeries(t, "sales", []int64{100, 250, 80, 300, 120}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestFilterAny(t *testing.T) {
+	df := filterTestFrame(t)
+
+	result := df.FilterAny(C("region", "==", "North"), C("region", "==", "South"))
+	if result.Error() != nil {
+		t.Fatalf("FilterAny error: %v", result.Error())
+	}
+	if result.Len() != 3 {
+		t.Fatalf("expected 3 rows, got %d", result.Len())
+	}
+	for i, want := range []string{"North", "South", "North"} {
+		if v, _ := result.Get(i, "region"); v != want {
+			t.Errorf("row %d: region = %v, want %s", i, v, want)
+		}
+	}
+
+	if got := df.FilterAny(); got.Len() != 0 {
+		t.Errorf("FilterAny with no conditions kept %d rows, want 0", got.Len())
+	}
+}
+
+func TestFilterAll(t *testing.T) {
+	df := filterTestFrame(t)
+
+	result := df.FilterAll(C("region", "==", "North"), C("sales", ">", 150))
+	if result.Error() != nil {
+		t.Fatalf("FilterAll error: %v", result.Error())
+	}
+	if result.Len() != 1 {
+		t.Fatalf("expected 1 row, got %d", result.Len())
+	}
+	if v, _ := result.Get(0, "sales"); v != int64(300) {
+		t.Errorf("sales = %v, want 300", v)
+	}
+
+	if got := df.FilterAll(); got.Len() != df.Len() {
+		t.Errorf("FilterAll with no conditions kept %d rows, want %d", got.Len(), df.Len())
+	}
+
+	if df.FilterAll(C("missing", "==", 1)).Error() == nil {
+		t.Error("expected error for missing column")
+	}
+	if df.FilterAny(C("sales", ">", "abc")).Error() == nil {
+		t.Error("expected error for unconvertible value")
+	}
+}
+
+func TestFilterFunc(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", "Bob", "Carol"}),
+		mustSeries(t, "salary", []float64{5000, 3000, 9000}),
+		mustSeries(t, "bonus", []int64{2000, 2000, 1000}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.FilterFunc(func(r Row) bool { return r.Float64("salary") > 2*r.Float64("bonus") })
+	if result.Error() != nil {
+		t.Fatalf("FilterFunc error: %v", result.Error())
+	}
+	if result.Len() != 2 {
+		t.Fatalf("expected 2 rows, got %d", result.Len())
+	}
+	if v, _ := result.Get(1, "name"); v != "Carol" {
+		t.Errorf("second row name = %v, want Carol", v)
+	}
+
+	var seen []int
+	df.FilterFunc(func(r Row) bool { seen = append(seen, r.Index()); return false })
+	if len(seen) != 3 || seen[2] != 2 {
+		t.Errorf("predicate saw rows %v, want [0 1 2]", seen)
+	}
+}
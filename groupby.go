@@ -0,0 +1,576 @@
+package otters
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GroupByOptions controls how GroupBy aggregations treat the columns that
+// are neither group keys nor numeric.
+type GroupByOptions struct {
+	// NonNumeric is what Sum, Mean and the other numeric aggregations do
+	// with string, bool and time columns:
+	//   ""/"drop" - leave them out of the result (the GroupBy default)
+	//   "first"   - keep each group's first value
+	//   "last"    - keep each group's last value
+	//   "count"   - count each group's non-missing values (int64)
+	//   "join"    - join each group's distinct values with Separator
+	NonNumeric string
+
+	// Separator joins values for NonNumeric "join" (default ", ")
+	Separator string
+
+	// ColumnName names the aggregated columns. "{column}" is replaced with
+	// the source column and "{agg}" with the aggregation ("sum", "mean",
+	// "q95" for Quantile(0.95), or the NonNumeric strategy), so
+	// "{column}_{agg}" gives "sales_sum" and "{agg}_{column}" gives
+	// "sum_sales". Empty keeps the source column names.
+	ColumnName string
+}
+
+// GroupByWithOptions groups the DataFrame by the given columns like GroupBy,
+// with options for the aggregations.
+func (df *DataFrame) GroupByWithOptions(columns []string, options GroupByOptions) *GroupBy {
+	gb := df.GroupBy(columns...)
+	if gb.err != nil {
+		return gb
+	}
+
+	switch options.NonNumeric {
+	case "", "drop", "first", "last", "count", "join":
+	default:
+		gb.err = newOpError("GroupByWithOptions", fmt.Sprintf("unknown NonNumeric strategy %q", options.NonNumeric))
+		return gb
+	}
+	if options.ColumnName != "" && !strings.Contains(options.ColumnName, "{column}") {
+		gb.err = newOpError("GroupByWithOptions", "ColumnName must contain {column}")
+		return gb
+	}
+	if options.Separator == "" {
+		options.Separator = ", "
+	}
+
+	gb.options = options
+	return gb
+}
+
+// resultColumnName names the aggregated column for column under the
+// ColumnName template.
+func (gb *GroupBy) resultColumnName(column, agg string) string {
+	if gb.options.ColumnName == "" {
+		return column
+	}
+	return strings.NewReplacer("{column}", column, "{agg}", agg).Replace(gb.options.ColumnName)
+}
+
+// nonNumericAggregate reduces a non-numeric column to one value per group
+// following the NonNumeric option, or returns nil to drop it.
+func (gb *GroupBy) nonNumericAggregate(column string, groups []*groupKey) any {
+	series := gb.df.columns[column]
+	isNull := nullPredicate(series)
+
+	switch gb.options.NonNumeric {
+	case "first", "last":
+		rows := make([]int, len(groups))
+		for i, g := range groups {
+			rows[i] = g.indices[0]
+			if gb.options.NonNumeric == "last" {
+				rows[i] = g.indices[len(g.indices)-1]
+			}
+		}
+		return selectSeriesRows(series, rows)
+	case "count":
+		counts := make([]int64, len(groups))
+		for i, g := range groups {
+			for _, row := range g.indices {
+				if !isNull(row) {
+					counts[i]++
+				}
+			}
+		}
+		return counts
+	case "join":
+		joined := make([]string, len(groups))
+		seen := make(map[string]struct{})
+		for i, g := range groups {
+			clear(seen)
+			var values []string
+			for _, row := range g.indices {
+				if isNull(row) {
+					continue
+				}
+				v, _ := series.Get(row)
+				s := formatValueForCSV(v)
+				if _, dup := seen[s]; !dup {
+					seen[s] = struct{}{}
+					values = append(values, s)
+				}
+			}
+			joined[i] = strings.Join(values, gb.options.Separator)
+		}
+		return joined
+	default:
+		return nil
+	}
+}
+
+// Quantile calculates the q-th quantile (0 <= q <= 1) of every numeric
+// column for each group, interpolating linearly as DataFrame.Quantile does.
+func (gb *GroupBy) Quantile(q float64) (*DataFrame, error) {
+	if gb.err != nil {
+		return nil, gb.err
+	}
+	if q < 0 || q > 1 {
+		return nil, newOpError("Quantile", "quantile must be between 0 and 1")
+	}
+
+	groups := gb.buildGroups()
+	gb.sortGroups(groups)
+
+	keys, err := gb.keyColumns(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	numericCols := identifyNumericColumns(gb.df, gb.columns, len(groups))
+	columnValues := make([][]float64, len(numericCols))
+	for i, nc := range numericCols {
+		columnValues[i] = numericAsFloat64(gb.df.columns[nc.name])
+	}
+
+	for _, g := range groups {
+		for i := range numericCols {
+			group := selectFloat64Rows(columnValues[i], g.indices)
+			sort.Float64s(group)
+			numericCols[i].data = append(numericCols[i].data, quantileSorted(group, q, "linear"))
+		}
+	}
+
+	return gb.buildResultDataFrame(groups, keys, numericCols, quantileName(q))
+}
+
+// quantileName names the aggregation of quantile q by its percentage, e.g.
+// "q95" or "q2.5". q*100 is rounded to four decimals first, so rounding
+// error in the product (0.07*100 = 7.000000000000001) does not reach the
+// name.
+func quantileName(q float64) string {
+	return "q" + strconv.FormatFloat(math.Round(q*1e6)/1e4, 'g', -1, 64)
+}
+
+// AggFunc runs fn over the values of a numeric column in each group and
+// returns the group columns plus a float64 column called name. Int64
+// columns are converted to float64 first. Each call to fn receives a fresh
+// slice, so fn may sort or otherwise modify it.
+//
+//	spread := func(v []float64) float64 { return slices.Max(v) - slices.Min(v) }
+//	result, err := df.GroupBy("region").AggFunc("price", "price_spread", spread)
+func (gb *GroupBy) AggFunc(column, name string, fn func([]float64) float64) (*DataFrame, error) {
+	if err := gb.validateAggColumn("AggFunc", column, name); err != nil {
+		return nil, err
+	}
+	series := gb.df.columns[column]
+	if !isNumericType(series.Type) {
+		return nil, newColumnError("AggFunc", column, "column must be numeric (int64 or float64)")
+	}
+	return aggregateFunc(gb, name, numericAsFloat64(series), fn)
+}
+
+// AggFuncInt64 is AggFunc for int64 columns; the result column is int64.
+func (gb *GroupBy) AggFuncInt64(column, name string, fn func([]int64) int64) (*DataFrame, error) {
+	if err := gb.validateAggColumn("AggFuncInt64", column, name); err != nil {
+		return nil, err
+	}
+	series := gb.df.columns[column]
+	if series.Type != Int64Type {
+		return nil, newColumnError("AggFuncInt64", column, "column must be int64")
+	}
+	return aggregateFunc(gb, name, series.Data.([]int64), fn)
+}
+
+// AggFuncString is AggFunc for string columns; the result column is string.
+func (gb *GroupBy) AggFuncString(column, name string, fn func([]string) string) (*DataFrame, error) {
+	if err := gb.validateAggColumn("AggFuncString", column, name); err != nil {
+		return nil, err
+	}
+	series := gb.df.columns[column]
+	if series.Type != StringType {
+		return nil, newColumnError("AggFuncString", column, "column must be string")
+	}
+	return aggregateFunc(gb, name, series.Data.([]string), fn)
+}
+
+// validateAggColumn checks that column exists and that the result column
+// name does not clash with a group column.
+func (gb *GroupBy) validateAggColumn(op, column, name string) error {
+	if gb.err != nil {
+		return gb.err
+	}
+	if err := gb.df.validateColumnExists(column); err != nil {
+		return wrapColumnError(op, column, err)
+	}
+	if name == "" {
+		return newOpError(op, "result column name cannot be empty")
+	}
+	if contains(gb.columns, name) {
+		return newColumnError(op, name, "result column name clashes with a group column")
+	}
+	return nil
+}
+
+// aggregateFunc applies fn to each group's values of data and builds a
+// result frame of the group columns plus the column name.
+func aggregateFunc[T, R any](gb *GroupBy, name string, data []T, fn func([]T) R) (*DataFrame, error) {
+	groups := gb.buildGroups()
+	gb.sortGroups(groups)
+
+	keys, err := gb.keyColumns(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]R, 0, len(groups))
+	for _, g := range groups {
+		values := make([]T, len(g.indices))
+		for i, idx := range g.indices {
+			values[i] = data[idx]
+		}
+		results = append(results, fn(values))
+	}
+
+	s, err := newSeriesOwned(name, results)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDataFrameFromSeries(append(keys, s)...)
+}
+
+// First returns the first row of each group with every column kept.
+func (gb *GroupBy) First() (*DataFrame, error) {
+	return gb.nthRows(0, "First")
+}
+
+// Last returns the last row of each group with every column kept, e.g. the
+// latest record per key when the frame is sorted by time.
+func (gb *GroupBy) Last() (*DataFrame, error) {
+	return gb.nthRows(-1, "Last")
+}
+
+// Nth returns the n-th row (0-based) of each group with every column kept.
+// A negative n counts from the end of the group, so -1 is the last row.
+// Groups with too few rows are left out.
+func (gb *GroupBy) Nth(n int) (*DataFrame, error) {
+	return gb.nthRows(n, "Nth")
+}
+
+// nthRows selects the n-th row of each group, with groups in key order.
+func (gb *GroupBy) nthRows(n int, op string) (*DataFrame, error) {
+	if gb.err != nil {
+		return nil, gb.err
+	}
+
+	groups := gb.buildGroups()
+	gb.sortGroups(groups)
+
+	rows := make([]int, 0, len(groups))
+	for _, g := range groups {
+		i := n
+		if i < 0 {
+			i += len(g.indices)
+		}
+		if i >= 0 && i < len(g.indices) {
+			rows = append(rows, g.indices[i])
+		}
+	}
+
+	result := gb.df.selectRows(rows, op)
+	if err := result.Error(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// NUnique counts the distinct values of every non-group column in each
+// group. Missing values (NaN, zero times) are not counted.
+func (gb *GroupBy) NUnique() (*DataFrame, error) {
+	if gb.err != nil {
+		return nil, gb.err
+	}
+
+	groups := gb.buildGroups()
+	gb.sortGroups(groups)
+
+	resultSeries, err := gb.keyColumns(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, col := range gb.df.order {
+		if contains(gb.columns, col) {
+			continue
+		}
+		series := gb.df.columns[col]
+		isNull := nullPredicate(series)
+		counts := make([]int64, len(groups))
+		seen := make(map[string]struct{})
+		for i, g := range groups {
+			clear(seen)
+			for _, row := range g.indices {
+				if !isNull(row) {
+					seen[seriesValueToString(series, row)] = struct{}{}
+				}
+			}
+			counts[i] = int64(len(seen))
+		}
+		s, err := newSeriesOwned(col, counts)
+		if err != nil {
+			return nil, err
+		}
+		resultSeries = append(resultSeries, s)
+	}
+
+	return NewDataFrameFromSeries(resultSeries...)
+}
+
+// Transform computes operation ("sum", "mean", "count", "min", "max",
+// "median", "std" or "var") for every numeric non-group column per group,
+// and returns the original frame with the results appended as
+// "<column>_<operation>", repeated on each row of the group. This keeps
+// group statistics aligned with the rows for demeaning or share-of-group
+// calculations:
+//
+//	withMean, err := df.GroupBy("region").Transform("mean")
+//	demeaned := withMean.Arith("sales_demeaned", "sales", "-", "sales_mean")
+func (gb *GroupBy) Transform(operation string) (*DataFrame, error) {
+	if gb.err != nil {
+		return nil, gb.err
+	}
+
+	groups := gb.buildGroups()
+	result := gb.df.Copy()
+	for _, nc := range identifyNumericColumns(gb.df, gb.columns, 0) {
+		column := nc.name
+		err := broadcastGroups(result, groups, column+"_"+operation, "Transform", func(indices []int) (float64, error) {
+			return gb.calculateAggregation(column, indices, operation)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// TransformFunc runs fn over each group's values of a numeric column and
+// returns the original frame with the result appended as the float64
+// column name, repeated on each row of the group. Int64 columns are
+// converted to float64 first; fn may modify the slice it receives.
+func (gb *GroupBy) TransformFunc(column, name string, fn func([]float64) float64) (*DataFrame, error) {
+	if err := gb.validateAggColumn("TransformFunc", column, name); err != nil {
+		return nil, err
+	}
+	series := gb.df.columns[column]
+	if !isNumericType(series.Type) {
+		return nil, newColumnError("TransformFunc", column, "column must be numeric (int64 or float64)")
+	}
+
+	values := numericAsFloat64(series)
+	result := gb.df.Copy()
+	err := broadcastGroups(result, gb.buildGroups(), name, "TransformFunc", func(indices []int) (float64, error) {
+		return fn(selectFloat64Rows(values, indices)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// broadcastGroups appends to df a float64 column name holding, on every
+// row, agg of the group the row belongs to.
+func broadcastGroups(df *DataFrame, groups []*groupKey, name, op string, agg func(indices []int) (float64, error)) error {
+	if _, exists := df.columns[name]; exists {
+		return newColumnError(op, name, "column already exists")
+	}
+
+	data := make([]float64, df.length)
+	for _, g := range groups {
+		v, err := agg(g.indices)
+		if err != nil {
+			return wrapError(op, err)
+		}
+		for _, row := range g.indices {
+			data[row] = v
+		}
+	}
+
+	series, err := newSeriesOwned(name, data)
+	if err != nil {
+		return wrapColumnError(op, name, err)
+	}
+	return df.addSeriesUnsafe(series)
+}
+
+// Apply calls fn with each group's key values and rows, in key order, and
+// stacks the frames it returns. The results must share the same columns
+// (see Concat for how int64 and float64 combine); a nil result drops the
+// group. Apply covers what the fixed aggregations cannot, such as
+// sorting within groups or keeping the top rows of each:
+//
+//	top2, err := df.GroupBy("region").Apply(func(key []string, g *DataFrame) (*DataFrame, error) {
+//		return g.Sort("sales", false).Head(2), nil
+//	})
+func (gb *GroupBy) Apply(fn func(key []string, group *DataFrame) (*DataFrame, error)) (*DataFrame, error) {
+	if gb.err != nil {
+		return nil, gb.err
+	}
+
+	groups := gb.buildGroups()
+	gb.sortGroups(groups)
+
+	frames := make([]*DataFrame, 0, len(groups))
+	for _, g := range groups {
+		if err := contextError("Apply", gb.ctx); err != nil {
+			return nil, err
+		}
+		group := gb.df.selectRows(g.indices, "Apply")
+		if err := group.Error(); err != nil {
+			return nil, err
+		}
+		result, err := fn(slices.Clone(g.values), group)
+		if err == nil && result != nil {
+			err = result.Error()
+		}
+		if err != nil {
+			return nil, &OtterError{Op: "Apply", Message: fmt.Sprintf("group %v: %v", g.values, err), Cause: err, Row: -1}
+		}
+		if result != nil {
+			frames = append(frames, result)
+		}
+	}
+
+	return concatFrames(frames, "Apply")
+}
+
+// GroupKey identifies a group by its values in the group columns, in
+// GroupBy column order and formatted as strings.
+type GroupKey []string
+
+// Error returns the error carried by the GroupBy, if any. Groups and All
+// return nothing when there is one; an error that stops All partway is
+// recorded here.
+func (gb *GroupBy) Error() error {
+	return gb.err
+}
+
+// Groups returns the key of every group, in key order.
+func (gb *GroupBy) Groups() []GroupKey {
+	if gb.err != nil {
+		return nil
+	}
+
+	groups := gb.buildGroups()
+	gb.sortGroups(groups)
+
+	keys := make([]GroupKey, len(groups))
+	for i, g := range groups {
+		keys[i] = GroupKey(slices.Clone(g.values))
+	}
+	return keys
+}
+
+// GetGroup returns the rows of the group with the given key.
+func (gb *GroupBy) GetGroup(key GroupKey) (*DataFrame, error) {
+	if gb.err != nil {
+		return nil, gb.err
+	}
+	if len(key) != len(gb.columns) {
+		return nil, newOpError("GetGroup",
+			fmt.Sprintf("key has %d values, expected %d", len(key), len(gb.columns)))
+	}
+
+	for _, g := range gb.buildGroups() {
+		if slices.Equal(g.values, key) {
+			group := gb.df.selectRows(g.indices, "GetGroup")
+			if err := group.Error(); err != nil {
+				return nil, err
+			}
+			return group, nil
+		}
+	}
+	return nil, newOpError("GetGroup", fmt.Sprintf("group %v not found", []string(key)))
+}
+
+// All returns an iterator over the groups in key order, yielding each
+// group's key and rows. If a group's rows cannot be selected the iteration
+// stops and the error is recorded on the GroupBy, so check Error after the
+// loop:
+//
+//	gb := df.GroupBy("region")
+//	for key, group := range gb.All() {
+//		fmt.Println(key, group.Len())
+//	}
+//	if err := gb.Error(); err != nil {
+//		return err
+//	}
+func (gb *GroupBy) All() iter.Seq2[GroupKey, *DataFrame] {
+	return func(yield func(GroupKey, *DataFrame) bool) {
+		if gb.err != nil {
+			return
+		}
+
+		groups := gb.buildGroups()
+		gb.sortGroups(groups)
+		for _, g := range groups {
+			group := gb.df.selectRows(g.indices, "All")
+			if err := group.Error(); err != nil {
+				gb.err = err
+				return
+			}
+			if !yield(GroupKey(slices.Clone(g.values)), group) {
+				return
+			}
+		}
+	}
+}
+
+// GroupByTime groups rows by the calendar bucket of a TimeType column,
+// optionally together with further group columns. The rule is any
+// Resample rule ("15m", "1h", "1d", "1w") or a calendar unit: "1M" for
+// months, "1Q" for quarters, "1Y" for years. Within the groups, and in
+// aggregated results, column holds the start of each row's bucket:
+//
+//	monthly, err := df.GroupByTime("date", "1M").Sum()
+func (df *DataFrame) GroupByTime(column, rule string, columns ...string) *GroupBy {
+	if df.err != nil {
+		return &GroupBy{df: df, err: df.err}
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return &GroupBy{df: df, err: err}
+	}
+	if df.columns[column].Type != TimeType {
+		return &GroupBy{df: df, err: newColumnError("GroupByTime", column, "column must be of type time")}
+	}
+
+	bucket, err := parseTimeBucket(rule)
+	if err != nil {
+		return &GroupBy{df: df, err: wrapColumnError("GroupByTime", column, err)}
+	}
+
+	times := df.columns[column].Data.([]time.Time)
+	starts := make([]time.Time, len(times))
+	for i, t := range times {
+		starts[i] = bucket(t)
+	}
+
+	bucketed := df.withReplacedColumn(column, starts, "GroupByTime")
+	if bucketed.err != nil {
+		return &GroupBy{df: df, err: bucketed.err}
+	}
+	return bucketed.GroupBy(append([]string{column}, columns...)...)
+}
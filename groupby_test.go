@@ -0,0 +1,489 @@
+package otters
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func groupByTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "region", []string{"North", "South", "North", "South", "North"}),
+		mustSeries(t, "product", []string{"a", "b", "c", "d", "e"}),
+		mustSeries(t, "units", []int64{3, 7, 1, 2, 5}),
+		mustSeries(t, "price", []float64{10, 40, 30, 20, 50}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestGroupByAggFunc(t *testing.T) {
+	df := groupByTestFrame(t)
+	gb := df.GroupBy("region")
+
+	spread := func(v []float64) float64 { return slices.Max(v) - slices.Min(v) }
+	result, err := gb.AggFunc("price", "price_spread", spread)
+	if err != nil {
+		t.Fatalf("AggFunc error: %v", err)
+	}
+	if got := result.Columns(); !slices.Equal(got, []string{"region", "price_spread"}) {
+		t.Fatalf("columns = %v", got)
+	}
+	if got := result.columns["price_spread"].Float64Slice(); !slices.Equal(got, []float64{40, 20}) {
+		t.Errorf("price_spread = %v, want [40 20]", got)
+	}
+
+	// Int64 columns are converted for AggFunc.
+	if _, err := gb.AggFunc("units", "u", spread); err != nil {
+		t.Errorf("AggFunc on int64 column: %v", err)
+	}
+
+	ints, err := gb.AggFuncInt64("units", "units_max", slices.Max[[]int64])
+	if err != nil {
+		t.Fatalf("AggFuncInt64 error: %v", err)
+	}
+	if got := ints.columns["units_max"].Int64Slice(); !slices.Equal(got, []int64{5, 7}) {
+		t.Errorf("units_max = %v, want [5 7]", got)
+	}
+
+	strs, err := gb.AggFuncString("product", "products", func(v []string) string { return strings.Join(v, ",") })
+	if err != nil {
+		t.Fatalf("AggFuncString error: %v", err)
+	}
+	if got := strs.columns["products"].StringSlice(); !slices.Equal(got, []string{"a,c,e", "b,d"}) {
+		t.Errorf("products = %v, want [a,c,e b,d]", got)
+	}
+
+	if _, err := gb.AggFunc("product", "x", spread); err == nil {
+		t.Error("expected error for non-numeric column")
+	}
+	if _, err := gb.AggFuncInt64("price", "x", slices.Max[[]int64]); err == nil {
+		t.Error("expected error for non-int64 column")
+	}
+	if _, err := gb.AggFunc("price", "region", spread); err == nil {
+		t.Error("expected error for name clashing with group column")
+	}
+	if _, err := gb.AggFunc("missing", "x", spread); err == nil {
+		t.Error("expected error for missing column")
+	}
+}
+
+func TestGroupBySpreadAggregations(t *testing.T) {
+	df := groupByTestFrame(t)
+	gb := df.GroupBy("region")
+
+	cases := []struct {
+		name string
+		run  func() (*DataFrame, error)
+		want []float64 // price for North, South
+	}{
+		{"median", gb.Median, []float64{30, 30}},
+		{"var", gb.Var, []float64{400, 200}},
+		{"quantile", func() (*DataFrame, error) { return gb.Quantile(0.25) }, []float64{20, 25}},
+	}
+	for _, c := range cases {
+		result, err := c.run()
+		if err != nil {
+			t.Fatalf("%s error: %v", c.name, err)
+		}
+		if got := result.columns["price"].Float64Slice(); !slices.Equal(got, c.want) {
+			t.Errorf("%s price = %v, want %v", c.name, got, c.want)
+		}
+		if _, ok := result.columns["units"]; !ok {
+			t.Errorf("%s result is missing the int64 units column", c.name)
+		}
+	}
+
+	std, err := gb.Std()
+	if err != nil {
+		t.Fatalf("Std error: %v", err)
+	}
+	if got, _ := std.Get(0, "price"); got != 20.0 {
+		t.Errorf("North price std = %v, want 20", got)
+	}
+
+	single, err := df.GroupBy("product").Std()
+	if err != nil {
+		t.Fatalf("Std error: %v", err)
+	}
+	if got, _ := single.Get(0, "price"); !math.IsNaN(got.(float64)) {
+		t.Errorf("std of a single-row group = %v, want NaN", got)
+	}
+
+	if _, err := gb.Quantile(1.5); err == nil {
+		t.Error("expected error for quantile outside [0, 1]")
+	}
+}
+
+func TestGroupByFirstLastNth(t *testing.T) {
+	df := groupByTestFrame(t)
+	gb := df.GroupBy("region")
+
+	cases := []struct {
+		name string
+		run  func() (*DataFrame, error)
+		want []string // product per group
+	}{
+		{"first", gb.First, []string{"a", "b"}},
+		{"last", gb.Last, []string{"e", "d"}},
+		{"nth", func() (*DataFrame, error) { return gb.Nth(1) }, []string{"c", "d"}},
+		{"nth negative", func() (*DataFrame, error) { return gb.Nth(-3) }, []string{"a"}},
+		{"nth too large", func() (*DataFrame, error) { return gb.Nth(5) }, nil},
+	}
+	for _, c := range cases {
+		result, err := c.run()
+		if err != nil {
+			t.Fatalf("%s error: %v", c.name, err)
+		}
+		if got := result.Columns(); !slices.Equal(got, df.Columns()) {
+			t.Errorf("%s columns = %v, want all of %v", c.name, got, df.Columns())
+		}
+		if got := result.columns["product"].StringSlice(); !slices.Equal(got, c.want) {
+			t.Errorf("%s product = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	last, _ := gb.Last()
+	if got := last.columns["units"].Int64Slice(); !slices.Equal(got, []int64{5, 2}) {
+		t.Errorf("last units = %v, want [5 2] with int64 type kept", got)
+	}
+}
+
+func TestGroupByNUnique(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "store", []string{"x", "x", "x", "y", "y"}),
+		mustSeries(t, "sku", []string{"a", "b", "a", "c", "c"}),
+		mustSeries(t, "price", []float64{1, math.NaN(), 1, 2, 3}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := df.GroupBy("store").NUnique()
+	if err != nil {
+		t.Fatalf("NUnique error: %v", err)
+	}
+	if got := result.columns["sku"].Int64Slice(); !slices.Equal(got, []int64{2, 1}) {
+		t.Errorf("sku = %v, want [2 1]", got)
+	}
+	if got := result.columns["price"].Int64Slice(); !slices.Equal(got, []int64{1, 2}) {
+		t.Errorf("price = %v, want [1 2] with NaN not counted", got)
+	}
+}
+
+func TestGroupByTransform(t *testing.T) {
+	df := groupByTestFrame(t)
+	gb := df.GroupBy("region")
+
+	result, err := gb.Transform("mean")
+	if err != nil {
+		t.Fatalf("Transform error: %v", err)
+	}
+	if result.Len() != df.Len() {
+		t.Fatalf("Transform changed row count to %d", result.Len())
+	}
+	if got := result.columns["price_mean"].Float64Slice(); !slices.Equal(got, []float64{30, 30, 30, 30, 30}) {
+		t.Errorf("price_mean = %v", got)
+	}
+	if got := result.columns["units_mean"].Float64Slice(); !slices.Equal(got, []float64{3, 4.5, 3, 4.5, 3}) {
+		t.Errorf("units_mean = %v", got)
+	}
+	if _, ok := df.columns["price_mean"]; ok {
+		t.Error("Transform modified the source frame")
+	}
+
+	total := func(v []float64) float64 {
+		var s float64
+		for _, x := range v {
+			s += x
+		}
+		return s
+	}
+	shares, err := gb.TransformFunc("units", "region_units", total)
+	if err != nil {
+		t.Fatalf("TransformFunc error: %v", err)
+	}
+	if got := shares.columns["region_units"].Float64Slice(); !slices.Equal(got, []float64{9, 9, 9, 9, 9}) {
+		t.Errorf("region_units = %v", got)
+	}
+
+	if _, err := gb.Transform("bogus"); err == nil {
+		t.Error("expected error for unsupported operation")
+	}
+	if _, err := gb.TransformFunc("price", "units", total); err == nil {
+		t.Error("expected error for existing result column")
+	}
+}
+
+func TestGroupByApply(t *testing.T) {
+	df := groupByTestFrame(t)
+
+	var keys [][]string
+	result, err := df.GroupBy("region").Apply(func(key []string, g *DataFrame) (*DataFrame, error) {
+		keys = append(keys, key)
+		if key[0] == "South" {
+			return nil, nil
+		}
+		return g.Sort("price", false).Head(2), nil
+	})
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	if len(keys) != 2 || keys[0][0] != "North" || keys[1][0] != "South" {
+		t.Errorf("fn saw keys %v, want [[North] [South]]", keys)
+	}
+	if got := result.columns["product"].StringSlice(); !slices.Equal(got, []string{"e", "c"}) {
+		t.Errorf("product = %v, want [e c]", got)
+	}
+
+	_, err = df.GroupBy("region").Apply(func(key []string, g *DataFrame) (*DataFrame, error) {
+		return g.Select("missing"), nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "North") {
+		t.Errorf("expected error naming the group, got %v", err)
+	}
+}
+
+func TestGroupByIteration(t *testing.T) {
+	df := groupByTestFrame(t)
+	gb := df.GroupBy("region")
+
+	keys := gb.Groups()
+	if len(keys) != 2 || keys[0][0] != "North" || keys[1][0] != "South" {
+		t.Fatalf("Groups() = %v, want [[North] [South]]", keys)
+	}
+
+	south, err := gb.GetGroup(GroupKey{"South"})
+	if err != nil {
+		t.Fatalf("GetGroup error: %v", err)
+	}
+	if got := south.columns["product"].StringSlice(); !slices.Equal(got, []string{"b", "d"}) {
+		t.Errorf("South products = %v, want [b d]", got)
+	}
+	if _, err := gb.GetGroup(GroupKey{"West"}); err == nil {
+		t.Error("expected error for unknown group")
+	}
+	if _, err := gb.GetGroup(GroupKey{"North", "a"}); err == nil {
+		t.Error("expected error for key of wrong length")
+	}
+
+	sizes := map[string]int{}
+	for key, group := range gb.All() {
+		sizes[key[0]] = group.Len()
+		break
+	}
+	if len(sizes) != 1 || sizes["North"] != 3 {
+		t.Errorf("iteration with break saw %v, want map[North:3]", sizes)
+	}
+
+	bad := df.GroupBy("missing")
+	if bad.Error() == nil || bad.Groups() != nil {
+		t.Error("expected error and no groups for missing column")
+	}
+	// A group whose rows cannot be selected stops All with an error
+	broken := df.Copy()
+	broken.columns["product"] = &Series{Name: "product", Type: ColumnType(99), Data: []int64{1, 2, 3, 4, 5}, Length: 5}
+	brokenGroups := broken.GroupBy("region")
+	seen := 0
+	for range brokenGroups.All() {
+		seen++
+	}
+	if seen != 0 || brokenGroups.Error() == nil {
+		t.Errorf("All over a broken frame saw %d groups, error %v; want 0 and an error", seen, brokenGroups.Error())
+	}
+}
+
+func TestGroupByTime(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 15, 30, 0, 0, time.UTC) }
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "date", []time.Time{day(2024, 1, 5), day(2024, 1, 20), day(2024, 2, 1), day(2024, 4, 9)}),
+		mustSeries(t, "sales", []int64{10, 20, 30, 40}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		rule string
+		want []float64
+	}{
+		{"1M", []float64{30, 30, 40}},
+		{"1Q", []float64{60, 40}},
+		{"1Y", []float64{100}},
+		{"1d", []float64{10, 20, 30, 40}},
+	}
+	for _, c := range cases {
+		result, err := df.GroupByTime("date", c.rule).Sum()
+		if err != nil {
+			t.Fatalf("%s: Sum error: %v", c.rule, err)
+		}
+		if got := result.columns["sales"].Float64Slice(); !slices.Equal(got, c.want) {
+			t.Errorf("%s: sales = %v, want %v", c.rule, got, c.want)
+		}
+	}
+
+	first, err := df.GroupByTime("date", "1M").First()
+	if err != nil {
+		t.Fatalf("First error: %v", err)
+	}
+	if got, _ := first.Get(0, "date"); got != time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("first bucket = %v, want 2024-01-01", got)
+	}
+
+	if df.GroupByTime("sales", "1M").Error() == nil {
+		t.Error("expected error for non-time column")
+	}
+	if df.GroupByTime("date", "0M").Error() == nil {
+		t.Error("expected error for zero-width rule")
+	}
+	if df.GroupByTime("date", "xM").Error() == nil {
+		t.Error("expected error for malformed rule")
+	}
+}
+
+func TestGroupByKeepsKeyTypes(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "quarter", []int64{10, 9, 10, 2}),
+		mustSeries(t, "active", []bool{true, false, true, true}),
+		mustSeries(t, "sales", []float64{1, 2, 3, 4}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := df.GroupBy("quarter", "active").Sum()
+	if err != nil {
+		t.Fatalf("Sum error: %v", err)
+	}
+	if got, _ := result.GetColumnType("quarter"); got != Int64Type {
+		t.Fatalf("quarter type = %v, want int64", got)
+	}
+	if got, _ := result.GetColumnType("active"); got != BoolType {
+		t.Errorf("active type = %v, want bool", got)
+	}
+	if got := result.columns["quarter"].Int64Slice(); !slices.Equal(got, []int64{2, 9, 10}) {
+		t.Errorf("quarter = %v, want numeric order [2 9 10]", got)
+	}
+
+	count, err := df.GroupBy("quarter").Count()
+	if err != nil {
+		t.Fatalf("Count error: %v", err)
+	}
+	if got := count.columns["count"].Int64Slice(); !slices.Equal(got, []int64{1, 1, 2}) {
+		t.Errorf("count = %v, want [1 1 2]", got)
+	}
+
+	// Results can be filtered with typed values again.
+	if got := result.Filter("quarter", ">", 5).Len(); got != 2 {
+		t.Errorf("Filter on int64 key kept %d rows, want 2", got)
+	}
+}
+
+func TestGroupByNonNumericStrategies(t *testing.T) {
+	df := groupByTestFrame(t)
+
+	cases := []struct {
+		strategy string
+		want     any // product column, or nil when dropped
+	}{
+		{"", nil},
+		{"drop", nil},
+		{"first", []string{"a", "b"}},
+		{"last", []string{"e", "d"}},
+		{"count", []int64{3, 2}},
+		{"join", []string{"a|c|e", "b|d"}},
+	}
+	for _, c := range cases {
+		gb := df.GroupByWithOptions([]string{"region"}, GroupByOptions{NonNumeric: c.strategy, Separator: "|"})
+		result, err := gb.Sum()
+		if err != nil {
+			t.Fatalf("%q: Sum error: %v", c.strategy, err)
+		}
+		series, ok := result.columns["product"]
+		if c.want == nil {
+			if ok {
+				t.Errorf("%q: product column should be dropped", c.strategy)
+			}
+			continue
+		}
+		if !ok {
+			t.Fatalf("%q: product column missing", c.strategy)
+		}
+		if got := result.Columns(); !slices.Equal(got, []string{"region", "product", "units", "price"}) {
+			t.Errorf("%q: columns = %v, want frame order", c.strategy, got)
+		}
+		switch want := c.want.(type) {
+		case []string:
+			if got := series.StringSlice(); !slices.Equal(got, want) {
+				t.Errorf("%q: product = %v, want %v", c.strategy, got, want)
+			}
+		case []int64:
+			if got := series.Int64Slice(); !slices.Equal(got, want) {
+				t.Errorf("%q: product = %v, want %v", c.strategy, got, want)
+			}
+		}
+	}
+
+	if df.GroupByWithOptions([]string{"region"}, GroupByOptions{NonNumeric: "bogus"}).Error() == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestGroupByColumnNaming(t *testing.T) {
+	df := groupByTestFrame(t)
+	options := GroupByOptions{NonNumeric: "first", ColumnName: "{column}_{agg}"}
+	gb := df.GroupByWithOptions([]string{"region"}, options)
+
+	sum, err := gb.Sum()
+	if err != nil {
+		t.Fatalf("Sum error: %v", err)
+	}
+	want := []string{"region", "product_first", "units_sum", "price_sum"}
+	if got := sum.Columns(); !slices.Equal(got, want) {
+		t.Errorf("Sum columns = %v, want %v", got, want)
+	}
+
+	q, err := gb.Quantile(0.95)
+	if err != nil {
+		t.Fatalf("Quantile error: %v", err)
+	}
+	if _, ok := q.columns["price_q95"]; !ok {
+		t.Errorf("Quantile columns = %v, want price_q95", q.Columns())
+	}
+	for q, want := range map[float64]string{0.07: "price_q7", 0.29: "price_q29", 0.025: "price_q2.5", 1: "price_q100"} {
+		result, err := gb.Quantile(q)
+		if err != nil {
+			t.Fatalf("Quantile(%v) error: %v", q, err)
+		}
+		if !result.HasColumn(want) {
+			t.Errorf("Quantile(%v) columns = %v, want %s", q, result.Columns(), want)
+		}
+	}
+
+	prefixed, err := df.GroupByWithOptions([]string{"region"}, GroupByOptions{ColumnName: "{agg}_{column}"}).Mean()
+	if err != nil {
+		t.Fatalf("Mean error: %v", err)
+	}
+	if _, ok := prefixed.columns["mean_price"]; !ok {
+		t.Errorf("Mean columns = %v, want mean_price", prefixed.Columns())
+	}
+
+	if _, err := df.GroupByWithOptions([]string{"region"}, GroupByOptions{ColumnName: "region"}).Sum(); err == nil {
+		t.Error("expected error for template without {column}")
+	}
+	clash, err := NewDataFrameFromSeries(
+		mustSeries(t, "sum_v", []string{"a"}),
+		mustSeries(t, "v", []int64{1}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clash.GroupByWithOptions([]string{"sum_v"}, GroupByOptions{ColumnName: "{agg}_{column}"}).Sum(); err == nil {
+		t.Error("expected error for result name clashing with a key column")
+	}
+}
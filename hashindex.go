@@ -0,0 +1,165 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// hashIndex maps each value of a column to the rows holding it, in row
+// order. It is only valid for the exact data slice it was built from.
+type hashIndex struct {
+	data   any
+	lookup func(value any) ([]int, bool)
+}
+
+// CreateIndex returns a copy of the DataFrame with a hash index on column.
+// Equality filters on the column (Filter with "==") then look the matching
+// rows up instead of scanning, which pays off when the same frame is
+// filtered by key many times:
+//
+//	users = users.CreateIndex("id")
+//	alice := users.Filter("id", "==", 42) // no full scan
+//
+// The index follows the column through Copy, Select and RenameColumn. Row
+// selections build new columns without it, and once the column is written
+// with Set the stale index is ignored.
+func (df *DataFrame) CreateIndex(column string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return df.setOpError("CreateIndex", err)
+	}
+
+	idx := newSeriesHashIndex(df.columns[column])
+	if idx == nil {
+		return df.setError(newColumnError("CreateIndex", column, "unsupported column type"))
+	}
+
+	newDf := df.Copy()
+	newDf.hashIndexes[column] = idx
+	return newDf
+}
+
+// newSeriesHashIndex builds a hash index over the series' current data, or
+// returns nil for an unsupported type.
+func newSeriesHashIndex(series *Series) *hashIndex {
+	switch data := series.Data.(type) {
+	case []int64:
+		return newHashIndex(data, func(v int64) (int64, bool) { return v, true }, func(value any) (int64, bool) {
+			// Leave fractional and out-of-range values to Filter, which compares them as float64
+			if f, isFloat := value.(float64); isFloat && !floatIsInt64(f) {
+				return 0, false
+			}
+			return toInt64(value)
+		})
+	case []float64:
+		return newHashIndex(data, func(v float64) (float64, bool) { return v, !math.IsNaN(v) }, toFloat64)
+	case []string:
+		return newHashIndex(data, func(v string) (string, bool) { return v, true }, func(value any) (string, bool) {
+			if s, ok := value.(string); ok {
+				return s, true
+			}
+			return fmt.Sprintf("%v", value), true
+		})
+	case []bool:
+		return newHashIndex(data, func(v bool) (bool, bool) { return v, true }, func(value any) (bool, bool) {
+			b, ok := value.(bool)
+			return b, ok
+		})
+	case []time.Time:
+		return newHashIndex(data, timeIndexKey, func(value any) (time.Time, bool) {
+			t, ok := value.(time.Time)
+			if !ok {
+				return t, false
+			}
+			key, _ := timeIndexKey(t)
+			return key, true
+		})
+	}
+	return nil
+}
+
+// HasIndex reports whether column has a usable hash index.
+func (df *DataFrame) HasIndex(column string) bool {
+	return df.hashIndexFor(column) != nil
+}
+
+// newHashIndex builds the index over data. key maps a stored value to its
+// map key, reporting false for missing values that no equality matches;
+// convert maps a filter value to a key, reporting false if the value has
+// the wrong type for the column.
+func newHashIndex[T any, K comparable](data []T, key func(T) (K, bool), convert func(any) (K, bool)) *hashIndex {
+	rows := make(map[K][]int)
+	for i, v := range data {
+		if k, ok := key(v); ok {
+			rows[k] = append(rows[k], i)
+		}
+	}
+	return &hashIndex{
+		data: data,
+		lookup: func(value any) ([]int, bool) {
+			k, ok := convert(value)
+			if !ok {
+				return nil, false
+			}
+			if matches, found := rows[k]; found {
+				return matches, true
+			}
+			return []int{}, true
+		},
+	}
+}
+
+// timeIndexKey normalizes a time so that equal instants share a map key,
+// as time.Equal does. The zero time is missing.
+func timeIndexKey(t time.Time) (time.Time, bool) {
+	return t.Round(0).UTC(), !t.IsZero()
+}
+
+// hashIndexFor returns the index on column if there is one and it was
+// built from the column's current data.
+func (df *DataFrame) hashIndexFor(column string) *hashIndex {
+	idx := df.hashIndexes[column]
+	series, ok := df.columns[column]
+	if idx == nil || !ok || !sameData(idx.data, series.Data) {
+		return nil
+	}
+	return idx
+}
+
+// indexedRows looks up the rows equal to value through the column's hash
+// index. ok is false when there is no usable index or value cannot be
+// compared with the column, leaving the caller to scan.
+func (df *DataFrame) indexedRows(column string, value any) (rows []int, ok bool) {
+	idx := df.hashIndexFor(column)
+	if idx == nil {
+		return nil, false
+	}
+	return idx.lookup(value)
+}
+
+// sameData reports whether a and b are the same slice: same type, start
+// and length.
+func sameData(a, b any) bool {
+	switch x := a.(type) {
+	case []int64:
+		y, ok := b.([]int64)
+		return ok && len(x) == len(y) && (len(x) == 0 || &x[0] == &y[0])
+	case []float64:
+		y, ok := b.([]float64)
+		return ok && len(x) == len(y) && (len(x) == 0 || &x[0] == &y[0])
+	case []string:
+		y, ok := b.([]string)
+		return ok && len(x) == len(y) && (len(x) == 0 || &x[0] == &y[0])
+	case []bool:
+		y, ok := b.([]bool)
+		return ok && len(x) == len(y) && (len(x) == 0 || &x[0] == &y[0])
+	case []time.Time:
+		y, ok := b.([]time.Time)
+		return ok && len(x) == len(y) && (len(x) == 0 || &x[0] == &y[0])
+	}
+	return false
+}
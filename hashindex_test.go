@@ -0,0 +1,116 @@
+package otters
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCreateIndexMatchesScan(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	df, err := NewDataFrameFromMap(map[string]any{
+		"id":    []int64{3, 1, 3, 2, 3},
+		"score": []float64{1.5, math.NaN(), 2, 1.5, 0},
+		"name":  []string{"a", "b", "a", "c", "1"},
+		"ok":    []bool{true, false, true, true, false},
+		"at":    []time.Time{base, {}, base.In(time.FixedZone("X", 3600)), base.Add(time.Hour), base},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		column string
+		value  any
+	}{
+		{"id", int64(3)}, {"id", 3}, {"id", 3.0}, {"id", 2.5}, {"id", int64(9)},
+		{"score", 1.5}, {"score", int64(2)}, {"score", math.NaN()},
+		{"name", "a"}, {"name", 1},
+		{"ok", true},
+		{"at", base}, {"at", time.Time{}},
+	}
+	for _, tc := range cases {
+		indexed := df.CreateIndex(tc.column)
+		if !indexed.HasIndex(tc.column) {
+			t.Fatalf("%s: HasIndex = false after CreateIndex", tc.column)
+		}
+		want := df.Filter(tc.column, "==", tc.value)
+		got := indexed.Filter(tc.column, "==", tc.value)
+		if err := got.Error(); err != nil {
+			t.Fatalf("%s == %v: %v", tc.column, tc.value, err)
+		}
+		if got.Len() != want.Len() {
+			t.Fatalf("%s == %v: %d rows, want %d", tc.column, tc.value, got.Len(), want.Len())
+		}
+		for i := range want.Len() {
+			g, _ := got.Get(i, "id")
+			w, _ := want.Get(i, "id")
+			if g != w {
+				t.Errorf("%s == %v: row %d id = %v, want %v", tc.column, tc.value, i, g, w)
+			}
+		}
+	}
+
+	if err := df.CreateIndex("ok").Filter("ok", "==", "yes").Error(); err == nil {
+		t.Error("expected type error filtering a bool index with a string")
+	}
+}
+
+func TestCreateIndexLifecycle(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"id":  []int64{1, 2, 1},
+		"val": []string{"x", "y", "z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexed := df.CreateIndex("id")
+	if df.HasIndex("id") {
+		t.Error("CreateIndex modified the source frame")
+	}
+	if !indexed.Copy().HasIndex("id") || !indexed.Select("id").HasIndex("id") {
+		t.Error("index should survive Copy and Select")
+	}
+	if indexed.Select("val").HasIndex("id") || indexed.DropColumn("id").HasIndex("id") {
+		t.Error("index should not outlive its column")
+	}
+
+	renamed := indexed.RenameColumn("id", "key")
+	if renamed.HasIndex("id") || !renamed.HasIndex("key") {
+		t.Error("index should follow RenameColumn")
+	}
+
+	written := indexed.Copy()
+	if err := written.Set(0, "id", int64(2)); err != nil {
+		t.Fatal(err)
+	}
+	if written.HasIndex("id") {
+		t.Error("index should be stale after Set")
+	}
+	if n := written.Filter("id", "==", int64(2)).Len(); n != 2 {
+		t.Errorf("Filter after Set matched %d rows, want 2", n)
+	}
+	if !indexed.HasIndex("id") || indexed.Filter("id", "==", int64(1)).Len() != 2 {
+		t.Error("Set on a copy invalidated the original's index")
+	}
+
+	if err := df.CreateIndex("missing").Error(); err == nil {
+		t.Error("expected error indexing a missing column")
+	}
+}
+
+func TestCreateIndexMissKeepsRowIndexAligned(t *testing.T) {
+	df, err := NewDataFrameFromSeries(mustSeries(t, "s", []string{"a", "b", "c"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.WithRowIndex().CreateIndex("s").Filter("s", "==", "missing")
+	if err := result.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if levels := result.IndexLevels(); result.Len() != 0 || len(levels) != 1 || levels[0].Length != result.Len() {
+		t.Errorf("Len = %d with index levels %v, want an empty index", result.Len(), levels)
+	}
+}
@@ -0,0 +1,142 @@
+package otters
+
+import "strings"
+
+// InternStrings returns a copy of the DataFrame whose string columns are
+// dictionary-encoded: each distinct value is stored once, and each row
+// holds an integer code into that dictionary. Strings read from CSV or
+// JSONL each hold their own bytes, so a low-cardinality column such as
+// "country" with a million rows keeps a million copies of a few dozen
+// values; interned, every row points at one shared copy per value. Values
+// are unchanged. With no columns given, every string column is interned.
+//
+// The codes speed up work on the column: equality filters ("==", "!=" in
+// Filter, FilterAll and the lazy API) compare codes instead of strings,
+// and GroupBy on the column alone groups by code without hashing. Like
+// CreateIndex, the encoding follows the column through Copy, Select, View
+// and renames; row selections build new columns without it, and Set drops
+// it.
+//
+// Interning pays off for columns with few distinct values; a column of
+// mostly unique values only gains the cost of the dictionary.
+func (df *DataFrame) InternStrings(columns ...string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if len(columns) == 0 {
+		for _, colName := range df.order {
+			if df.columns[colName].Type == StringType {
+				columns = append(columns, colName)
+			}
+		}
+	}
+
+	if err := df.validateColumnsExist(columns); err != nil {
+		return df.setOpError("InternStrings", err)
+	}
+
+	newDf := df.Copy()
+	for _, column := range columns {
+		data, ok := df.columns[column].Data.([]string)
+		if !ok {
+			return df.setError(newColumnError("InternStrings", column, "column must be string"))
+		}
+		dict := newStringDict(data)
+		series, err := newSeriesOwned(column, dict.data)
+		if err != nil {
+			return df.setError(wrapColumnError("InternStrings", column, err))
+		}
+		series.dict = dict
+		newDf.columns[column] = series
+	}
+	return newDf
+}
+
+// stringDict is the dictionary encoding of a string column: the distinct
+// values in order of first appearance, and each row's value as an index
+// into them. It is only valid for the exact data slice it was built from.
+type stringDict struct {
+	data   []string // The column's values, sharing the dictionary's bytes
+	codes  []int32
+	values []string
+	lookup map[string]int32
+}
+
+// newStringDict encodes data. Equal values in the encoded column share the
+// backing bytes of their dictionary entry.
+func newStringDict(data []string) *stringDict {
+	d := &stringDict{
+		data:   make([]string, len(data)),
+		codes:  make([]int32, len(data)),
+		lookup: make(map[string]int32),
+	}
+	for i, v := range data {
+		code, ok := d.lookup[v]
+		if !ok {
+			code = int32(len(d.values))
+			// Clone so the value stops pinning whatever buffer it was cut from
+			d.values = append(d.values, strings.Clone(v))
+			d.lookup[v] = code
+		}
+		d.codes[i] = code
+		d.data[i] = d.values[code]
+	}
+	return d
+}
+
+// view returns the encoding of rows start to end (exclusive) of data,
+// which must be the matching re-slice of d.data.
+func (d *stringDict) view(data []string, start, end int) *stringDict {
+	return &stringDict{data: data, codes: d.codes[start:end:end], values: d.values, lookup: d.lookup}
+}
+
+// dictionary returns the series' dictionary encoding if it has one built
+// from its current data.
+func (s *Series) dictionary() *stringDict {
+	if s.dict == nil || !sameData(s.dict.data, s.Data) {
+		return nil
+	}
+	return s.dict
+}
+
+// dictPredicate builds a row predicate comparing codes for "==" and "!="
+// on a dictionary-encoded series. ok is false for other operators and
+// series without an encoding.
+func dictPredicate(series *Series, operator, cmp string) (pred func(row int) bool, ok bool) {
+	d := series.dictionary()
+	if d == nil {
+		return nil, false
+	}
+	code, found := d.lookup[cmp]
+	switch operator {
+	case "==", "=":
+		if !found {
+			return func(int) bool { return false }, true
+		}
+		return func(row int) bool { return d.codes[row] == code }, true
+	case "!=", "<>":
+		if !found {
+			return func(int) bool { return true }, true
+		}
+		return func(row int) bool { return d.codes[row] != code }, true
+	}
+	return nil, false
+}
+
+// groups groups rows 0 to length by code, returning the groups in order of
+// first appearance as buildGroupsHashed does.
+func (d *stringDict) groups() []*groupKey {
+	byCode := make([]*groupKey, len(d.values))
+	var groups []*groupKey
+	for i, code := range d.codes {
+		g := byCode[code]
+		if g == nil {
+			g = &groupKey{values: []string{d.values[code]}}
+			byCode[code] = g
+			groups = append(groups, g)
+		}
+		g.indices = append(g.indices, i)
+	}
+	return groups
+}
@@ -0,0 +1,106 @@
+package otters
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestInternStrings(t *testing.T) {
+	line := "us,de,us,fr,us"
+	country := strings.Split(line, ",")
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "country", country),
+		mustSeries(t, "id", []int64{1, 2, 3, 4, 5}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interned := df.InternStrings()
+	if err := interned.Error(); err != nil {
+		t.Fatal(err)
+	}
+	series, _ := interned.GetSeries("country")
+	data := series.Data.([]string)
+	if !slices.Equal(data, country) {
+		t.Fatalf("values changed: %v", data)
+	}
+
+	got := interned.columns["country"].Data.([]string)
+	if unsafe.StringData(got[0]) != unsafe.StringData(got[2]) || unsafe.StringData(got[0]) != unsafe.StringData(got[4]) {
+		t.Error("equal values should share backing bytes")
+	}
+	if unsafe.StringData(got[0]) == unsafe.StringData(line) {
+		t.Error("interned values should not point into the source buffer")
+	}
+
+	if n := interned.Filter("country", "==", "us").Len(); n != 3 {
+		t.Errorf("Filter after interning matched %d rows, want 3", n)
+	}
+
+	if err := df.InternStrings("id").Error(); err == nil {
+		t.Error("expected error interning an int64 column")
+	}
+	if err := df.InternStrings("missing").Error(); err == nil {
+		t.Error("expected error interning a missing column")
+	}
+}
+
+func TestInternStringsDictionary(t *testing.T) {
+	country := make([]string, 200)
+	for i := range country {
+		country[i] = []string{"us", "de", "fr", "jp"}[i*7%4]
+	}
+	df, err := NewDataFrameFromSeries(mustSeries(t, "country", country))
+	if err != nil {
+		t.Fatal(err)
+	}
+	interned := df.InternStrings()
+	if interned.columns["country"].dictionary() == nil {
+		t.Fatal("InternStrings should dictionary-encode the column")
+	}
+
+	// Filters on codes match filters on strings
+	for _, c := range []Condition{C("country", "==", "de"), C("country", "!=", "de"), C("country", "==", "uk"), C("country", "!=", "uk")} {
+		want := df.Filter(c.Column, c.Operator, c.Value).Len()
+		if got := interned.Filter(c.Column, c.Operator, c.Value).Len(); got != want {
+			t.Errorf("Filter %s %v: %d rows, want %d", c.Operator, c.Value, got, want)
+		}
+		if got := interned.FilterAll(c).Len(); got != want {
+			t.Errorf("FilterAll %s %v: %d rows, want %d", c.Operator, c.Value, got, want)
+		}
+	}
+
+	// Grouping by code gives the groups of the hashed path, in the same order
+	want, _ := buildGroupsHashed([]*Series{df.columns["country"]}, 0, df.length)
+	got := interned.GroupBy("country").buildGroups()
+	if len(got) != len(want) {
+		t.Fatalf("%d groups, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !slices.Equal(got[i].values, want[i].values) || !slices.Equal(got[i].indices, want[i].indices) {
+			t.Errorf("group %d = %v, want %v", i, got[i].values, want[i].values)
+		}
+	}
+
+	// The encoding follows shared columns and views, and Set drops it
+	if interned.Select("country").columns["country"].dictionary() == nil {
+		t.Error("Select should keep the encoding")
+	}
+	view := interned.View(10, 20)
+	if view.columns["country"].dictionary() == nil || view.Filter("country", "==", country[10]).Len() != df.View(10, 20).Filter("country", "==", country[10]).Len() {
+		t.Error("View should keep a matching encoding")
+	}
+	changed := interned.Copy()
+	if err := changed.Set(0, "country", "uk"); err != nil {
+		t.Fatal(err)
+	}
+	if changed.columns["country"].dictionary() != nil || changed.Filter("country", "==", "uk").Len() != 1 {
+		t.Error("Set should drop the encoding")
+	}
+	if interned.columns["country"].dictionary() == nil {
+		t.Error("Set on a copy should leave the source's encoding")
+	}
+}
@@ -0,0 +1,173 @@
+package otters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ToJSON encodes the DataFrame as a JSON document, ready to write to an HTTP
+// response. orient sets the layout:
+//
+//   - "records": an array of one object per row, keys in column order:
+//     [{"name":"Alice","age":25},...]
+//   - "columns": an object of one array per column, in column order:
+//     {"name":["Alice",...],"age":[25,...]}
+//   - "split": the column names and the rows as arrays:
+//     {"columns":["name","age"],"data":[["Alice",25],...]}
+//
+// Values are written as by WriteJSONL: times as RFC3339 strings, and zero
+// times, NaN and ±Inf as null. The row index is not included; use
+// ResetIndex first to keep it.
+func (df *DataFrame) ToJSON(orient string) ([]byte, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	names := make([][]byte, len(df.order))
+	for j, colName := range df.order {
+		name, err := json.Marshal(colName)
+		if err != nil {
+			return nil, wrapColumnError("ToJSON", colName, err)
+		}
+		names[j] = name
+	}
+
+	var buf bytes.Buffer
+	switch orient {
+	case "records":
+		buf.WriteByte('[')
+		for i := 0; i < df.length; i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('{')
+			for j, colName := range df.order {
+				if j > 0 {
+					buf.WriteByte(',')
+				}
+				buf.Write(names[j])
+				buf.WriteByte(':')
+				if err := df.writeJSONValue(&buf, colName, i); err != nil {
+					return nil, err
+				}
+			}
+			buf.WriteByte('}')
+		}
+		buf.WriteByte(']')
+
+	case "columns":
+		buf.WriteByte('{')
+		for j, colName := range df.order {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(names[j])
+			buf.WriteString(":[")
+			for i := 0; i < df.length; i++ {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				if err := df.writeJSONValue(&buf, colName, i); err != nil {
+					return nil, err
+				}
+			}
+			buf.WriteByte(']')
+		}
+		buf.WriteByte('}')
+
+	case "split":
+		buf.WriteString(`{"columns":[`)
+		buf.Write(bytes.Join(names, []byte{','}))
+		buf.WriteString(`],"data":[`)
+		for i := 0; i < df.length; i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('[')
+			for j, colName := range df.order {
+				if j > 0 {
+					buf.WriteByte(',')
+				}
+				if err := df.writeJSONValue(&buf, colName, i); err != nil {
+					return nil, err
+				}
+			}
+			buf.WriteByte(']')
+		}
+		buf.WriteString("]}")
+
+	default:
+		return nil, newOpError("ToJSON", fmt.Sprintf("unsupported orientation: %s (want records, columns or split)", orient))
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the DataFrame in the
+// "records" orientation of ToJSON, so frames can be embedded in API
+// responses:
+//
+//	json.NewEncoder(w).Encode(struct {
+//		Total int               `json:"total"`
+//		Rows  *otters.DataFrame `json:"rows"`
+//	}{df.Len(), df})
+//
+// A DataFrame carrying an error fails to marshal with that error.
+func (df *DataFrame) MarshalJSON() ([]byte, error) {
+	return df.ToJSON("records")
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the DataFrame with
+// the rows of a "records" array: one object per row, columns in order of
+// first appearance and typed as by ReadJSONL, except that a numeric column
+// holding null is float64 with NaN there, so the output of MarshalJSON
+// reads back with its types and missing values. JSON null leaves the
+// DataFrame unchanged.
+func (df *DataFrame) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	var records []json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return wrapError("UnmarshalJSON", fmt.Errorf("expected an array of row objects: %w", err))
+	}
+
+	rows := make([]map[string]any, len(records))
+	var order []string
+	seen := make(map[string]bool)
+	for i, record := range records {
+		obj, keys, err := decodeJSONLine(string(record))
+		if err != nil {
+			return &OtterError{Op: "UnmarshalJSON", Row: i, Message: fmt.Sprintf("invalid row: %v", err), Cause: err}
+		}
+		for _, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				order = append(order, key)
+			}
+		}
+		rows[i] = obj
+	}
+
+	parsed, err := buildDataFrameFromJSONLRows(order, rows, true, "UnmarshalJSON")
+	if err != nil {
+		return err
+	}
+	*df = *parsed
+	return nil
+}
+
+// writeJSONValue appends the JSON encoding of one cell to buf.
+func (df *DataFrame) writeJSONValue(buf *bytes.Buffer, column string, row int) error {
+	value, err := df.columns[column].Get(row)
+	if err != nil {
+		return wrapColumnError("ToJSON", column, err)
+	}
+	formatted, err := formatValueForJSONL(value)
+	if err != nil {
+		return wrapColumnError("ToJSON", column, err)
+	}
+	buf.WriteString(formatted)
+	return nil
+}
@@ -0,0 +1,138 @@
+package otters
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestToJSON(t *testing.T) {
+	day := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", `Bob "B"`}),
+		mustSeries(t, "age", []int64{25, 30}),
+		mustSeries(t, "score", []float64{91.5, math.NaN()}),
+		mustSeries(t, "joined", []time.Time{day, {}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"records": `[{"name":"Alice","age":25,"score":91.5,"joined":"2024-05-01T09:30:00Z"},` +
+			`{"name":"Bob \"B\"","age":30,"score":null,"joined":null}]`,
+		"columns": `{"name":["Alice","Bob \"B\""],"age":[25,30],"score":[91.5,null],` +
+			`"joined":["2024-05-01T09:30:00Z",null]}`,
+		"split": `{"columns":["name","age","score","joined"],` +
+			`"data":[["Alice",25,91.5,"2024-05-01T09:30:00Z"],["Bob \"B\"",30,null,null]]}`,
+	}
+	for orient, want := range cases {
+		got, err := df.ToJSON(orient)
+		if err != nil {
+			t.Fatalf("%s: %v", orient, err)
+		}
+		if string(got) != want {
+			t.Errorf("%s:\n got %s\nwant %s", orient, got, want)
+		}
+		if !json.Valid(got) {
+			t.Errorf("%s: invalid JSON", orient)
+		}
+	}
+
+	empty, _ := NewDataFrameFromSeries(mustSeries(t, "x", []int64{}))
+	if got, _ := empty.ToJSON("records"); string(got) != "[]" {
+		t.Errorf("empty records = %s, want []", got)
+	}
+	if _, err := df.ToJSON("index"); err == nil {
+		t.Error("unsupported orientation: expected an error")
+	}
+	if _, err := df.Select("missing").ToJSON("records"); err == nil {
+		t.Error("error frame: expected an error")
+	}
+}
+
+func TestDataFrameJSONMarshaling(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", "Bob"}),
+		mustSeries(t, "age", []int64{25, 30}),
+		mustSeries(t, "score", []float64{91.5, 80}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type response struct {
+		Total int        `json:"total"`
+		Rows  *DataFrame `json:"rows"`
+	}
+	body, err := json.Marshal(response{Total: df.Len(), Rows: df})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"total":2,"rows":[{"name":"Alice","age":25,"score":91.5},{"name":"Bob","age":30,"score":80.0}]}`
+	if string(body) != want {
+		t.Errorf("Marshal =\n%s\nwant\n%s", body, want)
+	}
+
+	var decoded response
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.Rows.CheckEqual(df, EqualOptions{}); err != nil {
+		t.Errorf("round trip: %v", err)
+	}
+
+	var empty DataFrame
+	if err := json.Unmarshal([]byte(`[]`), &empty); err != nil || empty.Len() != 0 {
+		t.Errorf("empty array: %v rows, %v", empty.Len(), err)
+	}
+	for _, bad := range []string{`{"name":"Alice"}`, `[{"name":"Alice"},3]`} {
+		var target DataFrame
+		if err := json.Unmarshal([]byte(bad), &target); err == nil {
+			t.Errorf("Unmarshal(%s): expected an error", bad)
+		}
+	}
+
+	if _, err := json.Marshal(df.Select("missing")); err == nil {
+		t.Error("Marshal of an error frame: expected an error")
+	}
+}
+
+func TestDataFrameJSONRoundTripKeepsTypes(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{1, 2, 3}),
+		mustSeries(t, "amount", []float64{1, 2, math.NaN()}),
+		mustSeries(t, "rate", []float64{math.NaN(), 0, -4}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := json.Marshal(df)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"id":1,"amount":1.0,"rate":null},{"id":2,"amount":2.0,"rate":0.0},{"id":3,"amount":null,"rate":-4.0}]`
+	if string(body) != want {
+		t.Errorf("Marshal =\n%s\nwant\n%s", body, want)
+	}
+
+	var decoded DataFrame
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.CheckEqual(df, EqualOptions{}); err != nil {
+		t.Errorf("round trip: %v", err)
+	}
+
+	// An integer column with a null also reads back as float64 with NaN
+	var sparse DataFrame
+	if err := json.Unmarshal([]byte(`[{"n":1},{"n":null},{}]`), &sparse); err != nil {
+		t.Fatal(err)
+	}
+	series, _ := sparse.GetSeries("n")
+	if got := series.Data.([]float64); got[0] != 1 || !math.IsNaN(got[1]) || !math.IsNaN(got[2]) {
+		t.Errorf("n = %v, want [1 NaN NaN]", got)
+	}
+}
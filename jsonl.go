@@ -8,6 +8,7 @@ import (
 	"io"
 	"math"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -105,7 +106,7 @@ func readJSONL(r io.Reader, options JSONLOptions, operation string) (*DataFrame,
 		return nil, wrapError(operation, err)
 	}
 
-	return buildDataFrameFromJSONLRows(order, rows, operation)
+	return buildDataFrameFromJSONLRows(order, rows, false, operation)
 }
 
 // decodeJSONLine decodes one JSONL line into a value map plus the object's
@@ -151,8 +152,10 @@ func decodeJSONLine(line string) (map[string]any, []string, error) {
 	return obj, keys, nil
 }
 
-// buildDataFrameFromJSONLRows constructs a DataFrame from decoded JSONL rows
-func buildDataFrameFromJSONLRows(order []string, rows []map[string]any, operation string) (*DataFrame, error) {
+// buildDataFrameFromJSONLRows constructs a DataFrame from decoded JSONL rows.
+// With missingAsNaN, a numeric column holding null or a missing key is read
+// as float64 with NaN there instead of the type's zero value.
+func buildDataFrameFromJSONLRows(order []string, rows []map[string]any, missingAsNaN bool, operation string) (*DataFrame, error) {
 	if len(order) == 0 {
 		return NewDataFrame(), nil
 	}
@@ -165,10 +168,22 @@ func buildDataFrameFromJSONLRows(order []string, rows []map[string]any, operatio
 		}
 
 		colType := inferJSONLColumnType(values)
+		missing := missingAsNaN && isNumericType(colType) && slices.Contains(values, nil)
+		if missing {
+			colType = Float64Type
+		}
 		s, err := buildJSONLSeries(name, values, colType)
 		if err != nil {
 			return nil, wrapColumnError(operation, name, err)
 		}
+		if missing {
+			data := s.Data.([]float64)
+			for i, v := range values {
+				if v == nil {
+					data[i] = math.NaN()
+				}
+			}
+		}
 		series = append(series, s)
 	}
 
@@ -409,6 +424,11 @@ func formatValueForJSONL(value any) (string, error) {
 		if err != nil {
 			return "", err
 		}
+		if !bytes.ContainsAny(raw, ".eE") {
+			// Keep whole values recognizable as floats, so they read back
+			// as float64 rather than int64
+			raw = append(raw, ".0"...)
+		}
 		return string(raw), nil
 	case bool:
 		return strconv.FormatBool(v), nil
@@ -1,24 +1,44 @@
 package otters
 
 import (
-	"math"
+	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 )
 
-// LazyFrame is a deferred view over a DataFrame. Row-selection operations
-// (Filter, Sort, Head, Tail) and column selection (Select) only manipulate
-// an index/column view; the data itself is copied once, at Collect. For a
-// chain like Filter → Select → Sort this avoids materializing a full
-// intermediate DataFrame per step.
+// LazyFrame is a deferred query over a DataFrame. Filter, Select, Sort,
+// Head and Tail only record a logical plan; nothing is evaluated until
+// Collect (or GroupBy), which first optimizes the plan:
 //
-// The view references the source DataFrame's data, so the source must not
+//   - predicate pushdown: filters run before sorts, so fewer rows are sorted
+//   - filter fusion: consecutive filters are evaluated in one pass
+//   - sort fusion: consecutive sorts become a single multi-key sort
+//   - projection pruning: only the finally selected columns are copied, once
+//
+// Column references are still checked as the plan is built, so Error
+// reports a bad column or value at the call that introduced it. Explain
+// shows the optimized plan.
+//
+// The plan references the source DataFrame's data, so the source must not
 // be mutated between Lazy() and Collect().
 type LazyFrame struct {
-	src     *DataFrame
-	indices []int    // selected rows in view order; nil = all rows
-	cols    []string // selected columns in view order; nil = all columns
-	err     error
+	src   *DataFrame
+	steps []lazyStep // row operations in call order
+	cols  []string   // selected columns in view order; nil = all columns
+	err   error
+}
+
+// lazyStep is one row operation of a LazyFrame plan. Fused filters carry
+// several predicates, fused sorts several keys.
+type lazyStep struct {
+	kind      string // "filter", "sort", "head" or "tail"
+	preds     []func(row int) bool
+	exprs     []string // filter conditions, for Explain
+	columns   []string // sort keys
+	ascending []bool
+	n         int // head/tail row count
 }
 
 // Lazy returns a lazy view over the DataFrame.
@@ -26,7 +46,7 @@ func (df *DataFrame) Lazy() *LazyFrame {
 	return &LazyFrame{src: df, err: df.err}
 }
 
-// Error returns the first error encountered while building the view.
+// Error returns the first error encountered while building the plan.
 func (lf *LazyFrame) Error() error {
 	return lf.err
 }
@@ -36,6 +56,11 @@ func (lf *LazyFrame) fail(err error) *LazyFrame {
 	return &LazyFrame{src: lf.src, err: err}
 }
 
+// with returns a new LazyFrame with step appended to the plan.
+func (lf *LazyFrame) with(step lazyStep) *LazyFrame {
+	return &LazyFrame{src: lf.src, steps: slices.Concat(lf.steps, []lazyStep{step}), cols: lf.cols}
+}
+
 // columnSeries resolves a column that must exist in the source and still be
 // part of the current column selection.
 func (lf *LazyFrame) columnSeries(op, column string) (*Series, error) {
@@ -48,22 +73,6 @@ func (lf *LazyFrame) columnSeries(op, column string) (*Series, error) {
 	return lf.src.columns[column], nil
 }
 
-// rowCount returns the number of rows in the current view.
-func (lf *LazyFrame) rowCount() int {
-	if lf.indices == nil {
-		return lf.src.length
-	}
-	return len(lf.indices)
-}
-
-// rowAt maps a view position to a source row index.
-func (lf *LazyFrame) rowAt(pos int) int {
-	if lf.indices == nil {
-		return pos
-	}
-	return lf.indices[pos]
-}
-
 // Filter narrows the view to rows matching the condition.
 func (lf *LazyFrame) Filter(column, operator string, value any) *LazyFrame {
 	if lf.err != nil {
@@ -80,16 +89,11 @@ func (lf *LazyFrame) Filter(column, operator string, value any) *LazyFrame {
 		return lf.fail(wrapColumnError("Lazy.Filter", column, err))
 	}
 
-	n := lf.rowCount()
-	matched := make([]int, 0, n/4)
-	for pos := 0; pos < n; pos++ {
-		row := lf.rowAt(pos)
-		if pred(row) {
-			matched = append(matched, row)
-		}
-	}
-
-	return &LazyFrame{src: lf.src, indices: matched, cols: lf.cols}
+	return lf.with(lazyStep{
+		kind:  "filter",
+		preds: []func(row int) bool{pred},
+		exprs: []string{fmt.Sprintf("%s %s %v", column, operator, value)},
+	})
 }
 
 // Where is an alias for Filter (Pandas compatibility).
@@ -118,10 +122,7 @@ func (lf *LazyFrame) Select(columns ...string) *LazyFrame {
 		seen[column] = true
 	}
 
-	selected := make([]string, len(columns))
-	copy(selected, columns)
-
-	return &LazyFrame{src: lf.src, indices: lf.indices, cols: selected}
+	return &LazyFrame{src: lf.src, steps: lf.steps, cols: slices.Clone(columns)}
 }
 
 // Sort orders the view by a single column.
@@ -144,80 +145,17 @@ func (lf *LazyFrame) SortBy(columns []string, ascending []bool) *LazyFrame {
 		return lf.fail(newOpError("Lazy.SortBy", "columns and ascending arrays must have the same length"))
 	}
 
-	comparators := make([]func(a, b int) int, len(columns))
-	for k, column := range columns {
+	for _, column := range columns {
 		series, err := lf.columnSeries("Lazy.SortBy", column)
 		if err != nil {
 			return lf.fail(err)
 		}
-		cmp := typedComparator(series)
-		if cmp == nil {
+		if typedComparator(series) == nil {
 			return lf.fail(newColumnError("Lazy.SortBy", column, "unsupported column type for sorting"))
 		}
-		comparators[k] = cmp
-	}
-
-	n := lf.rowCount()
-	cur := make([]int, n)
-	for pos := 0; pos < n; pos++ {
-		cur[pos] = lf.rowAt(pos)
-	}
-
-	// If the view order still matches ascending source order (true unless a
-	// previous Sort reordered it), ties can break on the row index itself:
-	// that is a strict total order, so the result equals a stable sort while
-	// sorting the indices directly.
-	monotonic := true
-	for i := 1; i < n; i++ {
-		if cur[i] <= cur[i-1] {
-			monotonic = false
-			break
-		}
-	}
-
-	if monotonic {
-		sort.Slice(cur, func(i, j int) bool {
-			a, b := cur[i], cur[j]
-			for k, compare := range comparators {
-				cmp := compare(a, b)
-				if cmp != 0 {
-					if ascending[k] {
-						return cmp < 0
-					}
-					return cmp > 0
-				}
-			}
-			return a < b
-		})
-		return &LazyFrame{src: lf.src, indices: cur, cols: lf.cols}
-	}
-
-	// Otherwise sort a permutation of view positions; breaking ties on the
-	// position keeps the current view order for equal keys (stable).
-	perm := make([]int, n)
-	for i := range perm {
-		perm[i] = i
-	}
-	sort.Slice(perm, func(i, j int) bool {
-		a, b := cur[perm[i]], cur[perm[j]]
-		for k, compare := range comparators {
-			cmp := compare(a, b)
-			if cmp != 0 {
-				if ascending[k] {
-					return cmp < 0
-				}
-				return cmp > 0
-			}
-		}
-		return perm[i] < perm[j]
-	})
-
-	sorted := make([]int, n)
-	for k, p := range perm {
-		sorted[k] = cur[p]
 	}
 
-	return &LazyFrame{src: lf.src, indices: sorted, cols: lf.cols}
+	return lf.with(lazyStep{kind: "sort", columns: slices.Clone(columns), ascending: slices.Clone(ascending)})
 }
 
 // Head narrows the view to its first n rows.
@@ -228,18 +166,7 @@ func (lf *LazyFrame) Head(n int) *LazyFrame {
 	if n <= 0 {
 		return lf.fail(newOpError("Lazy.Head", "n must be positive"))
 	}
-
-	total := lf.rowCount()
-	if n > total {
-		n = total
-	}
-
-	head := make([]int, n)
-	for pos := 0; pos < n; pos++ {
-		head[pos] = lf.rowAt(pos)
-	}
-
-	return &LazyFrame{src: lf.src, indices: head, cols: lf.cols}
+	return lf.with(lazyStep{kind: "head", n: n})
 }
 
 // Tail narrows the view to its last n rows.
@@ -250,27 +177,206 @@ func (lf *LazyFrame) Tail(n int) *LazyFrame {
 	if n <= 0 {
 		return lf.fail(newOpError("Lazy.Tail", "n must be positive"))
 	}
+	return lf.with(lazyStep{kind: "tail", n: n})
+}
 
-	total := lf.rowCount()
-	if n > total {
-		n = total
+// optimizePlan rewrites the row operations into an equivalent, cheaper
+// plan. Filters commute with (stable) sorts, so within each stretch between
+// Head/Tail steps the filters move ahead of the sorts. Adjacent filters
+// then fuse into one pass, and adjacent sorts into one sort whose keys are
+// the later sort's followed by the earlier one's, which is what a stable
+// sort of a sorted view yields.
+func optimizePlan(steps []lazyStep) []lazyStep {
+	var pushed []lazyStep
+	for start := 0; start < len(steps); {
+		end := start
+		for end < len(steps) && steps[end].kind != "head" && steps[end].kind != "tail" {
+			end++
+		}
+		segment := steps[start:end]
+		for _, step := range segment {
+			if step.kind == "filter" {
+				pushed = append(pushed, step)
+			}
+		}
+		for _, step := range segment {
+			if step.kind == "sort" {
+				pushed = append(pushed, step)
+			}
+		}
+		if end < len(steps) {
+			pushed = append(pushed, steps[end])
+		}
+		start = end + 1
+	}
+
+	var plan []lazyStep
+	for _, step := range pushed {
+		if n := len(plan); n > 0 && plan[n-1].kind == step.kind {
+			last := &plan[n-1]
+			switch step.kind {
+			case "filter":
+				last.preds = slices.Concat(last.preds, step.preds)
+				last.exprs = slices.Concat(last.exprs, step.exprs)
+				continue
+			case "sort":
+				last.columns = slices.Concat(step.columns, last.columns)
+				last.ascending = slices.Concat(step.ascending, last.ascending)
+				continue
+			}
+		}
+		plan = append(plan, step)
 	}
+	return plan
+}
 
-	tail := make([]int, n)
-	for pos := 0; pos < n; pos++ {
-		tail[pos] = lf.rowAt(total - n + pos)
+// Explain describes the optimized plan, one operation per line in the
+// order they run.
+func (lf *LazyFrame) Explain() string {
+	if lf.err != nil {
+		return "error: " + lf.err.Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "scan %d rows\n", lf.src.length)
+	for _, step := range optimizePlan(lf.steps) {
+		switch step.kind {
+		case "filter":
+			fmt.Fprintf(&b, "filter %s\n", strings.Join(step.exprs, " and "))
+		case "sort":
+			keys := make([]string, len(step.columns))
+			for k, column := range step.columns {
+				keys[k] = column + " asc"
+				if !step.ascending[k] {
+					keys[k] = column + " desc"
+				}
+			}
+			fmt.Fprintf(&b, "sort %s\n", strings.Join(keys, ", "))
+		default:
+			fmt.Fprintf(&b, "%s %d\n", step.kind, step.n)
+		}
+	}
+	cols := lf.cols
+	if cols == nil {
+		cols = lf.src.order
 	}
+	fmt.Fprintf(&b, "project %s\n", strings.Join(cols, ", "))
+	return b.String()
+}
 
-	return &LazyFrame{src: lf.src, indices: tail, cols: lf.cols}
+// execute runs the optimized plan and returns the selected source rows in
+// view order. all is true, and indices nil, when no step selected rows, so
+// the result is every row in source order.
+func (lf *LazyFrame) execute() (indices []int, all bool) {
+	all = true
+	for _, step := range optimizePlan(lf.steps) {
+		switch step.kind {
+		case "filter":
+			match := func(row int) bool {
+				for _, pred := range step.preds {
+					if !pred(row) {
+						return false
+					}
+				}
+				return true
+			}
+			if all {
+				indices = matchingRows(lf.src.length, match)
+			} else {
+				indices = slices.DeleteFunc(indices, func(row int) bool { return !match(row) })
+			}
+		case "sort":
+			if all {
+				indices = rangeIndices(0, lf.src.length)
+			}
+			indices = lf.sortRows(indices, step.columns, step.ascending)
+		case "head", "tail":
+			if all {
+				indices = rangeIndices(0, lf.src.length)
+			}
+			n := min(step.n, len(indices))
+			if step.kind == "head" {
+				indices = indices[:n]
+			} else {
+				indices = indices[len(indices)-n:]
+			}
+		}
+		all = false
+	}
+	return indices, all
 }
 
-// Collect materializes the view into a new, independent DataFrame. This is
-// the only point in a lazy chain where column data is copied.
+// sortRows orders the rows in cur by the given keys. Rows with equal keys
+// keep their order in cur (stable).
+func (lf *LazyFrame) sortRows(cur []int, columns []string, ascending []bool) []int {
+	comparators := make([]func(a, b int) int, len(columns))
+	for k, column := range columns {
+		comparators[k] = sortComparator(lf.src.columns[column], ascending[k], SortOptions{})
+	}
+	compareKeys := func(a, b int) int {
+		for _, compare := range comparators {
+			if cmp := compare(a, b); cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	}
+
+	// If the view order still matches ascending source order (true unless a
+	// previous Head/Tail followed a sort), ties can break on the row index
+	// itself: that is a strict total order, so the result equals a stable
+	// sort while sorting the indices directly.
+	monotonic := true
+	for i := 1; i < len(cur); i++ {
+		if cur[i] <= cur[i-1] {
+			monotonic = false
+			break
+		}
+	}
+
+	if monotonic {
+		less := func(a, b int) bool {
+			if cmp := compareKeys(a, b); cmp != 0 {
+				return cmp < 0
+			}
+			return a < b
+		}
+		if workers := parallelWorkers(len(cur)); workers > 1 {
+			parallelSortRows(cur, less, workers)
+		} else {
+			sortIndices(cur, less)
+		}
+		return cur
+	}
+
+	// Otherwise sort a permutation of view positions; breaking ties on the
+	// position keeps the current view order for equal keys (stable).
+	perm := rangeIndices(0, len(cur))
+	sort.Slice(perm, func(i, j int) bool {
+		if cmp := compareKeys(cur[perm[i]], cur[perm[j]]); cmp != 0 {
+			return cmp < 0
+		}
+		return perm[i] < perm[j]
+	})
+
+	sorted := make([]int, len(cur))
+	for k, p := range perm {
+		sorted[k] = cur[p]
+	}
+	return sorted
+}
+
+// Collect runs the optimized plan and materializes the result into a new,
+// independent DataFrame. This is the only point in a lazy chain where
+// column data is copied, and only the selected columns are; without row
+// operations the columns are shared copy-on-write instead.
 func (lf *LazyFrame) Collect() (*DataFrame, error) {
 	if lf.err != nil {
 		return nil, lf.err
 	}
 
+	indices, all := lf.execute()
+
 	order := lf.cols
 	if order == nil {
 		order = lf.src.order
@@ -283,12 +389,12 @@ func (lf *LazyFrame) Collect() (*DataFrame, error) {
 		var newSeries *Series
 		var err error
 		switch {
-		case lf.indices == nil:
-			newSeries = series.Copy()
-		case len(lf.indices) == 0:
+		case all:
+			newSeries = series.share()
+		case len(indices) == 0:
 			newSeries, err = newSeriesOwned(series.Name, emptySliceForType(series.Type))
 		default:
-			newData := selectSeriesRows(series, lf.indices)
+			newData := selectSeriesRows(series, indices)
 			if newData == nil {
 				return nil, newColumnError("Lazy.Collect", colName, "unsupported column type")
 			}
@@ -303,24 +409,45 @@ func (lf *LazyFrame) Collect() (*DataFrame, error) {
 		}
 	}
 
-	if lf.indices == nil {
+	if all {
 		newDf.length = lf.src.length
+		newDf.index = lf.src.copyIndex()
 	} else {
-		newDf.length = len(lf.indices)
+		newDf.length = len(indices)
+		newDf.index = lf.src.indexRows(indices)
 	}
+	newDf.warnings = lf.src.warnings
 
 	return newDf, nil
 }
 
+// GroupBy runs the plan and groups the result, ending the lazy chain:
+//
+//	totals, err := df.Lazy().Filter("year", "==", 2024).Select("region", "sales").GroupBy("region").Sum()
+func (lf *LazyFrame) GroupBy(columns ...string) *GroupBy {
+	df, err := lf.Collect()
+	if err != nil {
+		return &GroupBy{df: lf.src, err: err}
+	}
+	return df.GroupBy(columns...)
+}
+
 // typedPredicate builds a row predicate for the condition, bound to the
 // series' typed data so evaluation involves no boxing.
 func typedPredicate(series *Series, operator string, value any) (func(row int) bool, error) {
+	if err := strictFilterValue(series, operator, value); err != nil {
+		return nil, err
+	}
+	if pred, ok, err := specialPredicate(series, operator, value); ok {
+		return pred, err
+	}
+
 	switch series.Type {
 	case Int64Type:
 		data := series.Data.([]int64)
-		// Fractional values cannot be truncated to int64 without changing
+		// Fractional or out-of-range values cannot be converted to int64 without changing
 		// the predicate; compare in float64 space (same rule as Filter).
-		if f, isFloat := value.(float64); isFloat && f != math.Trunc(f) {
+		if f, isFloat := value.(float64); isFloat && !floatIsInt64(f) {
 			return func(row int) bool { return matchFloat64(float64(data[row]), operator, f) }, nil
 		}
 		cmp, ok := toInt64(value)
@@ -343,6 +470,9 @@ func typedPredicate(series *Series, operator string, value any) (func(row int) b
 		if !ok {
 			return nil, newOpError("Filter", "cannot convert value to string")
 		}
+		if pred, ok := dictPredicate(series, operator, cmp); ok {
+			return pred, nil
+		}
 		return func(row int) bool { return matchString(data[row], operator, cmp) }, nil
 
 	case BoolType:
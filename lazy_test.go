@@ -2,6 +2,7 @@ package otters
 
 import (
 	"errors"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -166,6 +167,27 @@ func TestLazyEmptyResultKeepsSchema(t *testing.T) {
 	}
 }
 
+func TestLazyParallelFilterMatchingNothing(t *testing.T) {
+	values := make([]int64, 1000)
+	for i := range values {
+		values[i] = int64(i)
+	}
+	df, err := NewDataFrameFromSeries(mustSeries(t, "a", values))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setParallelRows(t, 100)
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	result, err := df.Lazy().Filter("a", ">", int64(10000)).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Len() != 0 {
+		t.Errorf("Len = %d, want 0", result.Len())
+	}
+}
+
 // TestLazyErrorPropagation verifies error handling through a lazy chain.
 func TestLazyErrorPropagation(t *testing.T) {
 	df := lazyTestFrame(t)
@@ -268,6 +290,64 @@ func TestLazyCollectIsIndependent(t *testing.T) {
 	}
 }
 
+// TestLazyPlanOptimization verifies that the optimized plan pushes filters
+// ahead of sorts, fuses adjacent steps, and still matches the eager chain.
+func TestLazyPlanOptimization(t *testing.T) {
+	df := lazyTestFrame(t)
+
+	lf := df.Lazy().
+		Sort("score", false).
+		Filter("dept", "==", "Eng").
+		Sort("name", true).
+		Filter("salary", ">", 70000).
+		Head(5).
+		Filter("score", ">", 4.0).
+		Select("name", "salary")
+
+	want := "scan 6 rows\n" +
+		"filter dept == Eng and salary > 70000\n" +
+		"sort name asc, score desc\n" +
+		"head 5\n" +
+		"filter score > 4\n" +
+		"project name, salary\n"
+	if got := lf.Explain(); got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+
+	got, err := lf.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	eager := df.
+		Sort("score", false).
+		Filter("dept", "==", "Eng").
+		Sort("name", true).
+		Filter("salary", ">", 70000).
+		Head(5).
+		Filter("score", ">", 4.0).
+		Select("name", "salary")
+	assertFramesEqual(t, got, eager)
+}
+
+// TestLazyGroupBy verifies that a lazy chain can end in a GroupBy.
+func TestLazyGroupBy(t *testing.T) {
+	df := lazyTestFrame(t)
+
+	got, err := df.Lazy().Filter("salary", ">=", 60000).Select("dept", "salary").GroupBy("dept").Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := df.Filter("salary", ">=", 60000).Select("dept", "salary").GroupBy("dept").Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFramesEqual(t, got, want)
+
+	if _, err := df.Lazy().Select("name").GroupBy("dept").Sum(); err == nil {
+		t.Error("GroupBy on an unselected column should error")
+	}
+}
+
 // BenchmarkChainedOps compares an eager Filter→Select→Sort chain against the
 // equivalent lazy chain (P4-B2).
 func BenchmarkChainedOps(b *testing.B) {
@@ -0,0 +1,191 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// SetIndex returns a copy of the DataFrame with the given columns moved out
+// of the columns and into the row index, so rows can be selected by their
+// values with Loc and LocRange:
+//
+//	byID := df.SetIndex("id")
+//	rows := byID.Loc(int64(7), int64(12))
+//
+// Several columns make a multi-level index, such as entity × date for
+// panel data; see IndexKey. Like the index from WithRowIndex, it stays
+// aligned through row selections, and ResetIndex turns it back into
+// columns.
+func (df *DataFrame) SetIndex(columns ...string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if len(columns) == 0 {
+		return df.setError(newOpError("SetIndex", "at least one column must be specified"))
+	}
+	if err := df.validateColumnsExist(columns); err != nil {
+		return df.setOpError("SetIndex", err)
+	}
+	if len(columns) >= len(df.order) {
+		return df.setError(newOpError("SetIndex", "at least one column must remain outside the index"))
+	}
+
+	levels := make([]*Series, len(columns))
+	for i, column := range columns {
+		if slices.Contains(columns[:i], column) {
+			return df.setError(newColumnError("SetIndex", column, "column specified more than once"))
+		}
+		levels[i] = df.columns[column].Copy()
+	}
+	newDf := df.Drop(columns...)
+	if err := newDf.Error(); err != nil {
+		return df.setError(wrapError("SetIndex", err))
+	}
+	newDf.index = levels
+	return newDf
+}
+
+// IndexKey is a Loc label for a multi-level index: its values match the
+// first len(key) index levels in order. A key shorter than the index
+// selects all rows under that prefix, so with an entity × date index
+// IndexKey{"acme"} selects every date of entity "acme".
+type IndexKey []any
+
+// Loc returns the rows whose index label equals one of labels, in the
+// order of labels; a label matching several rows returns all of them. A
+// label is a value of the first index level or an IndexKey. Values are
+// compared as by Filter with "==". A label matching no row is an error, as
+// is a DataFrame without an index (see SetIndex).
+func (df *DataFrame) Loc(labels ...any) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+	if df.index == nil {
+		return df.setError(newOpError("Loc", "DataFrame has no index; use SetIndex first"))
+	}
+
+	lookups := make([]*hashIndex, len(df.index))
+	var rows []int
+	for _, label := range labels {
+		key, ok := label.(IndexKey)
+		if !ok {
+			key = IndexKey{label}
+		}
+		if len(key) == 0 || len(key) > len(df.index) {
+			return df.setError(newOpError("Loc",
+				fmt.Sprintf("key %v has %d values for an index of %d levels", []any(key), len(key), len(df.index))))
+		}
+
+		var matches []int
+		for level, value := range key {
+			if lookups[level] == nil {
+				if lookups[level] = newSeriesHashIndex(df.index[level]); lookups[level] == nil {
+					return df.setError(newColumnError("Loc", df.index[level].Name, "unsupported index type"))
+				}
+			}
+			levelRows, _ := lookups[level].lookup(value)
+			if level == 0 {
+				matches = levelRows
+			} else {
+				matches = intersectSorted(matches, levelRows)
+			}
+			if len(matches) == 0 {
+				return df.setError(newColumnError("Loc", df.index[level].Name, fmt.Sprintf("label %v not found", label)))
+			}
+		}
+		rows = append(rows, matches...)
+	}
+	return df.selectRows(rows, "Loc")
+}
+
+// intersectSorted returns the values present in both ascending slices.
+func intersectSorted(a, b []int) []int {
+	var both []int
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0] < b[0]:
+			a = a[1:]
+		case a[0] > b[0]:
+			b = b[1:]
+		default:
+			both = append(both, a[0])
+			a, b = a[1:], b[1:]
+		}
+	}
+	return both
+}
+
+// LocRange returns the rows whose index label lies between from and to,
+// inclusive, in their current order; a multi-level index is compared on
+// its first level. A nil bound leaves that side open, so
+// LocRange(from, nil) returns every label from on. Rows with a missing
+// label (NaN, zero time) are never included. Numeric indexes take any
+// numeric bounds; string, bool and time indexes need bounds of their type.
+func (df *DataFrame) LocRange(from, to any) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+	if df.index == nil {
+		return df.setError(newOpError("LocRange", "DataFrame has no index; use SetIndex first"))
+	}
+
+	index := df.index[0]
+	var rows []int
+	var ok bool
+	switch data := index.Data.(type) {
+	case []int64:
+		rows, ok = labelRange(data, from, to, toFloat64, func(v int64) (float64, bool) { return float64(v), true }, compareFloat64)
+	case []float64:
+		rows, ok = labelRange(data, from, to, toFloat64, func(v float64) (float64, bool) { return v, !math.IsNaN(v) }, compareFloat64)
+	case []string:
+		rows, ok = labelRange(data, from, to, asType[string], func(v string) (string, bool) { return v, true }, compareStrings)
+	case []bool:
+		rows, ok = labelRange(data, from, to, asType[bool], func(v bool) (bool, bool) { return v, true }, compareBool)
+	case []time.Time:
+		rows, ok = labelRange(data, from, to, asType[time.Time], func(v time.Time) (time.Time, bool) { return v, !v.IsZero() }, compareTime)
+	default:
+		return df.setError(newColumnError("LocRange", index.Name, "unsupported index type"))
+	}
+	if !ok {
+		return df.setError(newColumnError("LocRange", index.Name,
+			fmt.Sprintf("bounds %v and %v cannot be compared with a %s index", from, to, index.Type)))
+	}
+	return df.selectRows(rows, "LocRange")
+}
+
+// labelRange returns the positions of the labels within [from, to]. convert
+// maps a bound to a key and key maps a label to one, reporting false for a
+// missing label; ok is false if a bound has the wrong type.
+func labelRange[T, K any](labels []T, from, to any, convert func(any) (K, bool), key func(T) (K, bool), compare func(a, b K) int) (rows []int, ok bool) {
+	var lo, hi K
+	hasLo, hasHi := from != nil, to != nil
+	if hasLo {
+		if lo, ok = convert(from); !ok {
+			return nil, false
+		}
+	}
+	if hasHi {
+		if hi, ok = convert(to); !ok {
+			return nil, false
+		}
+	}
+
+	rows = []int{}
+	for i, label := range labels {
+		k, valid := key(label)
+		if !valid || (hasLo && compare(k, lo) < 0) || (hasHi && compare(k, hi) > 0) {
+			continue
+		}
+		rows = append(rows, i)
+	}
+	return rows, true
+}
+
+// asType converts value to T when it already has that type.
+func asType[T any](value any) (T, bool) {
+	v, ok := value.(T)
+	return v, ok
+}
@@ -0,0 +1,93 @@
+package otters
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func locTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{30, 10, 20, 10}),
+		mustSeries(t, "name", []string{"c", "a", "b", "d"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestSetIndexLoc(t *testing.T) {
+	df := locTestFrame(t).SetIndex("id")
+	if err := df.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if df.HasColumn("id") || df.Index() == nil || df.Index().Name != "id" {
+		t.Fatalf("SetIndex should move id into the index, columns %v", df.Columns())
+	}
+
+	rows := df.Loc(int64(20), 10)
+	if err := rows.Error(); err != nil {
+		t.Fatal(err)
+	}
+	names := rows.columns["name"].Data.([]string)
+	if !slices.Equal(names, []string{"b", "a", "d"}) {
+		t.Errorf("Loc names = %v, want [b a d]", names)
+	}
+	if got := rows.Index().Data.([]int64); !slices.Equal(got, []int64{20, 10, 10}) {
+		t.Errorf("Loc index = %v, want [20 10 10]", got)
+	}
+
+	if none := df.Loc(); none.Len() != 0 || none.Index().Length != 0 {
+		t.Errorf("Loc() = %d rows with %d index labels, want none", none.Len(), none.Index().Length)
+	}
+	if none := df.selectRows(nil, "test"); none.Len() != 0 || none.Index().Length != 0 {
+		t.Errorf("selectRows(nil) = %d rows with %d index labels, want none", none.Len(), none.Index().Length)
+	}
+	if df.Loc(int64(99)).Error() == nil {
+		t.Error("expected error for a missing label")
+	}
+	if locTestFrame(t).Loc(int64(10)).Error() == nil {
+		t.Error("expected error for Loc without an index")
+	}
+	if df.SetIndex("name").Error() == nil {
+		t.Error("expected error moving the only column into the index")
+	}
+
+	reset := df.ResetIndex()
+	if err := reset.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if reset.Index() != nil || !slices.Equal(reset.Columns(), []string{"id", "name"}) {
+		t.Errorf("ResetIndex columns = %v, want [id name]", reset.Columns())
+	}
+}
+
+func TestLocRange(t *testing.T) {
+	df := locTestFrame(t).SetIndex("id")
+
+	between := df.LocRange(10, 20.5)
+	if got := between.Index().Data.([]int64); !slices.Equal(got, []int64{10, 20, 10}) {
+		t.Errorf("LocRange(10, 20.5) index = %v, want [10 20 10]", got)
+	}
+	if got := df.LocRange(int64(15), nil).Index().Data.([]int64); !slices.Equal(got, []int64{30, 20}) {
+		t.Errorf("open-ended LocRange index = %v, want [30 20]", got)
+	}
+	if df.LocRange("a", "b").Error() == nil {
+		t.Error("expected error for string bounds on a numeric index")
+	}
+
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts, err := NewDataFrameFromSeries(
+		mustSeries(t, "date", []time.Time{day, {}, day.AddDate(0, 0, 2), day.AddDate(0, 0, 5)}),
+		mustSeries(t, "v", []float64{1, 2, 3, 4}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	week := ts.SetIndex("date").LocRange(day, day.AddDate(0, 0, 3))
+	if got := week.columns["v"].Data.([]float64); !slices.Equal(got, []float64{1, 3}) {
+		t.Errorf("time LocRange values = %v, want [1 3]", got)
+	}
+}
@@ -0,0 +1,160 @@
+package otters
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Mask is a boolean row selection, one entry per row. Masks are built with
+// DataFrame.Mask, combined with And, Or and Not, and applied with
+// FilterByMask, so one condition can be evaluated once and reused.
+type Mask []bool
+
+// Mask evaluates the condition against every row, returning true where it
+// holds. Operators are the same as for Filter.
+func (df *DataFrame) Mask(column, operator string, value any) (Mask, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return nil, err
+	}
+
+	pred, err := typedPredicate(df.columns[column], operator, value)
+	if err != nil {
+		return nil, wrapColumnError("Mask", column, err)
+	}
+
+	mask := make(Mask, df.length)
+	for i := range mask {
+		mask[i] = pred(i)
+	}
+	return mask, nil
+}
+
+// IsIn returns a mask that is true where the column's value is one of
+// values, a slice such as []int64 or []string (see the "in" operator of
+// Filter). The values go into a hash set of the column's type, so the cost
+// is one lookup per row however long the list is.
+func (df *DataFrame) IsIn(column string, values any) (Mask, error) {
+	return df.Mask(column, "in", values)
+}
+
+// And returns a new mask true where both masks are true, or nil if the
+// masks have different lengths
+func (m Mask) And(other Mask) Mask {
+	if len(m) != len(other) {
+		return nil
+	}
+	result := make(Mask, len(m))
+	for i := range m {
+		result[i] = m[i] && other[i]
+	}
+	return result
+}
+
+// Or returns a new mask true where either mask is true, or nil if the masks
+// have different lengths
+func (m Mask) Or(other Mask) Mask {
+	if len(m) != len(other) {
+		return nil
+	}
+	result := make(Mask, len(m))
+	for i := range m {
+		result[i] = m[i] || other[i]
+	}
+	return result
+}
+
+// Not returns a new mask with every entry inverted
+func (m Mask) Not() Mask {
+	result := make(Mask, len(m))
+	for i := range m {
+		result[i] = !m[i]
+	}
+	return result
+}
+
+// Count returns the number of true entries
+func (m Mask) Count() int {
+	n := 0
+	for _, v := range m {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+// Any reports whether any entry is true. An empty mask has none.
+func (m Mask) Any() bool {
+	return slices.Contains(m, true)
+}
+
+// All reports whether every entry is true. An empty mask has no false
+// entry, so All is true.
+func (m Mask) All() bool {
+	return !slices.Contains(m, false)
+}
+
+// Any reports whether a bool column holds a true value, for existence
+// checks without Filter and Len. To test a condition on another column,
+// build a Mask and use its Any method:
+//
+//	late, _ := df.Mask("days_overdue", ">", 30)
+//	if late.Any() { ... }
+func (df *DataFrame) Any(column string) (bool, error) {
+	data, err := df.boolColumn(column, "Any")
+	if err != nil {
+		return false, err
+	}
+	return Mask(data).Any(), nil
+}
+
+// All reports whether every value of a bool column is true. It is true for
+// an empty DataFrame.
+func (df *DataFrame) All(column string) (bool, error) {
+	data, err := df.boolColumn(column, "All")
+	if err != nil {
+		return false, err
+	}
+	return Mask(data).All(), nil
+}
+
+// boolColumn returns the data of a bool column, which must not be modified.
+func (df *DataFrame) boolColumn(column, operation string) ([]bool, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+	if err := df.validateColumnExists(column); err != nil {
+		return nil, err
+	}
+	series := df.columns[column]
+	if series.Type != BoolType {
+		return nil, newColumnError(operation, column, fmt.Sprintf("column must be bool, got %s", series.Type))
+	}
+	return series.Data.([]bool), nil
+}
+
+// FilterByMask creates a new DataFrame with the rows where the mask is true.
+// The mask must have one entry per row.
+func (df *DataFrame) FilterByMask(mask Mask) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if len(mask) != df.length {
+		return df.setError(newOpError("FilterByMask",
+			fmt.Sprintf("mask length %d does not match DataFrame length %d", len(mask), df.length)))
+	}
+
+	indices := make([]int, 0, mask.Count())
+	for i, keep := range mask {
+		if keep {
+			indices = append(indices, i)
+		}
+	}
+
+	return df.selectRows(indices, "FilterByMask")
+}
@@ -0,0 +1,122 @@
+package otters
+
+import "testing"
+
+func TestMaskCombinators(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "region", []string{"North", "South", "North", "East"}),
+		mustSeries(t, "sales", []float64{100, 250, 300, 80}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	north, err := df.Mask("region", "==", "North")
+	if err != nil {
+		t.Fatalf("Mask error: %v", err)
+	}
+	big, err := df.Mask("sales", ">", 200)
+	if err != nil {
+		t.Fatalf("Mask error: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		mask Mask
+		want int
+	}{
+		{"and", north.And(big), 1},
+		{"or", north.Or(big), 3},
+		{"not", north.Not(), 2},
+	}
+	for _, c := range cases {
+		result := df.FilterByMask(c.mask)
+		if result.Error() != nil {
+			t.Fatalf("%s: FilterByMask error: %v", c.name, result.Error())
+		}
+		if result.Len() != c.want || c.mask.Count() != c.want {
+			t.Errorf("%s: got %d rows, want %d", c.name, result.Len(), c.want)
+		}
+	}
+
+	if north.And(Mask{true}) != nil {
+		t.Error("And of masks with different lengths should be nil")
+	}
+	if df.FilterByMask(Mask{true}).Error() == nil {
+		t.Error("expected error for mask length mismatch")
+	}
+	if _, err := df.Mask("missing", "==", 1); err == nil {
+		t.Error("expected error for missing column")
+	}
+}
+
+func TestIsIn(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{10, 20, 30, 40}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mask, err := df.IsIn("id", []int64{40, 10, 99})
+	if err != nil {
+		t.Fatalf("IsIn error: %v", err)
+	}
+	want := []bool{true, false, false, true}
+	for i := range want {
+		if mask[i] != want[i] {
+			t.Errorf("mask[%d] = %v, want %v", i, mask[i], want[i])
+		}
+	}
+	if got := df.FilterByMask(mask.Not()).Len(); got != 2 {
+		t.Errorf("FilterByMask(Not) kept %d rows, want 2", got)
+	}
+
+	if _, err := df.IsIn("id", "10"); err == nil {
+		t.Error("expected error for non-slice values")
+	}
+	if _, err := df.IsIn("missing", []int64{1}); err == nil {
+		t.Error("expected error for missing column")
+	}
+}
+
+func TestAnyAll(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "paid", []bool{true, false, true}),
+		mustSeries(t, "shipped", []bool{true, true, true}),
+		mustSeries(t, "amount", []float64{10, 0, 5}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		column   string
+		any, all bool
+	}{
+		{"paid", true, false},
+		{"shipped", true, true},
+	}
+	for _, c := range cases {
+		if got, err := df.Any(c.column); err != nil || got != c.any {
+			t.Errorf("Any(%s) = %v, %v; want %v", c.column, got, err, c.any)
+		}
+		if got, err := df.All(c.column); err != nil || got != c.all {
+			t.Errorf("All(%s) = %v, %v; want %v", c.column, got, err, c.all)
+		}
+	}
+	if _, err := df.Any("amount"); err == nil {
+		t.Error("Any on a float64 column: expected an error")
+	}
+	if _, err := df.All("missing"); err == nil {
+		t.Error("All on a missing column: expected an error")
+	}
+
+	free, _ := df.Mask("amount", "==", 0.0)
+	if !free.Any() || free.All() {
+		t.Errorf("mask %v: Any = %v, All = %v", free, free.Any(), free.All())
+	}
+	if empty := (Mask{}); empty.Any() || !empty.All() {
+		t.Error("empty mask: want Any false and All true")
+	}
+}
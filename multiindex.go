@@ -0,0 +1,177 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// IndexLevels returns copies of the levels of the DataFrame's row index, in
+// order, or nil if it has none.
+func (df *DataFrame) IndexLevels() []*Series {
+	if df.err != nil {
+		return nil
+	}
+	return df.copyIndex()
+}
+
+// indexLevel returns the position of the named index level, or -1.
+func (df *DataFrame) indexLevel(name string) int {
+	return slices.IndexFunc(df.index, func(level *Series) bool { return level.Name == name })
+}
+
+// GroupByLevel groups the rows by the named index levels, for aggregating
+// panel data across the other levels:
+//
+//	panel := df.SetIndex("entity", "date")
+//	totals, err := panel.GroupByLevel("entity").Sum() // per entity, over all dates
+//
+// The levels become the group columns of the results; the other levels are
+// left out.
+func (df *DataFrame) GroupByLevel(levels ...string) *GroupBy {
+	if df.err != nil {
+		return &GroupBy{df: df, err: df.err}
+	}
+	if len(levels) == 0 {
+		return &GroupBy{df: df, err: newOpError("GroupByLevel", "at least one level must be specified")}
+	}
+	for _, level := range levels {
+		if df.indexLevel(level) < 0 {
+			return &GroupBy{df: df, err: newColumnError("GroupByLevel", level, "not an index level")}
+		}
+	}
+
+	var others []string
+	for _, level := range df.index {
+		if !slices.Contains(levels, level.Name) {
+			others = append(others, level.Name)
+		}
+	}
+	flat := df.ResetIndex().Drop(others...)
+	if err := flat.Error(); err != nil {
+		return &GroupBy{df: df, err: wrapError("GroupByLevel", err)}
+	}
+	return flat.GroupBy(levels...)
+}
+
+// Unstack pivots the named index level into columns, leaving the other
+// levels as the index. With an entity × date index, Unstack("entity")
+// gives one row per date and one column per entity:
+//
+//	wide := df.SetIndex("entity", "date").Unstack("entity")
+//
+// Each new column is named by its level value, or "<column>_<value>" when
+// the frame has several columns. Rows and columns follow the order in which
+// their labels first appear. Cells with no matching row are missing values
+// (NaN, zero time), or the zero value for strings and bools; an int64
+// column with missing cells becomes float64 to hold NaN. Two rows with the
+// same labels are an error, and so is a frame with no rows, whose labels
+// give no columns.
+func (df *DataFrame) Unstack(level string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	pivot := df.indexLevel(level)
+	if pivot < 0 {
+		return df.setError(newColumnError("Unstack", level, "not an index level"))
+	}
+	if len(df.index) < 2 {
+		return df.setError(newOpError("Unstack", "index must have at least two levels"))
+	}
+	if df.length == 0 {
+		return df.setError(newOpError("Unstack", "cannot unstack an empty DataFrame"))
+	}
+	rest := slices.Delete(slices.Clone(df.index), pivot, pivot+1)
+
+	rowGroups, _ := buildGroupsHashed(rest, 0, df.length)
+	colGroups, _ := buildGroupsHashed([]*Series{df.index[pivot]}, 0, df.length)
+	rowOf := make([]int, df.length)
+	for r, g := range rowGroups {
+		for _, i := range g.indices {
+			rowOf[i] = r
+		}
+	}
+	for _, g := range colGroups {
+		seen := make([]bool, len(rowGroups))
+		for _, i := range g.indices {
+			if seen[rowOf[i]] {
+				return df.setError(newRowError("Unstack", i, "duplicate index labels"))
+			}
+			seen[rowOf[i]] = true
+		}
+	}
+
+	newDf := NewDataFrame()
+	newDf.length = len(rowGroups)
+	firstRows := make([]int, len(rowGroups))
+	for r, g := range rowGroups {
+		firstRows[r] = g.indices[0]
+	}
+	for _, level := range rest {
+		index, err := newSeriesOwned(level.Name, selectSeriesRows(level, firstRows))
+		if err != nil {
+			return df.setError(wrapError("Unstack", err))
+		}
+		newDf.index = append(newDf.index, index)
+	}
+
+	for _, column := range df.order {
+		series := df.columns[column]
+		for _, g := range colGroups {
+			label, _ := df.index[pivot].Get(g.indices[0])
+			name := formatValueForCSV(label)
+			if len(df.order) > 1 {
+				name = column + "_" + name
+			}
+			if newDf.HasColumn(name) {
+				return df.setError(newColumnError("Unstack", name, "result column name used more than once"))
+			}
+
+			var data any
+			switch values := series.Data.(type) {
+			case []int64:
+				if len(g.indices) == len(rowGroups) {
+					data = unstackValues(values, g.indices, rowOf, len(rowGroups), 0)
+				} else {
+					floats := make([]float64, len(values))
+					for i, v := range values {
+						floats[i] = float64(v)
+					}
+					data = unstackValues(floats, g.indices, rowOf, len(rowGroups), math.NaN())
+				}
+			case []float64:
+				data = unstackValues(values, g.indices, rowOf, len(rowGroups), math.NaN())
+			case []string:
+				data = unstackValues(values, g.indices, rowOf, len(rowGroups), "")
+			case []bool:
+				data = unstackValues(values, g.indices, rowOf, len(rowGroups), false)
+			case []time.Time:
+				data = unstackValues(values, g.indices, rowOf, len(rowGroups), time.Time{})
+			}
+			s, err := newSeriesOwned(name, data)
+			if err != nil {
+				return df.setError(wrapColumnError("Unstack", name, err))
+			}
+			newDf.addSeriesUnsafe(s)
+		}
+	}
+	if len(newDf.order) == 0 {
+		return df.setError(newOpError("Unstack", fmt.Sprintf("no columns to unstack by %s", level)))
+	}
+	return newDf
+}
+
+// unstackValues places values[i], for each row i in indices, at position
+// rowOf[i] of a new column of length rows, filling the rest with missing.
+func unstackValues[T any](values []T, indices, rowOf []int, rows int, missing T) []T {
+	out := make([]T, rows)
+	for i := range out {
+		out[i] = missing
+	}
+	for _, i := range indices {
+		out[rowOf[i]] = values[i]
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package otters
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func panelTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "entity", []string{"acme", "acme", "globex", "globex", "initech"}),
+		mustSeries(t, "date", []time.Time{jan, feb, jan, feb, feb}),
+		mustSeries(t, "sales", []int64{10, 12, 7, 9, 3}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df.SetIndex("entity", "date")
+}
+
+func TestMultiIndexLoc(t *testing.T) {
+	panel := panelTestFrame(t)
+	if err := panel.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if levels := panel.IndexLevels(); len(levels) != 2 || levels[1].Name != "date" {
+		t.Fatalf("IndexLevels = %v", levels)
+	}
+
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	rows := panel.Loc(IndexKey{"globex", feb}, "acme")
+	if err := rows.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if got := rows.columns["sales"].Int64Slice(); !slices.Equal(got, []int64{9, 10, 12}) {
+		t.Errorf("Loc sales = %v, want [9 10 12]", got)
+	}
+	if len(rows.IndexLevels()) != 2 {
+		t.Error("Loc should keep both index levels")
+	}
+
+	if panel.Loc(IndexKey{"initech", feb.AddDate(0, -1, 0)}).Error() == nil {
+		t.Error("expected error for a missing full key")
+	}
+	if panel.Loc(IndexKey{"acme", feb, 1}).Error() == nil {
+		t.Error("expected error for a key longer than the index")
+	}
+
+	flat := panel.ResetIndex()
+	if !slices.Equal(flat.Columns(), []string{"entity", "date", "sales"}) {
+		t.Errorf("ResetIndex columns = %v", flat.Columns())
+	}
+}
+
+func TestGroupByLevel(t *testing.T) {
+	panel := panelTestFrame(t)
+
+	sums, err := panel.GroupByLevel("entity").Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(sums.Columns(), []string{"entity", "sales"}) {
+		t.Fatalf("columns = %v, want [entity sales]", sums.Columns())
+	}
+	for i, want := range []float64{22, 16, 3} {
+		if got, _ := toFloat64(sums.MustGet(i, "sales")); got != want {
+			t.Errorf("sum %d = %v, want %v", i, got, want)
+		}
+	}
+
+	if _, err := panel.GroupByLevel("sales").Sum(); err == nil {
+		t.Error("expected error grouping by a column that is not a level")
+	}
+}
+
+func TestUnstack(t *testing.T) {
+	panel := panelTestFrame(t)
+
+	wide := panel.Unstack("entity")
+	if err := wide.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(wide.Columns(), []string{"acme", "globex", "initech"}) {
+		t.Fatalf("columns = %v", wide.Columns())
+	}
+	if wide.Len() != 2 || wide.Index().Name != "date" {
+		t.Fatalf("expected 2 rows indexed by date, got %d", wide.Len())
+	}
+	if got := wide.columns["acme"].Int64Slice(); !slices.Equal(got, []int64{10, 12}) {
+		t.Errorf("acme = %v, want [10 12]", got)
+	}
+	initech := wide.columns["initech"]
+	if initech.Type != Float64Type || !math.IsNaN(initech.Data.([]float64)[0]) || initech.Data.([]float64)[1] != 3 {
+		t.Errorf("initech should be float64 [NaN 3], got %v", initech.Data)
+	}
+
+	if panel.Unstack("sales").Error() == nil {
+		t.Error("expected error unstacking a column that is not a level")
+	}
+	if panel.ResetIndex().SetIndex("entity").Unstack("entity").Error() == nil {
+		t.Error("expected error unstacking a single-level index")
+	}
+	dup := panel.ResetIndex().SetIndex("entity").ResetIndex()
+	dup.columns["date"] = mustSeries(t, "date", make([]time.Time, 5))
+	if dup.SetIndex("entity", "date").Unstack("date").Error() == nil {
+		t.Error("expected error for duplicate index labels")
+	}
+	empty := panel.Filter("sales", ">", 1e9).Unstack("entity")
+	if err := empty.Error(); err == nil || !strings.Contains(err.Error(), "empty DataFrame") {
+		t.Errorf("error = %v, want one about the empty DataFrame", err)
+	}
+}
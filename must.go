@@ -0,0 +1,93 @@
+package otters
+
+// Must variants panic instead of returning errors, for short scripts and
+// tests where checking every step is noise. The panic value is the original
+// error (usually an *OtterError), so a recover can still inspect it with
+// errors.As and errors.Is.
+
+// Must returns the DataFrame unchanged, or panics with its error
+func (df *DataFrame) Must() *DataFrame {
+	if df.err != nil {
+		panic(df.err)
+	}
+	return df
+}
+
+// MustFilter is like Filter but panics on error
+func (df *DataFrame) MustFilter(column, operator string, value any) *DataFrame {
+	return df.Filter(column, operator, value).Must()
+}
+
+// MustSelect is like Select but panics on error
+func (df *DataFrame) MustSelect(columns ...string) *DataFrame {
+	return df.Select(columns...).Must()
+}
+
+// MustDrop is like Drop but panics on error
+func (df *DataFrame) MustDrop(columns ...string) *DataFrame {
+	return df.Drop(columns...).Must()
+}
+
+// MustSort is like Sort but panics on error
+func (df *DataFrame) MustSort(column string, ascending bool) *DataFrame {
+	return df.Sort(column, ascending).Must()
+}
+
+// MustSortBy is like SortBy but panics on error
+func (df *DataFrame) MustSortBy(columns []string, ascending []bool) *DataFrame {
+	return df.SortBy(columns, ascending).Must()
+}
+
+// MustQuery is like Query but panics on error
+func (df *DataFrame) MustQuery(query string) *DataFrame {
+	return df.Query(query).Must()
+}
+
+// MustGet is like Get but panics on error
+func (df *DataFrame) MustGet(row int, column string) any {
+	value, err := df.Get(row, column)
+	must(err)
+	return value
+}
+
+// MustReadCSV is like ReadCSV but panics on error
+func MustReadCSV(filename string) *DataFrame {
+	df, err := ReadCSV(filename)
+	must(err)
+	return df
+}
+
+// MustReadCSVFromString is like ReadCSVFromString but panics on error
+func MustReadCSVFromString(data string) *DataFrame {
+	df, err := ReadCSVFromString(data)
+	must(err)
+	return df
+}
+
+// MustReadJSONL is like ReadJSONL but panics on error
+func MustReadJSONL(filename string) *DataFrame {
+	df, err := ReadJSONL(filename)
+	must(err)
+	return df
+}
+
+// MustReadJSONLFromString is like ReadJSONLFromString but panics on error
+func MustReadJSONLFromString(data string) *DataFrame {
+	df, err := ReadJSONLFromString(data)
+	must(err)
+	return df
+}
+
+// MustNewDataFrameFromMap is like NewDataFrameFromMap but panics on error
+func MustNewDataFrameFromMap(data map[string]any) *DataFrame {
+	df, err := NewDataFrameFromMap(data)
+	must(err)
+	return df
+}
+
+// must panics with err if it is non-nil
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
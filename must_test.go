@@ -0,0 +1,69 @@
+package otters
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// recoverOtterError runs fn and returns the *OtterError it panicked with.
+func recoverOtterError(t *testing.T, fn func()) (oe *OtterError) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected a panic")
+		}
+		err, ok := r.(error)
+		if !ok || !errors.As(err, &oe) {
+			t.Fatalf("panic value should be an *OtterError, got %T: %v", r, r)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestMustChain(t *testing.T) {
+	df := MustReadCSVFromString("name,age\nAlice,30\nBob,25\nCarol,35\n")
+
+	result := df.MustFilter("age", ">", 26).MustSort("age", false).MustSelect("name")
+	if result.Len() != 2 || result.MustGet(0, "name") != "Carol" {
+		t.Errorf("unexpected result:\n%v", result)
+	}
+
+	if got := df.MustQuery("age < 30").Len(); got != 1 {
+		t.Errorf("MustQuery returned %d rows, want 1", got)
+	}
+	if got := df.MustDrop("age").Width(); got != 1 {
+		t.Errorf("MustDrop left %d columns, want 1", got)
+	}
+	if got := df.MustSortBy([]string{"name"}, []bool{true}).MustGet(0, "name"); got != "Alice" {
+		t.Errorf("MustSortBy first name = %v", got)
+	}
+	if got := MustNewDataFrameFromMap(map[string]any{"x": []int64{1}}).Len(); got != 1 {
+		t.Errorf("MustNewDataFrameFromMap rows = %d", got)
+	}
+}
+
+func TestMustPanicsWithOtterError(t *testing.T) {
+	df := MustReadCSVFromString("name,age\nAlice,30\n")
+
+	oe := recoverOtterError(t, func() { df.MustFilter("salary", ">", 1) })
+	if oe.Column != "salary" || !errors.Is(oe, ErrColumnNotFound) {
+		t.Errorf("panic lost error context: %v", oe)
+	}
+
+	// An error from earlier in the chain surfaces at the first Must
+	oe = recoverOtterError(t, func() { df.Head(0).Select("name").Must() })
+	if oe.Op != "Head" {
+		t.Errorf("expected the Head error, got %v", oe)
+	}
+
+	recoverOtterError(t, func() { df.MustGet(5, "name") })
+	recoverOtterError(t, func() { MustReadCSV(filepath.Join(t.TempDir(), "missing.csv")) })
+	recoverOtterError(t, func() { MustReadJSONL(filepath.Join(t.TempDir(), "missing.jsonl")) })
+	recoverOtterError(t, func() { MustReadJSONLFromString("not json") })
+	recoverOtterError(t, func() {
+		MustNewDataFrameFromMap(map[string]any{"a": []int64{1}, "b": []int64{1, 2}})
+	})
+}
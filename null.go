@@ -0,0 +1,190 @@
+package otters
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// Missing Values
+//
+// Columns have no separate null bitmap. A float64 NaN and a zero time.Time
+// are the missing values: they are what column arithmetic produces for
+// undefined results and what WriteJSONL writes as null. String, Int64 and
+// Bool columns cannot hold a missing value.
+
+// FilterOptions configures FilterWithOptions
+type FilterOptions struct {
+	// StrictNulls makes comparisons on a column holding missing values an
+	// error instead of skipping those rows, so they have to be dealt with
+	// explicitly using the "isnull" and "notnull" operators.
+	StrictNulls bool
+}
+
+// SortOptions configures SortByWithOptions
+type SortOptions struct {
+	// NullsFirst puts missing values before all other values. By default
+	// they sort last, whichever direction the column is sorted in.
+	NullsFirst bool
+
+	// Natural compares string columns the way people read them: runs of
+	// digits compare by numeric value, so "file2" sorts before "file10".
+	Natural bool
+
+	// Progress, if set, is told when sorting starts (0 of the row count)
+	// and when the rows are in order.
+	Progress ProgressFunc
+}
+
+// sortComparator compares two rows of a sort column in the requested
+// direction, placing missing values first or last regardless of it.
+// Returns nil for unsupported types.
+func sortComparator(series *Series, ascending bool, options SortOptions) func(a, b int) int {
+	compare := typedComparator(series)
+	if options.Natural && series.Type == StringType {
+		data := series.Data.([]string)
+		compare = func(a, b int) int { return compareNatural(data[a], data[b]) }
+	}
+	if compare == nil {
+		return nil
+	}
+	if !ascending {
+		asc := compare
+		compare = func(a, b int) int { return asc(b, a) }
+	}
+	if firstNull(series) < 0 {
+		return compare
+	}
+
+	isNull := nullPredicate(series)
+	return func(a, b int) int {
+		nullA, nullB := isNull(a), isNull(b)
+		switch {
+		case nullA && nullB:
+			return 0
+		case nullA:
+			if options.NullsFirst {
+				return -1
+			}
+			return 1
+		case nullB:
+			if options.NullsFirst {
+				return 1
+			}
+			return -1
+		}
+		return compare(a, b)
+	}
+}
+
+// nullPredicate returns a predicate reporting whether a row of the series
+// is missing. Column types without a missing value never report one.
+func nullPredicate(series *Series) func(row int) bool {
+	switch series.Type {
+	case Float64Type:
+		data := series.Data.([]float64)
+		return func(row int) bool { return math.IsNaN(data[row]) }
+	case TimeType:
+		data := series.Data.([]time.Time)
+		return func(row int) bool { return data[row].IsZero() }
+	default:
+		return func(int) bool { return false }
+	}
+}
+
+// holdsMissingValues reports whether columns of type t can hold missing
+// values: NaN in float64 columns and the zero time in time columns.
+func holdsMissingValues(t ColumnType) bool {
+	return t == Float64Type || t == TimeType
+}
+
+// firstNull returns the first row of the series holding a missing value,
+// or -1 if there is none.
+func firstNull(series *Series) int {
+	isNull := nullPredicate(series)
+	for row := 0; row < series.Length; row++ {
+		if isNull(row) {
+			return row
+		}
+	}
+	return -1
+}
+
+// ReplaceInf returns a copy of the DataFrame with every +Inf and -Inf in
+// column replaced by with. Infinities come from CSV cells such as "inf" or
+// from division by zero and would otherwise run through Describe and
+// Correlation; pass math.NaN() to turn them into missing values. An int64
+// column cannot hold an infinity and is returned unchanged.
+func (df *DataFrame) ReplaceInf(column string, with float64) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+	if err := df.validateColumnExists(column); err != nil {
+		return df.setOpError("ReplaceInf", err)
+	}
+
+	series := df.columns[column]
+	if !isNumericType(series.Type) {
+		return df.setError(newColumnError("ReplaceInf", column, "column must be numeric (int64 or float64)"))
+	}
+
+	newDf := df.Copy()
+	if firstInf(series) < 0 {
+		return newDf
+	}
+	data := slices.Clone(series.Data.([]float64))
+	for i, v := range data {
+		if math.IsInf(v, 0) {
+			data[i] = with
+		}
+	}
+	replaced, err := newSeriesOwned(column, data)
+	if err != nil {
+		return df.setError(wrapColumnError("ReplaceInf", column, err))
+	}
+	newDf.columns[column] = replaced
+	return newDf
+}
+
+// firstInf returns the first row of the series holding +Inf or -Inf, or -1
+// if there is none.
+func firstInf(series *Series) int {
+	if data, ok := series.Data.([]float64); ok {
+		for row, v := range data {
+			if math.IsInf(v, 0) {
+				return row
+			}
+		}
+	}
+	return -1
+}
+
+// infError reports the first infinite value found in columns, for the
+// statistics that refuse them unless asked to skip them.
+func (df *DataFrame) infError(columns []string, operation string) error {
+	for _, column := range columns {
+		if row := firstInf(df.columns[column]); row >= 0 {
+			return &OtterError{Op: operation, Column: column, Row: row,
+				Message: "infinite value; use ReplaceInf or SkipInf"}
+		}
+	}
+	return nil
+}
+
+// finiteRows returns the rows where none of columns holds +Inf or -Inf.
+func (df *DataFrame) finiteRows(columns []string) []int {
+	rows := make([]int, 0, df.length)
+	for row := 0; row < df.length; row++ {
+		finite := true
+		for _, column := range columns {
+			if data, ok := df.columns[column].Data.([]float64); ok && math.IsInf(data[row], 0) {
+				finite = false
+				break
+			}
+		}
+		if finite {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
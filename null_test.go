@@ -0,0 +1,143 @@
+package otters
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFilterNullSemantics(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "price", []float64{1, math.NaN(), 3}),
+		mustSeries(t, "seen", []time.Time{day, {}, day}),
+		mustSeries(t, "id", []int64{1, 2, 3}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		column string
+		op     string
+		value  any
+		want   int
+	}{
+		{"price", "!=", 1.0, 1},
+		{"price", "isnull", nil, 1},
+		{"price", "notnull", nil, 2},
+		{"seen", "<", day.Add(time.Hour), 2},
+		{"seen", "==", time.Time{}, 0},
+		{"seen", "isnull", nil, 1},
+		{"seen", "in", []time.Time{{}}, 0},
+		{"id", "isnull", nil, 0},
+	}
+	for _, c := range cases {
+		result := df.Filter(c.column, c.op, c.value)
+		if result.Error() != nil {
+			t.Fatalf("%s %s: %v", c.column, c.op, result.Error())
+		}
+		if result.Len() != c.want {
+			t.Errorf("%s %s %v: got %d rows, want %d", c.column, c.op, c.value, result.Len(), c.want)
+		}
+	}
+}
+
+func TestFilterStrictNulls(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "price", []float64{1, math.NaN(), 3}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strict := FilterOptions{StrictNulls: true}
+
+	result := df.FilterWithOptions("price", ">", 0, strict)
+	oe, ok := result.Error().(*OtterError)
+	if !ok || oe.Row != 1 || oe.Column != "price" {
+		t.Fatalf("expected error at row 1 of price, got %v", result.Error())
+	}
+
+	clean := df.FilterWithOptions("price", "notnull", nil, strict).
+		FilterWithOptions("price", ">", 0, strict)
+	if clean.Error() != nil {
+		t.Fatalf("strict filter after notnull: %v", clean.Error())
+	}
+	if clean.Len() != 2 {
+		t.Errorf("got %d rows, want 2", clean.Len())
+	}
+}
+
+func TestSortNullPlacement(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	nan := math.NaN()
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{1, 2, 3, 4, 5}),
+		mustSeries(t, "price", []float64{2, nan, 1, nan, 3}),
+		mustSeries(t, "seen", []time.Time{day, {}, day.Add(-time.Hour), day.Add(time.Hour), {}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		column     string
+		ascending  bool
+		nullsFirst bool
+		want       []int64
+	}{
+		{"price", true, false, []int64{3, 1, 5, 2, 4}},
+		{"price", false, false, []int64{5, 1, 3, 2, 4}},
+		{"price", true, true, []int64{2, 4, 3, 1, 5}},
+		{"price", false, true, []int64{2, 4, 5, 1, 3}},
+		{"seen", true, false, []int64{3, 1, 4, 2, 5}},
+		{"seen", false, true, []int64{2, 5, 4, 1, 3}},
+	}
+	for _, c := range cases {
+		sorted := df.SortByWithOptions([]string{c.column}, []bool{c.ascending}, SortOptions{NullsFirst: c.nullsFirst})
+		if err := sorted.Error(); err != nil {
+			t.Fatal(err)
+		}
+		ids, _ := sorted.GetSeries("id")
+		if got := ids.Data.([]int64); !slices.Equal(got, c.want) {
+			t.Errorf("%s asc=%v nullsFirst=%v: ids %v, want %v", c.column, c.ascending, c.nullsFirst, got, c.want)
+		}
+	}
+
+	lazy, err := df.Lazy().Sort("price", false).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, _ := lazy.GetSeries("id")
+	if got := ids.Data.([]int64); !slices.Equal(got, []int64{5, 1, 3, 2, 4}) {
+		t.Errorf("lazy sort ids %v, want NaN rows last", got)
+	}
+}
+
+func TestReplaceInf(t *testing.T) {
+	df, err := ReadCSVFromString("x,n\n1,1\ninf,2\n-inf,3\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replaced := df.ReplaceInf("x", math.NaN())
+	if replaced.Error() != nil {
+		t.Fatal(replaced.Error())
+	}
+	series, _ := replaced.GetSeries("x")
+	got := series.Data.([]float64)
+	if got[0] != 1 || !math.IsNaN(got[1]) || !math.IsNaN(got[2]) {
+		t.Errorf("x = %v, want [1 NaN NaN]", got)
+	}
+	if original, _ := df.Get(1, "x"); !math.IsInf(original.(float64), 1) {
+		t.Errorf("original x[1] = %v, want +Inf", original)
+	}
+
+	if err := df.ReplaceInf("n", 0).Error(); err != nil {
+		t.Errorf("int64 column: %v", err)
+	}
+	if err := df.ReplaceInf("missing", 0).Error(); err == nil {
+		t.Error("missing column: expected an error")
+	}
+}
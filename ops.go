@@ -1,48 +1,115 @@
 package otters
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 )
 
-// Filter creates a new DataFrame with rows that match the condition
+// Filter creates a new DataFrame with rows that match the condition.
+// Comparisons never match missing values (NaN, zero times); select those
+// with the "isnull" and "notnull" operators.
 func (df *DataFrame) Filter(column, operator string, value any) *DataFrame {
+	return df.FilterWithOptions(column, operator, value, FilterOptions{})
+}
+
+// FilterWithOptions is Filter with options controlling missing values
+func (df *DataFrame) FilterWithOptions(column, operator string, value any, options FilterOptions) *DataFrame {
 	if df.err != nil {
 		return df
 	}
 
 	if err := df.validateColumnExists(column); err != nil {
-		return df.setError(err)
+		return df.setOpError("Filter", err)
 	}
 
 	if err := df.validateNotEmpty(); err != nil {
-		return df.setError(err)
+		return df.setOpError("Filter", err)
 	}
 
 	series := df.columns[column]
 
-	// Try optimized typed path first
-	matchingIndices, err := filterIndicesTyped(series, operator, value)
-	if err != nil {
-		return df.setError(wrapColumnError("Filter", column, err))
+	if options.StrictNulls && operator != "isnull" && operator != "notnull" {
+		if row := firstNull(series); row >= 0 {
+			return df.setError(&OtterError{
+				Op:      "Filter",
+				Column:  column,
+				Row:     row,
+				Message: `column contains missing values; filter them with "isnull" or "notnull" first`,
+			})
+		}
+	}
+
+	// Equality on an indexed column is a lookup
+	var result *DataFrame
+	if operator == "==" || operator == "=" {
+		if rows, ok := df.indexedRows(column, value); ok {
+			result = df.selectRows(rows, "Filter")
+		}
+	}
+	missing := missingRows{first: -1}
+	if result == nil {
+		var matchingIndices []int
+		var err error
+		matchingIndices, missing, err = filterRows(series, operator, value)
+		if err != nil {
+			return df.setError(wrapColumnError("Filter", column, err))
+		}
+		result = df.selectRows(matchingIndices, "Filter")
 	}
 
-	return df.selectRows(matchingIndices, "Filter")
+	if result.err == nil {
+		for _, w := range filterWarnings(series, operator, value, missing, "Filter") {
+			result.warn(w)
+		}
+	}
+	return result
+}
+
+// filterRows returns the rows of series matching the filter. Rows holding
+// a missing value never match a comparison; they are counted in the same
+// scan, for the warning about them.
+func filterRows(series *Series, operator string, value any) ([]int, missingRows, error) {
+	if !holdsMissingValues(series.Type) || operator == "isnull" || operator == "notnull" {
+		indices, err := filterIndicesTyped(series, operator, value)
+		return indices, missingRows{first: -1}, err
+	}
+	pred, err := typedPredicate(series, operator, value)
+	if err != nil {
+		return nil, missingRows{}, err
+	}
+	indices, missing := matchingRowsSkipping(series.Length, nullPredicate(series), pred)
+	return indices, missing, nil
 }
 
 // filterIndicesTyped returns matching indices using typed slice access to avoid boxing.
 func filterIndicesTyped(series *Series, operator string, value any) ([]int, error) {
+	if err := strictFilterValue(series, operator, value); err != nil {
+		return nil, err
+	}
+	if pred, ok, err := specialPredicate(series, operator, value); ok {
+		if err != nil {
+			return nil, err
+		}
+		return matchingRows(series.Length, pred), nil
+	}
+
 	switch series.Type {
 	case Int64Type:
 		return filterInt64Indices(series.Data.([]int64), operator, value)
 	case Float64Type:
 		return filterFloat64Indices(series.Data.([]float64), operator, value)
 	case StringType:
+		if pred, ok := dictPredicate(series, operator, fmt.Sprintf("%v", value)); ok {
+			return matchingRows(series.Length, pred), nil
+		}
 		return filterStringIndices(series.Data.([]string), operator, value)
 	case BoolType:
 		return filterBoolIndices(series.Data.([]bool), operator, value)
@@ -53,30 +120,18 @@ func filterIndicesTyped(series *Series, operator string, value any) ([]int, erro
 }
 
 func filterInt64Indices(data []int64, op string, value any) ([]int, error) {
-	// A fractional comparison value cannot be truncated to int64 without
-	// changing the predicate (e.g. "== 2.5" would match 2); compare in
-	// float64 space instead.
-	if f, isFloat := value.(float64); isFloat && f != math.Trunc(f) {
-		indices := make([]int, 0, len(data)/4)
-		for i, v := range data {
-			if matchFloat64(float64(v), op, f) {
-				indices = append(indices, i)
-			}
-		}
-		return indices, nil
+	// A fractional or out-of-range comparison value cannot be converted to
+	// int64 without changing the predicate (e.g. "== 2.5" would match 2);
+	// compare in float64 space instead.
+	if f, isFloat := value.(float64); isFloat && !floatIsInt64(f) {
+		return matchingValues(data, func(v int64) bool { return matchFloat64(float64(v), op, f) }), nil
 	}
 
 	cmp, ok := toInt64(value)
 	if !ok {
 		return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to int64", value))
 	}
-	indices := make([]int, 0, len(data)/4)
-	for i, v := range data {
-		if matchInt64(v, op, cmp) {
-			indices = append(indices, i)
-		}
-	}
-	return indices, nil
+	return matchingValues(data, func(v int64) bool { return matchInt64(v, op, cmp) }), nil
 }
 
 func filterFloat64Indices(data []float64, op string, value any) ([]int, error) {
@@ -84,13 +139,7 @@ func filterFloat64Indices(data []float64, op string, value any) ([]int, error) {
 	if !ok {
 		return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to float64", value))
 	}
-	indices := make([]int, 0, len(data)/4)
-	for i, v := range data {
-		if matchFloat64(v, op, cmp) {
-			indices = append(indices, i)
-		}
-	}
-	return indices, nil
+	return matchingValues(data, func(v float64) bool { return matchFloat64(v, op, cmp) }), nil
 }
 
 func filterStringIndices(data []string, op string, value any) ([]int, error) {
@@ -98,13 +147,7 @@ func filterStringIndices(data []string, op string, value any) ([]int, error) {
 	if !ok {
 		cmp = fmt.Sprintf("%v", value)
 	}
-	indices := make([]int, 0, len(data)/4)
-	for i, v := range data {
-		if matchString(v, op, cmp) {
-			indices = append(indices, i)
-		}
-	}
-	return indices, nil
+	return matchingValues(data, func(v string) bool { return matchString(v, op, cmp) }), nil
 }
 
 func filterBoolIndices(data []bool, op string, value any) ([]int, error) {
@@ -112,13 +155,7 @@ func filterBoolIndices(data []bool, op string, value any) ([]int, error) {
 	if !ok {
 		return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to bool", value))
 	}
-	indices := make([]int, 0, len(data)/4)
-	for i, v := range data {
-		if matchBool(v, op, cmp) {
-			indices = append(indices, i)
-		}
-	}
-	return indices, nil
+	return matchingValues(data, func(v bool) bool { return matchBool(v, op, cmp) }), nil
 }
 
 func filterTimeIndices(data []time.Time, op string, value any) ([]int, error) {
@@ -126,13 +163,7 @@ func filterTimeIndices(data []time.Time, op string, value any) ([]int, error) {
 	if !ok {
 		return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to time.Time", value))
 	}
-	indices := make([]int, 0, len(data)/4)
-	for i, v := range data {
-		if matchTime(v, op, cmp) {
-			indices = append(indices, i)
-		}
-	}
-	return indices, nil
+	return matchingValues(data, func(v time.Time) bool { return matchTime(v, op, cmp) }), nil
 }
 
 func toInt64(v any) (int64, bool) {
@@ -177,7 +208,12 @@ func matchInt64(v int64, op string, cmp int64) bool {
 	return false
 }
 
+// matchFloat64 compares v with cmp. NaN is a missing value and matches no
+// comparison, not even "!=".
 func matchFloat64(v float64, op string, cmp float64) bool {
+	if math.IsNaN(v) {
+		return false
+	}
 	switch op {
 	case "==", "=":
 		return v == cmp
@@ -215,6 +251,14 @@ func matchString(v, op, cmp string) bool {
 		return strings.HasPrefix(v, cmp)
 	case "endswith":
 		return strings.HasSuffix(v, cmp)
+	case "iequals":
+		return strings.EqualFold(v, cmp)
+	case "icontains":
+		return strings.Contains(strings.ToLower(v), strings.ToLower(cmp))
+	case "istartswith":
+		return strings.HasPrefix(strings.ToLower(v), strings.ToLower(cmp))
+	case "iendswith":
+		return strings.HasSuffix(strings.ToLower(v), strings.ToLower(cmp))
 	}
 	return false
 }
@@ -229,7 +273,12 @@ func matchBool(v bool, op string, cmp bool) bool {
 	return false
 }
 
+// matchTime compares v with cmp. The zero time is a missing value and
+// matches no comparison.
 func matchTime(v time.Time, op string, cmp time.Time) bool {
+	if v.IsZero() {
+		return false
+	}
 	switch op {
 	case "==", "=":
 		return v.Equal(cmp)
@@ -247,6 +296,228 @@ func matchTime(v time.Time, op string, cmp time.Time) bool {
 	return false
 }
 
+// specialPredicate builds the row predicate for the operators that do not
+// compare against a single value: "in", "between", "isnull" and "notnull".
+// ok is false for any other operator.
+func specialPredicate(series *Series, operator string, value any) (pred func(row int) bool, ok bool, err error) {
+	switch operator {
+	case "in":
+		pred, err = inPredicate(series, value)
+	case "between":
+		pred, err = betweenPredicate(series, value)
+	case "isnull":
+		pred = nullPredicate(series)
+	case "notnull":
+		isNull := nullPredicate(series)
+		pred = func(row int) bool { return !isNull(row) }
+	default:
+		return nil, false, nil
+	}
+	return pred, true, err
+}
+
+// betweenPredicate builds a row predicate for the "between" operator: lo <=
+// v <= hi, with the bounds given as a two-element array or slice such as
+// [2]float64{10, 20}. Only numeric and time columns have an order to test.
+func betweenPredicate(series *Series, value any) (func(row int) bool, error) {
+	lo, hi, ok := betweenBounds(value)
+	if !ok {
+		return nil, newOpError("Filter", fmt.Sprintf(`operator "between" needs two bounds, got %T`, value))
+	}
+
+	switch series.Type {
+	case Int64Type:
+		data := series.Data.([]int64)
+		loInt, loOk := toInt64(lo)
+		hiInt, hiOk := toInt64(hi)
+		// Fractional or out-of-range bounds cannot be converted; compare in float64 space
+		// (same rule as Filter)
+		if f, isFloat := lo.(float64); isFloat && !floatIsInt64(f) {
+			loOk = false
+		}
+		if f, isFloat := hi.(float64); isFloat && !floatIsInt64(f) {
+			hiOk = false
+		}
+		if loOk && hiOk {
+			return func(row int) bool { return data[row] >= loInt && data[row] <= hiInt }, nil
+		}
+		loF, loOk := toFloat64(lo)
+		hiF, hiOk := toFloat64(hi)
+		if !loOk || !hiOk {
+			return nil, newOpError("Filter", "cannot convert bounds to int64")
+		}
+		return func(row int) bool { v := float64(data[row]); return v >= loF && v <= hiF }, nil
+
+	case Float64Type:
+		data := series.Data.([]float64)
+		loF, loOk := toFloat64(lo)
+		hiF, hiOk := toFloat64(hi)
+		if !loOk || !hiOk {
+			return nil, newOpError("Filter", "cannot convert bounds to float64")
+		}
+		return func(row int) bool { return data[row] >= loF && data[row] <= hiF }, nil
+
+	case TimeType:
+		data := series.Data.([]time.Time)
+		loT, loOk := lo.(time.Time)
+		hiT, hiOk := hi.(time.Time)
+		if !loOk || !hiOk {
+			return nil, newOpError("Filter", "cannot convert bounds to time.Time")
+		}
+		return func(row int) bool {
+			return !data[row].IsZero() && !data[row].Before(loT) && !data[row].After(hiT)
+		}, nil
+	}
+
+	return nil, newOpError("Filter", fmt.Sprintf(`operator "between" is not supported for %s columns`, series.Type))
+}
+
+// betweenBounds extracts the lower and upper bound of a "between" value.
+func betweenBounds(value any) (any, any, bool) {
+	switch v := value.(type) {
+	case [2]float64:
+		return v[0], v[1], true
+	case [2]int64:
+		return v[0], v[1], true
+	case [2]int:
+		return v[0], v[1], true
+	case [2]time.Time:
+		return v[0], v[1], true
+	case [2]any:
+		return v[0], v[1], true
+	}
+	bounds, ok := inCandidates(value)
+	if !ok || len(bounds) != 2 {
+		return nil, nil, false
+	}
+	return bounds[0], bounds[1], true
+}
+
+// inPredicate builds a row predicate for the "in" operator. value is a
+// slice of candidates ([]string, []int64, []int, []float64, []bool,
+// []time.Time or []any), loaded into a set of the column's type so each
+// row is a single lookup.
+func inPredicate(series *Series, value any) (func(row int) bool, error) {
+	candidates, ok := inCandidates(value)
+	if !ok {
+		return nil, newOpError("Filter", fmt.Sprintf(`operator "in" needs a slice of values, got %T`, value))
+	}
+
+	switch series.Type {
+	case Int64Type:
+		data := series.Data.([]int64)
+		set := make(map[int64]struct{}, len(candidates))
+		for _, c := range candidates {
+			// A fractional or out-of-range value can never equal an int64 row
+			if f, isFloat := c.(float64); isFloat && !floatIsInt64(f) {
+				continue
+			}
+			v, ok := toInt64(c)
+			if !ok {
+				return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to int64", c))
+			}
+			set[v] = struct{}{}
+		}
+		return func(row int) bool { _, ok := set[data[row]]; return ok }, nil
+
+	case Float64Type:
+		data := series.Data.([]float64)
+		set := make(map[float64]struct{}, len(candidates))
+		for _, c := range candidates {
+			v, ok := toFloat64(c)
+			if !ok {
+				return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to float64", c))
+			}
+			set[v] = struct{}{}
+		}
+		return func(row int) bool { _, ok := set[data[row]]; return ok }, nil
+
+	case StringType:
+		data := series.Data.([]string)
+		set := make(map[string]struct{}, len(candidates))
+		for _, c := range candidates {
+			v, ok := c.(string)
+			if !ok {
+				return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to string", c))
+			}
+			set[v] = struct{}{}
+		}
+		return func(row int) bool { _, ok := set[data[row]]; return ok }, nil
+
+	case BoolType:
+		data := series.Data.([]bool)
+		var set [2]bool
+		for _, c := range candidates {
+			v, ok := c.(bool)
+			if !ok {
+				return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to bool", c))
+			}
+			if v {
+				set[1] = true
+			} else {
+				set[0] = true
+			}
+		}
+		return func(row int) bool {
+			if data[row] {
+				return set[1]
+			}
+			return set[0]
+		}, nil
+
+	case TimeType:
+		data := series.Data.([]time.Time)
+		// Keyed in UTC so instants compare like time.Equal
+		set := make(map[time.Time]struct{}, len(candidates))
+		for _, c := range candidates {
+			v, ok := c.(time.Time)
+			if !ok {
+				return nil, newOpError("Filter", fmt.Sprintf("cannot convert %T to time.Time", c))
+			}
+			set[v.UTC()] = struct{}{}
+		}
+		return func(row int) bool {
+			if data[row].IsZero() {
+				return false
+			}
+			_, ok := set[data[row].UTC()]
+			return ok
+		}, nil
+	}
+
+	return nil, newOpError("Filter", "unsupported column type")
+}
+
+// inCandidates flattens the supported slice types into []any.
+func inCandidates(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case []string:
+		return boxSlice(v), true
+	case []int64:
+		return boxSlice(v), true
+	case []int:
+		return boxSlice(v), true
+	case []float64:
+		return boxSlice(v), true
+	case []bool:
+		return boxSlice(v), true
+	case []time.Time:
+		return boxSlice(v), true
+	}
+	return nil, false
+}
+
+// boxSlice converts a typed slice to []any.
+func boxSlice[T any](values []T) []any {
+	boxed := make([]any, len(values))
+	for i, v := range values {
+		boxed[i] = v
+	}
+	return boxed
+}
+
 // Select creates a new DataFrame with only the specified columns
 func (df *DataFrame) Select(columns ...string) *DataFrame {
 	if df.err != nil {
@@ -258,7 +529,7 @@ func (df *DataFrame) Select(columns ...string) *DataFrame {
 	}
 
 	if err := df.validateColumnsExist(columns); err != nil {
-		return df.setError(err)
+		return df.setOpError("Select", err)
 	}
 
 	seen := make(map[string]bool, len(columns))
@@ -271,13 +542,18 @@ func (df *DataFrame) Select(columns ...string) *DataFrame {
 
 	newDf := NewDataFrame()
 	newDf.length = df.length
+	newDf.index = df.copyIndex()
+	newDf.warnings = df.warnings
 
 	// Add selected columns in the order specified
 	for _, colName := range columns {
-		series := df.columns[colName].Copy()
+		series := df.columns[colName].share()
 		if err := newDf.addSeriesUnsafe(series); err != nil {
 			return df.setError(wrapColumnError("Select", colName, err))
 		}
+		if idx, ok := df.hashIndexes[colName]; ok {
+			newDf.hashIndexes[colName] = idx
+		}
 	}
 
 	return newDf
@@ -295,7 +571,7 @@ func (df *DataFrame) Drop(columns ...string) *DataFrame {
 
 	// Validate all columns exist
 	if err := df.validateColumnsExist(columns); err != nil {
-		return df.setError(err)
+		return df.setOpError("Drop", err)
 	}
 
 	// Create set of columns to drop for O(1) lookup
@@ -324,8 +600,33 @@ func (df *DataFrame) Sort(column string, ascending bool) *DataFrame {
 	return df.SortBy([]string{column}, []bool{ascending})
 }
 
-// SortBy creates a new DataFrame sorted by multiple columns
+// SortStable sorts by column like Sort, spelling out that rows with equal
+// keys keep their current relative order. Sort and SortBy already
+// guarantee this; SortStable makes the intent explicit when re-sorting
+// already ordered output:
+//
+//	byRegion := df.Sort("sales", false).SortStable("region", true)
+//	// regions ascending, sales still descending within each region
+func (df *DataFrame) SortStable(column string, ascending bool) *DataFrame {
+	return df.SortBy([]string{column}, []bool{ascending})
+}
+
+// SortBy creates a new DataFrame sorted by multiple columns. The sort is
+// stable: rows with equal keys keep their current relative order. Missing
+// values (NaN, zero times) sort last; see SortByWithOptions.
 func (df *DataFrame) SortBy(columns []string, ascending []bool) *DataFrame {
+	return df.SortByWithOptions(columns, ascending, SortOptions{})
+}
+
+// SortByWithOptions is SortBy with options controlling where missing
+// values land and how strings compare
+func (df *DataFrame) SortByWithOptions(columns []string, ascending []bool, options SortOptions) *DataFrame {
+	return df.sortBy(nil, columns, ascending, options)
+}
+
+// sortBy implements SortByWithOptions and SortByContext. A nil ctx is never
+// done.
+func (df *DataFrame) sortBy(ctx context.Context, columns []string, ascending []bool, options SortOptions) *DataFrame {
 	if df.err != nil {
 		return df
 	}
@@ -339,11 +640,11 @@ func (df *DataFrame) SortBy(columns []string, ascending []bool) *DataFrame {
 	}
 
 	if err := df.validateColumnsExist(columns); err != nil {
-		return df.setError(err)
+		return df.setOpError("SortBy", err)
 	}
 
 	if err := df.validateNotEmpty(); err != nil {
-		return df.setError(err)
+		return df.setOpError("SortBy", err)
 	}
 
 	// Create index array to sort
@@ -356,7 +657,7 @@ func (df *DataFrame) SortBy(columns []string, ascending []bool) *DataFrame {
 	// touches typed slices directly instead of boxing values through Get.
 	comparators := make([]func(a, b int) int, len(columns))
 	for k, colName := range columns {
-		cmp := typedComparator(df.columns[colName])
+		cmp := sortComparator(df.columns[colName], ascending[k], options)
 		if cmp == nil {
 			return df.setError(newColumnError("SortBy", colName, "unsupported column type for sorting"))
 		}
@@ -366,26 +667,66 @@ func (df *DataFrame) SortBy(columns []string, ascending []bool) *DataFrame {
 	// Sort indices based on column values. Ties break on the original row
 	// index, which makes the comparison a strict total order — the result is
 	// identical to a stable sort while keeping the faster unstable algorithm.
-	sort.Slice(indices, func(i, j int) bool {
-		rowI, rowJ := indices[i], indices[j]
-
+	less := func(rowI, rowJ int) bool {
 		// Compare by each column in order
-		for k, compare := range comparators {
-			cmp := compare(rowI, rowJ)
-			if cmp != 0 {
-				if ascending[k] {
-					return cmp < 0
-				}
-				return cmp > 0
+		for _, compare := range comparators {
+			if cmp := compare(rowI, rowJ); cmp != 0 {
+				return cmp < 0
 			}
 		}
 		return rowI < rowJ // Equal keys: preserve original row order
-	})
+	}
+	if ctx != nil {
+		var stop func() bool
+		less, stop = cancellableLess(ctx, less)
+		defer stop()
+	}
+	if options.Progress != nil {
+		options.Progress(0, df.length)
+	}
+	if df.length <= smallFrameRows {
+		insertionSortRows(indices, less)
+	} else if keys := singleRadixKeys(df, columns, ascending); keys != nil {
+		indices = radixSortRows(keys) // a single numeric key sorts in linear time
+	} else if workers := parallelWorkers(df.length); workers > 1 {
+		parallelSortRows(indices, less, workers)
+	} else {
+		sortIndices(indices, less)
+	}
+	if err := contextError("SortBy", ctx); err != nil {
+		return df.setError(err)
+	}
+	reportDone(options.Progress, df.length)
 
 	// Create new DataFrame with sorted rows
 	return df.selectRows(indices, "SortBy")
 }
 
+// sortIndices sorts row indices in place by less, which must be a strict
+// total order over distinct rows. slices.SortFunc avoids the reflection
+// swapper that sort.Slice goes through.
+func sortIndices(indices []int, less func(a, b int) bool) {
+	slices.SortFunc(indices, func(a, b int) int {
+		if less(a, b) {
+			return -1
+		}
+		return 1
+	})
+}
+
+// insertionSortRows sorts row indices in place. For small inputs it beats
+// sort.Slice, which allocates and swaps through reflection.
+func insertionSortRows(indices []int, less func(a, b int) bool) {
+	for i := 1; i < len(indices); i++ {
+		row := indices[i]
+		j := i
+		for ; j > 0 && less(row, indices[j-1]); j-- {
+			indices[j] = indices[j-1]
+		}
+		indices[j] = row
+	}
+}
+
 // uniqueFromSeries extracts unique values from a series.
 func uniqueFromSeries(series *Series) []any {
 	switch series.Type {
@@ -507,16 +848,20 @@ func (df *DataFrame) Where(column, operator string, value any) *DataFrame {
 	return df.Filter(column, operator, value)
 }
 
-// Query applies a simple query string to filter the DataFrame
+// Query applies a simple query string to filter the DataFrame, e.g.
+// "age > 25" or "name == 'John Smith'". The operator is a comparison
+// ("==", "!=", "<>", ">", ">=", "<", "<="), or for a string column one of
+// Filter's string operators such as "contains". When the query cannot be
+// used, the error's Token and Offset point at the part to fix.
 func (df *DataFrame) Query(query string) *DataFrame {
 	if df.err != nil {
 		return df
 	}
 
 	// Parse simple queries like "age > 25" or "name == 'John Smith'"
-	parts := strings.Fields(query)
+	parts, offsets := queryFields(query)
 	if len(parts) < 3 {
-		return df.setError(newOpError("Query", "query must be in format 'column operator value'"))
+		return df.setError(newSyntaxError("Query", len(query), "", "query must be in format 'column operator value'"))
 	}
 
 	column := parts[0]
@@ -533,30 +878,98 @@ func (df *DataFrame) Query(query string) *DataFrame {
 
 	// Convert value to appropriate type based on column type
 	if !df.HasColumn(column) {
-		return df.setError(newColumnError("Query", column, "column does not exist"))
+		return df.setError(&OtterError{Op: "Query", Column: column, Message: "column does not exist", Row: -1,
+			Token: column, Offset: offsets[0]})
 	}
 
 	columnType, _ := df.GetColumnType(column)
+	if !queryOperators[operator] && !(columnType == StringType && queryStringOperators[operator]) {
+		return df.setError(&OtterError{Op: "Query", Column: column, Row: -1,
+			Message: fmt.Sprintf("unsupported operator %q for %s column", operator, columnType),
+			Token:   operator, Offset: offsets[1]})
+	}
+
+	if StrictConversions && valueStr == "" && columnType != StringType && columnType != TimeType {
+		return df.setError(&OtterError{Op: "Query", Column: column, Row: -1,
+			Message: fmt.Sprintf("empty value for %s column (StrictConversions)", columnType),
+			Token:   strings.TrimSpace(query[offsets[2]:]), Offset: offsets[2]})
+	}
 	value, err := ConvertValue(valueStr, columnType)
 	if err != nil {
-		return df.setError(wrapColumnError("Query", column, err))
+		return df.setError(&OtterError{Op: "Query", Column: column, Message: err.Error(), Cause: err, Row: -1,
+			Token: strings.TrimSpace(query[offsets[2]:]), Offset: offsets[2]})
 	}
 
 	return df.Filter(column, operator, value)
 }
 
-// Reset index (currently a no-op, but maintains Pandas compatibility)
+// queryOperators are the Query operators for every column type;
+// queryStringOperators are the extra ones for string columns.
+var (
+	queryOperators = map[string]bool{
+		"==": true, "=": true, "!=": true, "<>": true, ">": true, ">=": true, "<": true, "<=": true,
+	}
+	queryStringOperators = map[string]bool{
+		"contains": true, "startswith": true, "endswith": true,
+		"iequals": true, "icontains": true, "istartswith": true, "iendswith": true,
+	}
+)
+
+// queryFields splits query around whitespace as strings.Fields does, also
+// returning the byte offset of each field.
+func queryFields(query string) (fields []string, offsets []int) {
+	start := -1
+	for i, r := range query {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			fields, offsets = append(fields, query[start:i]), append(offsets, start)
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields, offsets = append(fields, query[start:]), append(offsets, start)
+	}
+	return fields, offsets
+}
+
+// ResetIndex returns a copy of the DataFrame with its row index (see
+// SetIndex and WithRowIndex) moved back in as the first columns. As in
+// pandas, a frame without an index gets its row positions 0..n-1 as an
+// int64 "index" column, or "level_0" if "index" is taken.
 func (df *DataFrame) ResetIndex() *DataFrame {
 	if df.err != nil {
 		return df
 	}
-	return df.Copy()
+	if df.index == nil {
+		indexed := df.WithRowIndex()
+		if df.HasColumn("index") {
+			indexed.index[0].Name = "level_0"
+		}
+		return indexed.ResetIndex()
+	}
+
+	newDf := df.Copy()
+	newDf.index = nil
+	names := make([]string, len(df.index))
+	for i, level := range df.index {
+		if df.HasColumn(level.Name) {
+			return df.setError(newColumnError("ResetIndex", level.Name, "column already exists"))
+		}
+		newDf.columns[level.Name] = level.share()
+		names[i] = level.Name
+	}
+	newDf.order = append(names, newDf.order...)
+	return newDf
 }
 
 // GroupBy represents a grouped DataFrame for aggregation operations
 type GroupBy struct {
 	df      *DataFrame
 	columns []string
+	options GroupByOptions
+	ctx     context.Context // Checked while aggregating (nil = never done)
 	err     error
 }
 
@@ -585,6 +998,21 @@ func (gb *GroupBy) Max() (*DataFrame, error) {
 	return gb.aggregate("max")
 }
 
+// Median calculates the median for each group
+func (gb *GroupBy) Median() (*DataFrame, error) {
+	return gb.aggregate("median")
+}
+
+// Std calculates the sample standard deviation for each group
+func (gb *GroupBy) Std() (*DataFrame, error) {
+	return gb.aggregate("std")
+}
+
+// Var calculates the sample variance for each group
+func (gb *GroupBy) Var() (*DataFrame, error) {
+	return gb.aggregate("var")
+}
+
 // Internal helper methods
 
 // selectSeriesRows extracts rows at indices from a series, returning new data slice.
@@ -663,10 +1091,13 @@ func emptySliceForType(colType ColumnType) any {
 	}
 }
 
-// selectRows creates a new DataFrame with rows at the specified indices
+// selectRows creates a new DataFrame with rows at the specified indices;
+// nil indices select no rows.
 func (df *DataFrame) selectRows(indices []int, operation string) *DataFrame {
 	if len(indices) == 0 {
 		newDf := NewDataFrame()
+		newDf.index = df.indexRows(indices)
+		newDf.warnings = df.warnings
 		for _, colName := range df.order {
 			series := df.columns[colName]
 			newSeries, err := newSeriesOwned(series.Name, emptySliceForType(series.Type))
@@ -680,6 +1111,8 @@ func (df *DataFrame) selectRows(indices []int, operation string) *DataFrame {
 
 	newDf := NewDataFrame()
 	newDf.length = len(indices)
+	newDf.index = df.indexRows(indices)
+	newDf.warnings = df.warnings
 
 	for _, colName := range df.order {
 		series := df.columns[colName]
@@ -734,6 +1167,44 @@ func compareStrings(a, b string) int {
 	return 0
 }
 
+// compareNatural compares strings with runs of ASCII digits ordered by
+// numeric value ("file2" < "file10"). Strings that differ only in leading
+// zeros fall back to byte order so the order stays total.
+func compareNatural(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			si, sj := i, j
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+			na := strings.TrimLeft(a[si:i], "0")
+			nb := strings.TrimLeft(b[sj:j], "0")
+			if len(na) != len(nb) {
+				return compareInt64(int64(len(na)), int64(len(nb)))
+			}
+			if cmp := strings.Compare(na, nb); cmp != 0 {
+				return cmp
+			}
+			continue
+		}
+		if a[i] != b[j] {
+			return compareInt64(int64(a[i]), int64(b[j]))
+		}
+		i++
+		j++
+	}
+	if cmp := compareInt64(int64(len(a)-i), int64(len(b)-j)); cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
 func compareInt64(a, b int64) int {
 	if a < b {
 		return -1
@@ -801,22 +1272,52 @@ type groupKey struct {
 	indices []int
 }
 
-// buildGroups creates group map from DataFrame rows.
-func (gb *GroupBy) buildGroups() map[string]*groupKey {
-	groups := make(map[string]*groupKey)
+// smallFrameRows is the row count up to which operations take simpler
+// code paths. At that size fixed costs such as hashing, maps and the
+// generic sort machinery outweigh the work itself.
+const smallFrameRows = 64
 
+// buildGroups groups the DataFrame rows by the group columns, returning the
+// groups in order of first appearance.
+func (gb *GroupBy) buildGroups() []*groupKey {
 	// Pre-cache series pointers for grouping columns
 	groupSeries := make([]*Series, len(gb.columns))
 	for j, col := range gb.columns {
 		groupSeries[j] = gb.df.columns[col]
 	}
 
+	if gb.df.length <= smallFrameRows {
+		return buildGroupsLinear(groupSeries, gb.df.length)
+	}
+
+	if len(groupSeries) == 1 {
+		if dict := groupSeries[0].dictionary(); dict != nil {
+			return dict.groups()
+		}
+	}
+
+	if workers := parallelWorkers(gb.df.length); workers > 1 {
+		return buildGroupsParallel(groupSeries, gb.df.length, workers)
+	}
+
+	groups, _ := buildGroupsHashed(groupSeries, 0, gb.df.length)
+	return groups
+}
+
+// buildGroupsHashed groups rows start to end (exclusive) through a map on
+// an encoded key, returning the groups in order of first appearance along
+// with each group's key.
+func buildGroupsHashed(groupSeries []*Series, start, end int) ([]*groupKey, []string) {
+	var groups []*groupKey
+	var keys []string
+	lookup := make(map[string]*groupKey)
+
 	var key strings.Builder
 	key.Grow(64)
+	values := make([]string, len(groupSeries))
 
-	for i := 0; i < gb.df.length; i++ {
+	for i := start; i < end; i++ {
 		key.Reset()
-		values := make([]string, len(gb.columns))
 		for j, series := range groupSeries {
 			if j > 0 {
 				key.WriteByte(0)
@@ -828,10 +1329,79 @@ func (gb *GroupBy) buildGroups() map[string]*groupKey {
 			key.WriteString(part)
 		}
 		k := key.String()
-		if _, exists := groups[k]; !exists {
-			groups[k] = &groupKey{values: values}
+		g, exists := lookup[k]
+		if !exists {
+			g = &groupKey{values: slices.Clone(values)}
+			lookup[k] = g
+			groups = append(groups, g)
+			keys = append(keys, k)
+		}
+		g.indices = append(g.indices, i)
+	}
+	return groups, keys
+}
+
+// buildGroupsParallel splits the rows into one contiguous chunk per worker,
+// groups the chunks concurrently and merges the partial groups in chunk
+// order, so groups and their row indices come out exactly as
+// buildGroupsHashed would return them.
+func buildGroupsParallel(groupSeries []*Series, length, workers int) []*groupKey {
+	type partial struct {
+		groups []*groupKey
+		keys   []string
+	}
+	partials := make([]partial, workers)
+
+	var wg sync.WaitGroup
+	for w, r := range chunkRanges(length, workers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			groups, keys := buildGroupsHashed(groupSeries, r[0], r[1])
+			partials[w] = partial{groups, keys}
+		}()
+	}
+	wg.Wait()
+
+	var groups []*groupKey
+	lookup := make(map[string]*groupKey)
+	for _, p := range partials {
+		for i, g := range p.groups {
+			merged, exists := lookup[p.keys[i]]
+			if !exists {
+				lookup[p.keys[i]] = g
+				groups = append(groups, g)
+				continue
+			}
+			merged.indices = append(merged.indices, g.indices...)
+		}
+	}
+	return groups
+}
+
+// buildGroupsLinear groups a small number of rows by comparing each row
+// against the groups found so far, avoiding the key encoding and map.
+func buildGroupsLinear(groupSeries []*Series, length int) []*groupKey {
+	var groups []*groupKey
+	values := make([]string, len(groupSeries))
+
+	for i := 0; i < length; i++ {
+		for j, series := range groupSeries {
+			values[j] = seriesValueToString(series, i)
+		}
+
+		var found *groupKey
+		for _, g := range groups {
+			if slices.Equal(g.values, values) {
+				found = g
+				break
+			}
 		}
-		groups[k].indices = append(groups[k].indices, i)
+		if found == nil {
+			found = &groupKey{values: slices.Clone(values)}
+			groups = append(groups, found)
+		}
+		found.indices = append(found.indices, i)
 	}
 	return groups
 }
@@ -843,60 +1413,71 @@ func (gb *GroupBy) aggregate(operation string) (*DataFrame, error) {
 	}
 
 	groups := gb.buildGroups()
-	sortedKeys := sortGroupKeys(groups)
-	numGroups := len(sortedKeys)
+	if err := contextError("GroupBy", gb.ctx); err != nil {
+		return nil, err
+	}
+	gb.sortGroups(groups)
 
-	groupColData := allocateGroupColumns(gb.columns, numGroups)
+	keys, err := gb.keyColumns(groups)
+	if err != nil {
+		return nil, err
+	}
 
 	// Count is the size of each group, independent of any numeric columns.
 	if operation == "count" {
-		counts := make([]int64, 0, numGroups)
-		for _, k := range sortedKeys {
-			g := groups[k]
-			for j := range gb.columns {
-				groupColData[j] = append(groupColData[j], g.values[j])
-			}
-			counts = append(counts, int64(len(g.indices)))
+		counts := make([]int64, len(groups))
+		for i, g := range groups {
+			counts[i] = int64(len(g.indices))
 		}
-		return buildCountDataFrame(gb.columns, groupColData, counts)
+		return buildCountDataFrame(keys, counts)
 	}
 
-	numericCols := identifyNumericColumns(gb.df, gb.columns, numGroups)
+	numericCols := identifyNumericColumns(gb.df, gb.columns, len(groups))
 
-	if err := processGroups(gb, groups, sortedKeys, groupColData, numericCols, operation); err != nil {
+	if err := processGroups(gb, groups, numericCols, operation); err != nil {
 		return nil, err
 	}
 
-	return buildResultDataFrame(gb.columns, groupColData, numericCols)
+	return gb.buildResultDataFrame(groups, keys, numericCols, operation)
 }
 
-// sortGroupKeys orders groups by their actual column values, not by the
-// internal length-prefixed key encoding (which would sort "East" before
-// "North" but also "Phone" before "Laptop", by key length first).
-func sortGroupKeys(groups map[string]*groupKey) []string {
-	sortedKeys := make([]string, 0, len(groups))
-	for k := range groups {
-		sortedKeys = append(sortedKeys, k)
-	}
-	sort.Slice(sortedKeys, func(i, j int) bool {
-		a := groups[sortedKeys[i]].values
-		b := groups[sortedKeys[j]].values
-		for x := range a {
-			if a[x] != b[x] {
-				return a[x] < b[x]
+// sortGroups orders groups by their key values, compared in each group
+// column's own type (so int64 keys sort numerically and times
+// chronologically), not by the internal key encoding.
+func (gb *GroupBy) sortGroups(groups []*groupKey) {
+	cmps := make([]func(a, b int) int, len(gb.columns))
+	for j, col := range gb.columns {
+		cmps[j] = typedComparator(gb.df.columns[col])
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a := groups[i].indices[0]
+		b := groups[j].indices[0]
+		for _, cmp := range cmps {
+			if c := cmp(a, b); c != 0 {
+				return c < 0
 			}
 		}
 		return false
 	})
-	return sortedKeys
 }
 
-func allocateGroupColumns(columns []string, numGroups int) [][]string {
-	groupColData := make([][]string, len(columns))
-	for j := range columns {
-		groupColData[j] = make([]string, 0, numGroups)
+// keyColumns builds the group columns of a result, one row per group, in
+// their original types. Each group's key is taken from its first row.
+func (gb *GroupBy) keyColumns(groups []*groupKey) ([]*Series, error) {
+	firstRows := make([]int, len(groups))
+	for i, g := range groups {
+		firstRows[i] = g.indices[0]
 	}
-	return groupColData
+
+	keys := make([]*Series, len(gb.columns))
+	for j, col := range gb.columns {
+		s, err := newSeriesOwned(col, selectSeriesRows(gb.df.columns[col], firstRows))
+		if err != nil {
+			return nil, wrapColumnError("GroupBy", col, err)
+		}
+		keys[j] = s
+	}
+	return keys, nil
 }
 
 type numericCol struct {
@@ -921,13 +1502,13 @@ func identifyNumericColumns(df *DataFrame, groupColumns []string, numGroups int)
 	return numericCols
 }
 
-func processGroups(gb *GroupBy, groups map[string]*groupKey, sortedKeys []string, groupColData [][]string, numericCols []numericCol, operation string) error {
-	for _, k := range sortedKeys {
-		g := groups[k]
-		for j := range gb.columns {
-			groupColData[j] = append(groupColData[j], g.values[j])
+func processGroups(gb *GroupBy, groups []*groupKey, numericCols []numericCol, operation string) error {
+	for n, g := range groups {
+		if n%checkRows == 0 {
+			if err := contextError("GroupBy", gb.ctx); err != nil {
+				return err
+			}
 		}
-
 		for i := range numericCols {
 			aggValue, err := gb.calculateAggregation(numericCols[i].name, g.indices, operation)
 			if err != nil {
@@ -941,43 +1522,48 @@ func processGroups(gb *GroupBy, groups map[string]*groupKey, sortedKeys []string
 
 // buildCountDataFrame builds the GroupBy.Count result: group columns plus a
 // "count" column holding each group's row count.
-func buildCountDataFrame(columns []string, groupColData [][]string, counts []int64) (*DataFrame, error) {
+func buildCountDataFrame(keys []*Series, counts []int64) (*DataFrame, error) {
 	countName := "count"
-	for contains(columns, countName) {
+	for slices.ContainsFunc(keys, func(s *Series) bool { return s.Name == countName }) {
 		countName += "_"
 	}
 
-	resultSeries := make([]*Series, 0, len(columns)+1)
-	for j, col := range columns {
-		s, err := newSeriesOwned(col, groupColData[j])
-		if err != nil {
-			return nil, err
-		}
-		resultSeries = append(resultSeries, s)
-	}
-
 	countSeries, err := newSeriesOwned(countName, counts)
 	if err != nil {
 		return nil, err
 	}
-	resultSeries = append(resultSeries, countSeries)
 
-	return NewDataFrameFromSeries(resultSeries...)
+	return NewDataFrameFromSeries(append(keys, countSeries)...)
 }
 
-func buildResultDataFrame(columns []string, groupColData [][]string, numericCols []numericCol) (*DataFrame, error) {
-	resultSeries := make([]*Series, 0, len(columns)+len(numericCols))
+// buildResultDataFrame assembles an aggregation result: the key columns,
+// then the other columns in frame order. Non-numeric columns are kept
+// according to the GroupBy's NonNumeric option, and value columns are
+// named by its ColumnName template.
+func (gb *GroupBy) buildResultDataFrame(groups []*groupKey, keys []*Series, numericCols []numericCol, operation string) (*DataFrame, error) {
+	resultSeries := make([]*Series, 0, len(gb.df.order))
+	resultSeries = append(resultSeries, keys...)
 
-	for j, col := range columns {
-		s, err := newSeriesOwned(col, groupColData[j])
-		if err != nil {
-			return nil, err
+	for _, col := range gb.df.order {
+		if contains(gb.columns, col) {
+			continue
+		}
+
+		var data any
+		agg := operation
+		if i := slices.IndexFunc(numericCols, func(nc numericCol) bool { return nc.name == col }); i >= 0 {
+			data = numericCols[i].data
+		} else if data = gb.nonNumericAggregate(col, groups); data != nil {
+			agg = gb.options.NonNumeric
+		} else {
+			continue
 		}
-		resultSeries = append(resultSeries, s)
-	}
 
-	for _, nc := range numericCols {
-		s, err := newSeriesOwned(nc.name, nc.data)
+		name := gb.resultColumnName(col, agg)
+		if slices.ContainsFunc(resultSeries, func(s *Series) bool { return s.Name == name }) {
+			return nil, newColumnError("GroupBy", name, "result column name used more than once")
+		}
+		s, err := newSeriesOwned(name, data)
 		if err != nil {
 			return nil, err
 		}
@@ -1023,6 +1609,12 @@ func aggregateInt64(data []int64, indices []int, operation string) (float64, err
 		return minInt64(data, indices), nil
 	case "max":
 		return maxInt64(data, indices), nil
+	case "median", "std", "var":
+		values := make([]float64, len(indices))
+		for i, idx := range indices {
+			values[i] = float64(data[idx])
+		}
+		return spreadAggregate(values, operation), nil
 	default:
 		return 0, newOpError("aggregateInt64", fmt.Sprintf("unsupported operation: %s", operation))
 	}
@@ -1074,6 +1666,8 @@ func aggregateFloat64(data []float64, indices []int, operation string) (float64,
 		return minFloat64(data, indices), nil
 	case "max":
 		return maxFloat64(data, indices), nil
+	case "median", "std", "var":
+		return spreadAggregate(selectFloat64Rows(data, indices), operation), nil
 	default:
 		return 0, newOpError("aggregateFloat64", fmt.Sprintf("unsupported operation: %s", operation))
 	}
@@ -1111,6 +1705,21 @@ func maxFloat64(data []float64, indices []int) float64 {
 	return maxVal
 }
 
+// spreadAggregate computes the aggregations that need all of a group's
+// values at once. values is sorted in place. Std and var use the sample
+// (n-1) form, so a single-row group yields NaN.
+func spreadAggregate(values []float64, operation string) float64 {
+	switch operation {
+	case "median":
+		sort.Float64s(values)
+		return quantileSorted(values, 0.5, "linear")
+	case "std":
+		return math.Sqrt(covariance(values, values))
+	default:
+		return covariance(values, values)
+	}
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	return slices.Contains(slice, item)
@@ -1,7 +1,9 @@
 package otters
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 )
@@ -639,6 +641,71 @@ func TestSortStability(t *testing.T) {
 	}
 }
 
+func TestSortStable(t *testing.T) {
+	setParallelRows(t, 100)
+	n := 1000
+	region := make([]string, n)
+	sales := make([]int64, n)
+	for i := range n {
+		region[i] = []string{"east", "west", "north"}[i*7%3]
+		sales[i] = int64(i * 37 % 101)
+	}
+	df, err := NewDataFrameFromMap(map[string]any{"region": region, "sales": sales})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ascending := range []bool{true, false} {
+		sorted := df.Sort("sales", false).SortStable("region", ascending)
+		if err := sorted.Error(); err != nil {
+			t.Fatal(err)
+		}
+		for i := 1; i < sorted.Len(); i++ {
+			r0, _ := sorted.Get(i-1, "region")
+			r1, _ := sorted.Get(i, "region")
+			s0, _ := sorted.Get(i-1, "sales")
+			s1, _ := sorted.Get(i, "sales")
+			if r0 == r1 && s0.(int64) < s1.(int64) {
+				t.Fatalf("ascending=%v: row %d breaks the prior sales order within %s", ascending, i, r1)
+			}
+		}
+	}
+}
+
+func TestSortNatural(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"file2", "file10", -1},
+		{"file10", "file10", 0},
+		{"file010", "file10", -1}, // equal numbers fall back to byte order
+		{"v1.9", "v1.10", -1},
+		{"a", "a1", -1},
+		{"b1", "a2", 1},
+		{"10", "9x", 1},
+	}
+	for _, c := range cases {
+		if got := compareNatural(c.a, c.b); got != c.want {
+			t.Errorf("compareNatural(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+
+	df, err := NewDataFrameFromMap(map[string]any{"name": []string{"file10", "file2", "file1", "File3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorted := df.SortByWithOptions([]string{"name"}, []bool{true}, SortOptions{Natural: true})
+	if err := sorted.Error(); err != nil {
+		t.Fatal(err)
+	}
+	names, _ := sorted.GetSeries("name")
+	want := []string{"File3", "file1", "file2", "file10"}
+	if got := names.Data.([]string); !slices.Equal(got, want) {
+		t.Errorf("natural sort = %v, want %v", got, want)
+	}
+}
+
 // TestGroupByKeyCollision verifies that group values containing the pipe
 // character do not cause key collisions (regression for GroupBy key bug).
 func TestGroupByKeyCollision(t *testing.T) {
@@ -847,9 +914,15 @@ func TestOpsOperations(t *testing.T) {
 	}
 	rRows, rCols := reset.Shape()
 	dfRows, dfCols := df.Shape()
-	if rRows != dfRows || rCols != dfCols {
-		t.Errorf("ResetIndex: shape changed: got (%d, %d), want (%d, %d)",
-			rRows, rCols, dfRows, dfCols)
+	if rRows != dfRows || rCols != dfCols+1 {
+		t.Errorf("ResetIndex: got shape (%d, %d), want (%d, %d)",
+			rRows, rCols, dfRows, dfCols+1)
+	}
+	if reset.Columns()[0] != "index" || reset.MustGet(dfRows-1, "index") != int64(dfRows-1) {
+		t.Errorf("ResetIndex should add row positions as the first column, got %v", reset.Columns())
+	}
+	if again := reset.ResetIndex(); again.Columns()[0] != "level_0" {
+		t.Errorf("ResetIndex with an index column taken: columns %v", again.Columns())
 	}
 }
 
@@ -894,6 +967,126 @@ func TestStringOperators(t *testing.T) {
 	}
 }
 
+func TestCaseInsensitiveStringOperators(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "city", []string{"Berlin", "BERLIN", "berlin-mitte", "Bern", "Über"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		op    string
+		value string
+		want  int
+	}{
+		{"iequals", "berlin", 2},
+		{"icontains", "LIN", 3},
+		{"istartswith", "ber", 4},
+		{"iendswith", "N", 3},
+		{"iequals", "über", 1},
+		{"==", "berlin", 0},
+	}
+	for _, c := range cases {
+		result := df.Filter("city", c.op, c.value)
+		if result.Error() != nil {
+			t.Fatalf("%s %q: %v", c.op, c.value, result.Error())
+		}
+		if result.Len() != c.want {
+			t.Errorf("%s %q: got %d rows, want %d", c.op, c.value, result.Len(), c.want)
+		}
+	}
+}
+
+func TestFilterInOperator(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "status", []string{"new", "open", "closed", "new"}),
+		mustSeries(t, "id", []int64{1, 2, 3, 4}),
+		mustSeries(t, "score", []float64{0.5, 1.5, 2.5, 3.5}),
+		mustSeries(t, "flag", []bool{true, false, true, true}),
+		mustSeries(t, "day", []time.Time{day(1), day(2), day(3), day(4)}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		column string
+		values any
+		want   int
+	}{
+		{"status", []string{"new", "open"}, 3},
+		{"id", []int64{2, 4, 9}, 2},
+		{"id", []int{1}, 1},
+		{"id", []float64{2, 2.5}, 1},
+		{"score", []any{1.5, int64(3)}, 1},
+		{"flag", []bool{false}, 1},
+		{"day", []time.Time{day(3).In(time.FixedZone("X", 3600))}, 1},
+		{"status", []string{}, 0},
+	}
+	for _, c := range cases {
+		result := df.Filter(c.column, "in", c.values)
+		if result.Error() != nil {
+			t.Fatalf("%s in %v: %v", c.column, c.values, result.Error())
+		}
+		if result.Len() != c.want {
+			t.Errorf("%s in %v: got %d rows, want %d", c.column, c.values, result.Len(), c.want)
+		}
+	}
+
+	if df.Filter("id", "in", 2).Error() == nil {
+		t.Error("expected error for non-slice value")
+	}
+	if df.Filter("id", "in", []string{"2"}).Error() == nil {
+		t.Error("expected error for mismatched candidate type")
+	}
+	if got := df.FilterAny(C("status", "in", []string{"closed"}), C("id", "==", 1)); got.Len() != 2 {
+		t.Errorf("FilterAny with in: got %d rows, want 2", got.Len())
+	}
+}
+
+func TestFilterBetweenOperator(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "qty", []int64{5, 10, 15, 20, 25}),
+		mustSeries(t, "price", []float64{9.5, 10, 15, 20, 20.5}),
+		mustSeries(t, "day", []time.Time{day(1), day(2), day(3), day(4), day(5)}),
+		mustSeries(t, "name", []string{"a", "b", "c", "d", "e"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		column string
+		bounds any
+		want   int
+	}{
+		{"price", [2]float64{10, 20}, 3},
+		{"qty", [2]int64{10, 20}, 3},
+		{"qty", [2]float64{9.5, 15.5}, 2},
+		{"qty", []int{20, 10}, 0},
+		{"day", [2]time.Time{day(2), day(4)}, 3},
+	}
+	for _, c := range cases {
+		result := df.Filter(c.column, "between", c.bounds)
+		if result.Error() != nil {
+			t.Fatalf("%s between %v: %v", c.column, c.bounds, result.Error())
+		}
+		if result.Len() != c.want {
+			t.Errorf("%s between %v: got %d rows, want %d", c.column, c.bounds, result.Len(), c.want)
+		}
+	}
+
+	if df.Filter("price", "between", []float64{1, 2, 3}).Error() == nil {
+		t.Error("expected error for three bounds")
+	}
+	if df.Filter("name", "between", [2]any{"a", "c"}).Error() == nil {
+		t.Error("expected error for string column")
+	}
+}
+
 // Benchmark basic operations
 func BenchmarkDataFrameOperations(b *testing.B) {
 	// Create test data
@@ -941,3 +1134,94 @@ func BenchmarkDataFrameOperations(b *testing.B) {
 		}
 	})
 }
+
+func TestSmallFramePathsMatchGeneralPaths(t *testing.T) {
+	size := 100
+	region := make([]string, size)
+	score := make([]int64, size)
+	for i := range region {
+		region[i] = []string{"North", "South", "East"}[i*7%3]
+		score[i] = int64(i * 37 % 11)
+	}
+	df, err := NewDataFrameFromMap(map[string]any{"region": region, "score": score})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// df is above smallFrameRows, so buildGroups takes the map path
+	gb := df.GroupBy("region", "score")
+	general := gb.buildGroups()
+	linear := buildGroupsLinear([]*Series{df.columns["region"], df.columns["score"]}, df.length)
+	if len(general) != len(linear) {
+		t.Fatalf("group counts differ: map %d, linear %d", len(general), len(linear))
+	}
+	for i := range general {
+		if !slices.Equal(general[i].values, linear[i].values) || !slices.Equal(general[i].indices, linear[i].indices) {
+			t.Fatalf("group %d differs: map %v, linear %v", i, general[i], linear[i])
+		}
+	}
+
+	small := df.Head(smallFrameRows)
+	sorted := small.SortBy([]string{"score", "region"}, []bool{false, true})
+	for i := 1; i < sorted.Len(); i++ {
+		prev, _ := sorted.Get(i-1, "score")
+		cur, _ := sorted.Get(i, "score")
+		if prev.(int64) < cur.(int64) {
+			t.Fatalf("rows %d and %d out of order: %v < %v", i-1, i, prev, cur)
+		}
+	}
+}
+
+func BenchmarkSmallFrameOperations(b *testing.B) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"region": []string{"North", "South", "East", "North", "South", "West", "East", "North"},
+		"sales":  []float64{120, 80, 95, 300, 40, 210, 75, 60},
+	})
+	if err != nil {
+		b.Fatalf("Failed to create DataFrame: %v", err)
+	}
+
+	b.Run("GroupBy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = df.GroupBy("region").Sum()
+		}
+	})
+
+	b.Run("Sort", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = df.Sort("sales", false)
+		}
+	})
+}
+
+func TestQueryDiagnostics(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", "Bob"}),
+		mustSeries(t, "age", []int64{25, 35}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := df.Query("name contains li").Len(); got != 1 {
+		t.Errorf("string operator: got %d rows, want 1", got)
+	}
+
+	for query, want := range map[string]struct {
+		token  string
+		offset int
+	}{
+		"agee > 30":      {"agee", 0},
+		"age >== 30":     {">==", 4},
+		"age contains 3": {"contains", 4},
+		"age  >  thirty": {"thirty", 8},
+		"age >":          {"", 5},
+	} {
+		var otterErr *OtterError
+		if err := df.Query(query).Error(); !errors.As(err, &otterErr) || otterErr.Token != want.token || otterErr.Offset != want.offset {
+			t.Errorf("%q: got %v, want token %q at offset %d", query, err, want.token, want.offset)
+		}
+	}
+}
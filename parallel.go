@@ -0,0 +1,163 @@
+package otters
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// ParallelRows is the frame length from which GroupBy, Filter and Sort
+// split their work across GOMAXPROCS goroutines. Below it the fixed cost of starting
+// goroutines and merging their results outweighs the gain. Set it to 0 to
+// keep all work on the calling goroutine.
+var ParallelRows = 1 << 17
+
+// parallelWorkers returns how many goroutines an operation over length
+// rows should use; 1 means run serially.
+func parallelWorkers(length int) int {
+	if ParallelRows <= 0 || length < ParallelRows {
+		return 1
+	}
+	return max(1, min(runtime.GOMAXPROCS(0), length/smallFrameRows))
+}
+
+// chunkRanges splits rows 0 to length into n contiguous [start, end)
+// ranges of near-equal size.
+func chunkRanges(length, n int) [][2]int {
+	ranges := make([][2]int, n)
+	for i := range ranges {
+		ranges[i] = [2]int{i * length / n, (i + 1) * length / n}
+	}
+	return ranges
+}
+
+// matchingValues returns the indices of the values for which match is
+// true, in ascending order. Long slices are scanned in parallel chunks.
+func matchingValues[T any](data []T, match func(v T) bool) []int {
+	return matchingRows(len(data), func(i int) bool { return match(data[i]) })
+}
+
+// matchingRows returns the rows 0 to length for which pred is true, in
+// ascending order, scanning in parallel chunks from ParallelRows rows.
+func matchingRows(length int, pred func(row int) bool) []int {
+	indices, _ := matchingRowsSkipping(length, nil, pred)
+	return indices
+}
+
+// missingRows counts the rows a filter left out for holding a missing
+// value.
+type missingRows struct {
+	first int // First row left out, -1 if none
+	count int
+}
+
+// matchingRowsSkipping is matchingRows leaving out the rows for which skip
+// (if not nil) is true, counted in the same scan.
+func matchingRowsSkipping(length int, skip, pred func(row int) bool) ([]int, missingRows) {
+	type part struct {
+		indices []int
+		skipped missingRows
+	}
+	scan := func(start, end int) part {
+		p := part{indices: make([]int, 0, (end-start)/4), skipped: missingRows{first: -1}}
+		for i := start; i < end; i++ {
+			if skip != nil && skip(i) {
+				if p.skipped.count == 0 {
+					p.skipped.first = i
+				}
+				p.skipped.count++
+			} else if pred(i) {
+				p.indices = append(p.indices, i)
+			}
+		}
+		return p
+	}
+
+	workers := parallelWorkers(length)
+	if workers == 1 {
+		p := scan(0, length)
+		return p.indices, p.skipped
+	}
+
+	parts := make([]part, workers)
+	var wg sync.WaitGroup
+	for w, r := range chunkRanges(length, workers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parts[w] = scan(r[0], r[1])
+		}()
+	}
+	wg.Wait()
+
+	indices := make([][]int, workers)
+	skipped := missingRows{first: -1}
+	for w, p := range parts {
+		indices[w] = p.indices
+		if skipped.count == 0 {
+			skipped.first = p.skipped.first
+		}
+		skipped.count += p.skipped.count
+	}
+	if merged := slices.Concat(indices...); merged != nil {
+		return merged, skipped
+	}
+	return []int{}, skipped
+}
+
+// parallelSortRows sorts row indices by less, which must be a strict total
+// order: each worker sorts one chunk, then neighbouring chunks are merged
+// pairwise, in parallel, until one run remains.
+func parallelSortRows(indices []int, less func(a, b int) bool, workers int) {
+	ranges := chunkRanges(len(indices), workers)
+
+	var wg sync.WaitGroup
+	for _, r := range ranges {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sortIndices(indices[r[0]:r[1]], less)
+		}()
+	}
+	wg.Wait()
+
+	buf := make([]int, len(indices))
+	src, dst := indices, buf
+	for len(ranges) > 1 {
+		merged := make([][2]int, 0, (len(ranges)+1)/2)
+		for i := 0; i < len(ranges); i += 2 {
+			if i+1 == len(ranges) {
+				copy(dst[ranges[i][0]:ranges[i][1]], src[ranges[i][0]:ranges[i][1]])
+				merged = append(merged, ranges[i])
+				continue
+			}
+			a, b := ranges[i], ranges[i+1]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mergeRows(dst[a[0]:b[1]], src[a[0]:a[1]], src[b[0]:b[1]], less)
+			}()
+			merged = append(merged, [2]int{a[0], b[1]})
+		}
+		wg.Wait()
+		ranges = merged
+		src, dst = dst, src
+	}
+	if &src[0] != &indices[0] {
+		copy(indices, src)
+	}
+}
+
+// mergeRows merges the sorted runs a and b into out, taking from a on ties.
+func mergeRows(out, a, b []int, less func(a, b int) bool) {
+	i, j := 0, 0
+	for k := range out {
+		if j == len(b) || (i < len(a) && !less(b[j], a[i])) {
+			out[k] = a[i]
+			i++
+		} else {
+			out[k] = b[j]
+			j++
+		}
+	}
+}
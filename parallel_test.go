@@ -0,0 +1,186 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+// setParallelRows lowers ParallelRows for one test so small frames take
+// the parallel paths.
+func setParallelRows(t testing.TB, rows int) {
+	old := ParallelRows
+	ParallelRows = rows
+	t.Cleanup(func() { ParallelRows = old })
+}
+
+func parallelTestFrame(t testing.TB, size int) *DataFrame {
+	t.Helper()
+	ids := make([]int64, size)
+	values := make([]float64, size)
+	status := make([]string, size)
+	for i := range size {
+		ids[i] = int64(i * 7919 % size)
+		values[i] = float64(i%97) * 1.5
+		status[i] = fmt.Sprintf("status_%d", (i*31)%23)
+	}
+	df, err := NewDataFrameFromMap(map[string]any{"id": ids, "value": values, "status": status})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestBuildGroupsParallel(t *testing.T) {
+	df := parallelTestFrame(t, 5000)
+	groupSeries := []*Series{df.columns["status"]}
+
+	want, _ := buildGroupsHashed(groupSeries, 0, df.length)
+	for _, workers := range []int{2, 3, 8} {
+		got := buildGroupsParallel(groupSeries, df.length, workers)
+		if len(got) != len(want) {
+			t.Fatalf("%d workers: %d groups, want %d", workers, len(got), len(want))
+		}
+		for i := range want {
+			if !slices.Equal(got[i].values, want[i].values) || !slices.Equal(got[i].indices, want[i].indices) {
+				t.Fatalf("%d workers: group %d differs from the serial result", workers, i)
+			}
+		}
+	}
+}
+
+func TestParallelGroupByMatchesSerial(t *testing.T) {
+	df := parallelTestFrame(t, 5000)
+
+	serial, err := df.GroupBy("status").Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setParallelRows(t, 100)
+	parallel, err := df.GroupBy("status").Sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parallel.Len() != serial.Len() {
+		t.Fatalf("parallel result has %d rows, serial %d", parallel.Len(), serial.Len())
+	}
+	for _, col := range []string{"status", "id", "value"} {
+		for i := 0; i < serial.Len(); i++ {
+			va, _ := serial.Get(i, col)
+			vb, _ := parallel.Get(i, col)
+			if va != vb {
+				t.Fatalf("column %s row %d: parallel %v, serial %v", col, i, vb, va)
+			}
+		}
+	}
+}
+
+func TestParallelFilterAndSortMatchSerial(t *testing.T) {
+	df := parallelTestFrame(t, 5000)
+
+	ops := map[string]func() *DataFrame{
+		"filter":    func() *DataFrame { return df.Filter("value", ">", 70.0) },
+		"filter in": func() *DataFrame { return df.Filter("status", "in", []string{"status_1", "status_5"}) },
+		"sort":      func() *DataFrame { return df.SortBy([]string{"value", "status"}, []bool{false, true}) },
+	}
+	setParallelRows(t, ParallelRows)
+	for name, op := range ops {
+		ParallelRows = 0
+		serial := op()
+		ParallelRows = 100
+		parallel := op()
+
+		if serial.Error() != nil || parallel.Error() != nil {
+			t.Fatalf("%s: errors %v, %v", name, serial.Error(), parallel.Error())
+		}
+		if !slices.Equal(parallel.columns["id"].Int64Slice(), serial.columns["id"].Int64Slice()) {
+			t.Errorf("%s: parallel rows differ from serial rows", name)
+		}
+	}
+}
+
+func TestParallelFilterCountsMissing(t *testing.T) {
+	values := make([]float64, 5000)
+	for i := range values {
+		values[i] = float64(i % 10)
+		if i%7 == 3 {
+			values[i] = math.NaN()
+		}
+	}
+	df, err := NewDataFrameFromSeries(mustSeries(t, "value", values))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setParallelRows(t, 100)
+	result := df.Filter("value", ">=", 5.0)
+	warnings := result.Warnings()
+	if len(warnings) != 1 || warnings[0].Row != 3 || warnings[0].Count != 714 {
+		t.Fatalf("warnings = %v, want 714 missing rows from row 3", warnings)
+	}
+	if result.Len() != 2144 {
+		t.Errorf("Len = %d, want 2144", result.Len())
+	}
+}
+
+func TestParallelSortRows(t *testing.T) {
+	values := []int{5, 3, 9, 3, 1, 8, 2, 7, 3, 0, 6}
+	less := func(a, b int) bool {
+		if values[a] != values[b] {
+			return values[a] < values[b]
+		}
+		return a < b
+	}
+	for _, workers := range []int{2, 3, 4} {
+		indices := rangeIndices(0, len(values))
+		parallelSortRows(indices, less, workers)
+		want := []int{9, 4, 6, 1, 3, 8, 0, 10, 7, 5, 2}
+		if !slices.Equal(indices, want) {
+			t.Errorf("%d workers: order = %v, want %v", workers, indices, want)
+		}
+	}
+}
+
+func TestParallelWorkers(t *testing.T) {
+	setParallelRows(t, 0)
+	if got := parallelWorkers(1 << 20); got != 1 {
+		t.Errorf("ParallelRows 0 should disable parallelism, got %d workers", got)
+	}
+
+	setParallelRows(t, 1000)
+	if got := parallelWorkers(999); got != 1 {
+		t.Errorf("below the threshold: got %d workers, want 1", got)
+	}
+
+	ranges := chunkRanges(10, 3)
+	if ranges[0][0] != 0 || ranges[2][1] != 10 || ranges[0][1] != ranges[1][0] || ranges[1][1] != ranges[2][0] {
+		t.Errorf("chunkRanges(10, 3) = %v, want contiguous cover of [0, 10)", ranges)
+	}
+}
+
+func BenchmarkParallelOps(b *testing.B) {
+	df := parallelTestFrame(b, 1_000_000)
+
+	for _, rows := range []int{0, 1 << 17} {
+		b.Run(fmt.Sprintf("GroupBy/ParallelRows=%d", rows), func(b *testing.B) {
+			setParallelRows(b, rows)
+			for i := 0; i < b.N; i++ {
+				_, _ = df.GroupBy("status").Sum()
+			}
+		})
+		b.Run(fmt.Sprintf("Filter/ParallelRows=%d", rows), func(b *testing.B) {
+			setParallelRows(b, rows)
+			for i := 0; i < b.N; i++ {
+				_ = df.Filter("value", ">", 70.0)
+			}
+		})
+		b.Run(fmt.Sprintf("Sort/ParallelRows=%d", rows), func(b *testing.B) {
+			setParallelRows(b, rows)
+			for i := 0; i < b.N; i++ {
+				_ = df.Sort("value", false)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package otters
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// PartitionOptions provides options for reading partitioned datasets
+type PartitionOptions struct {
+	// Filter prunes partitions before any of their files are read: it is
+	// called with the partition's key=value pairs and the partition is
+	// skipped when it returns false. nil keeps every partition.
+	Filter func(partition map[string]string) bool
+
+	// Provenance adds a "__source" column holding each row's file path
+	// (relative to the dataset directory, slash-separated) and a
+	// "__source_row" column holding the row's position within that file.
+	Provenance bool
+}
+
+// Provenance column names added by PartitionOptions.Provenance
+const (
+	SourceColumn    = "__source"
+	SourceRowColumn = "__source_row"
+)
+
+// hiveDefaultPartition is the directory value Hive writes for missing keys.
+const hiveDefaultPartition = "__HIVE_DEFAULT_PARTITION__"
+
+// partitionFile is a data file together with the partition it belongs to.
+type partitionFile struct {
+	path   string
+	keys   []string
+	values []string
+}
+
+// ReadPartitioned reads a Hive-style partitioned dataset, such as
+//
+//	sales/region=North/year=2024/part-0.csv
+//
+// Every .csv and .jsonl file below dir is read and stacked into one
+// DataFrame, with each key=value directory level added as a column (typed by
+// the same inference as CSV cells). All files must sit under the same
+// partition keys and share the same columns. Files and directories whose
+// names start with "." or "_" (like _SUCCESS markers) are ignored.
+func ReadPartitioned(dir string) (*DataFrame, error) {
+	return ReadPartitionedWithOptions(dir, PartitionOptions{})
+}
+
+// ReadPartitionedWithOptions reads a partitioned dataset with custom options
+func ReadPartitionedWithOptions(dir string, options PartitionOptions) (*DataFrame, error) {
+	files, err := discoverPartitions(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Filter != nil {
+		kept := files[:0]
+		for _, f := range files {
+			partition := make(map[string]string, len(f.keys))
+			for i, key := range f.keys {
+				partition[key] = f.values[i]
+			}
+			if options.Filter(partition) {
+				kept = append(kept, f)
+			}
+		}
+		files = kept
+	}
+
+	if len(files) == 0 {
+		return NewDataFrame(), nil
+	}
+
+	keys := files[0].keys
+	keyTypes := make([]ColumnType, len(keys))
+	for k := range keys {
+		values := make([]string, len(files))
+		for i, f := range files {
+			values[i] = f.values[k]
+		}
+		keyTypes[k] = InferType(values)
+	}
+
+	frames := make([]*DataFrame, 0, len(files))
+	for _, f := range files {
+		frame, err := readPartitionFile(f.path)
+		if err != nil {
+			return nil, err
+		}
+		if frame.Width() == 0 {
+			continue // empty file
+		}
+		for k, key := range keys {
+			if frame.HasColumn(key) {
+				return nil, newColumnError("ReadPartitioned", key,
+					fmt.Sprintf("partition key collides with a column in %s", f.path))
+			}
+			s, err := repeatedSeries(key, f.values[k], keyTypes[k], frame.length)
+			if err != nil {
+				return nil, wrapColumnError("ReadPartitioned", key, err)
+			}
+			if err := frame.addSeriesUnsafe(s); err != nil {
+				return nil, wrapColumnError("ReadPartitioned", key, err)
+			}
+		}
+		if options.Provenance {
+			if err := addProvenance(frame, dir, f.path); err != nil {
+				return nil, err
+			}
+		}
+		frames = append(frames, frame)
+	}
+
+	return concatFrames(frames, "ReadPartitioned")
+}
+
+// discoverPartitions walks dir and returns its data files in path order.
+func discoverPartitions(dir string) ([]partitionFile, error) {
+	var files []partitionFile
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+
+		name := d.Name()
+		if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if !strings.Contains(name, "=") {
+				return newOpError("ReadPartitioned",
+					fmt.Sprintf("directory %s is not a key=value partition", path))
+			}
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".csv" && ext != ".jsonl" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		f := partitionFile{path: path}
+		if rel != "." {
+			for _, part := range strings.Split(rel, string(filepath.Separator)) {
+				key, value, _ := strings.Cut(part, "=")
+				f.keys = append(f.keys, key)
+				f.values = append(f.values, unescapePartitionValue(value))
+			}
+		}
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		if _, ok := err.(*OtterError); ok {
+			return nil, err
+		}
+		return nil, wrapError("ReadPartitioned", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+
+	for _, f := range files {
+		if !slices.Equal(f.keys, files[0].keys) {
+			return nil, newOpError("ReadPartitioned",
+				fmt.Sprintf("inconsistent partition keys: %s has [%s], expected [%s]",
+					f.path, strings.Join(f.keys, ", "), strings.Join(files[0].keys, ", ")))
+		}
+	}
+
+	return files, nil
+}
+
+// unescapePartitionValue decodes a partition directory value. Hive
+// percent-encodes special characters and marks missing values with
+// __HIVE_DEFAULT_PARTITION__, which becomes an empty cell.
+func unescapePartitionValue(value string) string {
+	if value == hiveDefaultPartition {
+		return ""
+	}
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// readPartitionFile reads a single data file by extension.
+func readPartitionFile(path string) (*DataFrame, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".jsonl" {
+		return ReadJSONL(path)
+	}
+	return ReadCSV(path)
+}
+
+// addProvenance appends the source file and row position columns to a
+// frame read from path.
+func addProvenance(frame *DataFrame, dir, path string) error {
+	for _, name := range []string{SourceColumn, SourceRowColumn} {
+		if frame.HasColumn(name) {
+			return newColumnError("ReadPartitioned", name,
+				fmt.Sprintf("provenance column collides with a column in %s", path))
+		}
+	}
+
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return wrapError("ReadPartitioned", err)
+	}
+	source, err := repeatedSeries(SourceColumn, filepath.ToSlash(rel), StringType, frame.length)
+	if err != nil {
+		return wrapColumnError("ReadPartitioned", SourceColumn, err)
+	}
+
+	rows := make([]int64, frame.length)
+	for i := range rows {
+		rows[i] = int64(i)
+	}
+	sourceRow, err := newSeriesOwned(SourceRowColumn, rows)
+	if err != nil {
+		return wrapColumnError("ReadPartitioned", SourceRowColumn, err)
+	}
+
+	if err := frame.addSeriesUnsafe(source); err != nil {
+		return err
+	}
+	return frame.addSeriesUnsafe(sourceRow)
+}
+
+// repeatedSeries builds a series holding one converted value n times.
+func repeatedSeries(name, value string, colType ColumnType, n int) (*Series, error) {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = value
+	}
+	data, err := convertStringSliceToType(values, colType)
+	if err != nil {
+		return nil, err
+	}
+	return newSeriesOwned(name, data)
+}
@@ -0,0 +1,182 @@
+package otters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writePartitionFiles creates files (relative path → content) under a temp dir.
+func writePartitionFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestReadPartitioned(t *testing.T) {
+	root := writePartitionFiles(t, map[string]string{
+		"region=North/year=2023/part-0.csv": "product,amount\nA,10\nB,20\n",
+		"region=North/year=2024/part-0.csv": "product,amount\nC,30\n",
+		"region=South/year=2024/part-0.csv": "product,amount\nD,1.5\n",
+		"region=South/year=2024/_SUCCESS":   "",
+		".hidden/part-0.csv":                "ignored\n",
+	})
+
+	df, err := ReadPartitioned(root)
+	if err != nil {
+		t.Fatalf("ReadPartitioned error: %v", err)
+	}
+
+	want := []string{"product", "amount", "region", "year"}
+	got := df.Columns()
+	if len(got) != len(want) {
+		t.Fatalf("columns = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("columns = %v, want %v", got, want)
+		}
+	}
+	if df.Len() != 4 {
+		t.Fatalf("expected 4 rows, got %d", df.Len())
+	}
+
+	// int64 in one file and float64 in another combine as float64
+	if typ, _ := df.GetColumnType("amount"); typ != Float64Type {
+		t.Errorf("amount type = %v, want float64", typ)
+	}
+	if typ, _ := df.GetColumnType("year"); typ != Int64Type {
+		t.Errorf("year partition type = %v, want int64", typ)
+	}
+
+	region, _ := df.Get(3, "region")
+	year, _ := df.Get(3, "year")
+	if region != "South" || year != int64(2024) {
+		t.Errorf("last row partition = (%v, %v), want (South, 2024)", region, year)
+	}
+}
+
+func TestReadPartitionedPruning(t *testing.T) {
+	root := writePartitionFiles(t, map[string]string{
+		"region=North/part-0.csv": "amount\n10\n",
+		"region=South/part-0.csv": "amount\n20\n",
+		// Pruned partitions are never read, so a broken file there is harmless
+		"region=West/part-0.csv": "amount\n1\n2,3\n",
+	})
+
+	df, err := ReadPartitionedWithOptions(root, PartitionOptions{
+		Filter: func(p map[string]string) bool { return p["region"] != "West" },
+	})
+	if err != nil {
+		t.Fatalf("ReadPartitionedWithOptions error: %v", err)
+	}
+	if df.Len() != 2 {
+		t.Fatalf("expected 2 rows after pruning, got %d", df.Len())
+	}
+
+	empty, err := ReadPartitionedWithOptions(root, PartitionOptions{
+		Filter: func(map[string]string) bool { return false },
+	})
+	if err != nil {
+		t.Fatalf("pruning everything should not error: %v", err)
+	}
+	if !empty.IsEmpty() {
+		t.Error("pruning everything should return an empty DataFrame")
+	}
+}
+
+func TestReadPartitionedJSONLAndEscaping(t *testing.T) {
+	root := writePartitionFiles(t, map[string]string{
+		"city=New%20York/part-0.jsonl":                 `{"n": 1}` + "\n",
+		"city=__HIVE_DEFAULT_PARTITION__/part-0.jsonl": `{"n": 2}` + "\n",
+	})
+
+	df, err := ReadPartitioned(root)
+	if err != nil {
+		t.Fatalf("ReadPartitioned error: %v", err)
+	}
+	cities, _ := df.Unique("city")
+	found := map[any]bool{}
+	for _, c := range cities {
+		found[c] = true
+	}
+	if !found["New York"] || !found[""] {
+		t.Errorf("expected unescaped and default partition values, got %v", cities)
+	}
+}
+
+func TestReadPartitionedErrors(t *testing.T) {
+	notPartition := writePartitionFiles(t, map[string]string{
+		"misc/part-0.csv": "a\n1\n",
+	})
+	if _, err := ReadPartitioned(notPartition); err == nil {
+		t.Error("expected error for non key=value directory")
+	}
+
+	inconsistent := writePartitionFiles(t, map[string]string{
+		"a=1/part-0.csv":     "x\n1\n",
+		"a=2/b=3/part-0.csv": "x\n2\n",
+	})
+	if _, err := ReadPartitioned(inconsistent); err == nil {
+		t.Error("expected error for inconsistent partition keys")
+	}
+
+	collision := writePartitionFiles(t, map[string]string{
+		"x=1/part-0.csv": "x\n1\n",
+	})
+	if _, err := ReadPartitioned(collision); err == nil {
+		t.Error("expected error when a partition key collides with a column")
+	}
+
+	mismatch := writePartitionFiles(t, map[string]string{
+		"a=1/part-0.csv": "x\n1\n",
+		"a=2/part-0.csv": "y\n2\n",
+	})
+	if _, err := ReadPartitioned(mismatch); err == nil {
+		t.Error("expected error for files with different columns")
+	}
+
+	if _, err := ReadPartitioned(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestReadPartitionedProvenance(t *testing.T) {
+	root := writePartitionFiles(t, map[string]string{
+		"day=1/part-0.csv": "v\n10\n11\n",
+		"day=2/part-0.csv": "v\n20\n",
+	})
+
+	df, err := ReadPartitionedWithOptions(root, PartitionOptions{Provenance: true})
+	if err != nil {
+		t.Fatalf("ReadPartitionedWithOptions error: %v", err)
+	}
+
+	want := []string{"v", "day", SourceColumn, SourceRowColumn}
+	if got := df.Columns(); len(got) != len(want) || got[2] != want[2] || got[3] != want[3] {
+		t.Fatalf("columns = %v, want %v", got, want)
+	}
+
+	anomaly := df.Filter("v", "==", 11)
+	source, _ := anomaly.Get(0, SourceColumn)
+	row, _ := anomaly.Get(0, SourceRowColumn)
+	if source != "day=1/part-0.csv" || row != int64(1) {
+		t.Errorf("provenance = (%v, %v), want (day=1/part-0.csv, 1)", source, row)
+	}
+
+	clash := writePartitionFiles(t, map[string]string{
+		"day=1/part-0.csv": "__source\nx\n",
+	})
+	if _, err := ReadPartitionedWithOptions(clash, PartitionOptions{Provenance: true}); err == nil {
+		t.Error("expected error when a file already has a provenance column")
+	}
+}
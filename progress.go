@@ -0,0 +1,15 @@
+package otters
+
+// ProgressFunc receives progress reports from long-running operations:
+// done units of work out of total. Reports come from the goroutine running
+// the operation and end with done == total on success. Reading CSV counts
+// bytes and reports every thousand or so rows; sorting counts rows and
+// reports when it starts and finishes.
+type ProgressFunc func(done, total int)
+
+// reportDone sends the final report, if progress is set.
+func reportDone(progress ProgressFunc, total int) {
+	if progress != nil {
+		progress(total, total)
+	}
+}
@@ -0,0 +1,69 @@
+package otters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// recordProgress returns a ProgressFunc that checks reports never go
+// backwards and a pointer to the reports seen.
+func recordProgress(t *testing.T) (ProgressFunc, *[][2]int) {
+	var reports [][2]int
+	return func(done, total int) {
+		if n := len(reports); n > 0 && done < reports[n-1][0] {
+			t.Errorf("progress went backwards: %d after %d", done, reports[n-1][0])
+		}
+		reports = append(reports, [2]int{done, total})
+	}, &reports
+}
+
+func TestProgressReports(t *testing.T) {
+	var data strings.Builder
+	data.WriteString("id,group\n")
+	for i := range 5000 {
+		fmt.Fprintf(&data, "%d,g%d\n", i, i%3)
+	}
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(path, []byte(data.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	size := data.Len()
+
+	progress, reports := recordProgress(t)
+	df, err := ReadCSVWithOptions(path, CSVOptions{HasHeader: true, Delimiter: ',', Progress: progress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*reports) < 4 || (*reports)[len(*reports)-1] != [2]int{size, size} {
+		t.Errorf("ReadCSV reports = %d, last %v; want several ending at %d", len(*reports), (*reports)[len(*reports)-1], size)
+	}
+
+	progress, reports = recordProgress(t)
+	if _, err := ReadCSVFromStringWithOptions(data.String(), CSVOptions{HasHeader: true, Delimiter: ',', Progress: progress}); err != nil {
+		t.Fatal(err)
+	}
+	if last := (*reports)[len(*reports)-1]; last != [2]int{size, size} {
+		t.Errorf("ReadCSVFromString last report %v, want %d of %d", last, size, size)
+	}
+
+	progress, reports = recordProgress(t)
+	if _, err := AggregateCSV(path, []string{"group"}, []CSVAggregation{{Op: "count"}},
+		CSVOptions{HasHeader: true, Delimiter: ',', Progress: progress}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*reports) < 2 || (*reports)[len(*reports)-1] != [2]int{size, size} {
+		t.Errorf("AggregateCSV reports %v", *reports)
+	}
+
+	progress, reports = recordProgress(t)
+	sorted := df.SortByWithOptions([]string{"group"}, []bool{true}, SortOptions{Progress: progress})
+	if err := sorted.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][2]int{{0, 5000}, {5000, 5000}}; len(*reports) != 2 || (*reports)[0] != want[0] || (*reports)[1] != want[1] {
+		t.Errorf("SortBy reports %v, want %v", *reports, want)
+	}
+}
@@ -0,0 +1,118 @@
+package otters
+
+import (
+	"math"
+	"slices"
+)
+
+// singleRadixKeys returns the radix keys for a SortBy on one numeric
+// column, or nil when the sort has to compare rows.
+func singleRadixKeys(df *DataFrame, columns []string, ascending []bool) []uint64 {
+	if len(columns) != 1 {
+		return nil
+	}
+	return radixKeys(df.columns[columns[0]], ascending[0])
+}
+
+// radixKeys maps a numeric sort column to unsigned keys whose order matches
+// compareInt64/compareFloat64, so rows can be radix sorted. It returns nil
+// for other types and for float columns holding NaN, which compares equal
+// to everything and has no place in a total order.
+func radixKeys(series *Series, ascending bool) []uint64 {
+	keys := make([]uint64, series.Length)
+	switch data := series.Data.(type) {
+	case []int64:
+		for i, v := range data {
+			keys[i] = uint64(v) ^ (1 << 63)
+		}
+	case []float64:
+		for i, v := range data {
+			if math.IsNaN(v) {
+				return nil
+			}
+			if v == 0 {
+				v = 0 // -0 and +0 compare equal, so they must share a key
+			}
+			bits := math.Float64bits(v)
+			if bits>>63 == 1 {
+				keys[i] = ^bits
+			} else {
+				keys[i] = bits | 1<<63
+			}
+		}
+	default:
+		return nil
+	}
+
+	if !ascending {
+		for i := range keys {
+			keys[i] = ^keys[i]
+		}
+	}
+	return keys
+}
+
+// radixSortRows returns the row indices 0..len(keys)-1 ordered by key,
+// reusing keys as scratch space. The sort is an LSD radix sort over bytes,
+// so it is stable: equal keys keep their row order, matching SortBy's
+// tiebreak. Passes over bytes that are the same in every key are skipped.
+func radixSortRows(keys []uint64) []int {
+	n := len(keys)
+	if n == 0 {
+		return []int{}
+	}
+	rows := make([]int, n)
+	for i := range rows {
+		rows[i] = i
+	}
+	// Presorted input is common and cheaper to detect than to sort. Only a
+	// strictly descending run can be reversed without reordering ties.
+	if slices.IsSorted(keys) {
+		return rows
+	}
+	if strictlyDescending(keys) {
+		slices.Reverse(rows)
+		return rows
+	}
+
+	var counts [8][256]int
+	for _, k := range keys {
+		for b := range 8 {
+			counts[b][byte(k>>(8*b))]++
+		}
+	}
+
+	srcKeys, dstKeys := keys, make([]uint64, n)
+	srcRows, dstRows := rows, make([]int, n)
+	for b := range 8 {
+		count := &counts[b]
+		if count[byte(srcKeys[0]>>(8*b))] == n {
+			continue
+		}
+
+		offset := 0
+		for i, c := range count {
+			count[i] = offset
+			offset += c
+		}
+		shift := 8 * b
+		for i, k := range srcKeys {
+			pos := count[byte(k>>shift)]
+			count[byte(k>>shift)]++
+			dstKeys[pos] = k
+			dstRows[pos] = srcRows[i]
+		}
+		srcKeys, dstKeys = dstKeys, srcKeys
+		srcRows, dstRows = dstRows, srcRows
+	}
+	return srcRows
+}
+
+func strictlyDescending(keys []uint64) bool {
+	for i := 1; i < len(keys); i++ {
+		if keys[i] >= keys[i-1] {
+			return false
+		}
+	}
+	return true
+}
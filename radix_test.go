@@ -0,0 +1,89 @@
+package otters
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRadixSortRowsMatchesComparisonSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ints := make([]int64, 1000)
+	floats := make([]float64, 1000)
+	for i := range ints {
+		ints[i] = rng.Int63n(200) - 100
+		floats[i] = float64(rng.Intn(50)-25) / 4
+	}
+	ints[0], ints[1] = math.MinInt64, math.MaxInt64
+	floats[0], floats[1], floats[2] = math.Inf(-1), math.Inf(1), math.Copysign(0, -1)
+
+	for _, series := range []*Series{mustSeries(t, "i", ints), mustSeries(t, "f", floats)} {
+		compare := typedComparator(series)
+		for _, ascending := range []bool{true, false} {
+			want := rangeIndices(0, series.Length)
+			slices.SortStableFunc(want, func(a, b int) int {
+				if ascending {
+					return compare(a, b)
+				}
+				return compare(b, a)
+			})
+
+			got := radixSortRows(radixKeys(series, ascending))
+			if !slices.Equal(got, want) {
+				t.Errorf("%s ascending=%v: radix order differs from a stable comparison sort", series.Name, ascending)
+			}
+		}
+	}
+}
+
+func TestRadixSortRowsPresorted(t *testing.T) {
+	cases := []struct {
+		keys []uint64
+		want []int
+	}{
+		{[]uint64{1, 2, 2, 3}, []int{0, 1, 2, 3}},
+		{[]uint64{3, 2, 1}, []int{2, 1, 0}},
+		{[]uint64{3, 2, 2, 1}, []int{3, 1, 2, 0}}, // ties keep row order
+	}
+	for _, tc := range cases {
+		if got := radixSortRows(slices.Clone(tc.keys)); !slices.Equal(got, tc.want) {
+			t.Errorf("radixSortRows(%v) = %v, want %v", tc.keys, got, tc.want)
+		}
+	}
+}
+
+func TestRadixKeysUnsupported(t *testing.T) {
+	if radixKeys(mustSeries(t, "f", []float64{1, math.NaN()}), true) != nil {
+		t.Error("NaN columns should not radix sort")
+	}
+	if radixKeys(mustSeries(t, "s", []string{"a"}), true) != nil {
+		t.Error("string columns should not radix sort")
+	}
+}
+
+func BenchmarkSortBy(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	n := 100000
+	values := make([]float64, n)
+	groups := make([]int64, n)
+	for i := range values {
+		values[i] = rng.Float64()
+		groups[i] = rng.Int63n(100)
+	}
+	df, err := NewDataFrameFromMap(map[string]any{"value": values, "group": groups})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("SingleNumeric", func(b *testing.B) {
+		for range b.N {
+			df.Sort("value", true)
+		}
+	})
+	b.Run("MultiKey", func(b *testing.B) {
+		for range b.N {
+			df.SortBy([]string{"group", "value"}, []bool{true, false})
+		}
+	})
+}
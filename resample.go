@@ -0,0 +1,287 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Resampler buckets the rows of a DataFrame into fixed-width time windows
+// for aggregation, like Pandas' resample.
+type Resampler struct {
+	df     *DataFrame
+	column string
+	every  time.Duration
+	err    error
+}
+
+// Resample groups rows into fixed time windows of a TimeType column.
+// The rule is a duration such as "30s", "15m", "1h", "1d" or "1w"; any
+// time.ParseDuration string is accepted, plus the "d" (24h) and "w" (7d)
+// units. Windows are aligned to multiples of the rule since the zero time,
+// so "1d" buckets start at midnight UTC. Aggregating fails if the times
+// span more than ten million windows.
+func (df *DataFrame) Resample(column, rule string) *Resampler {
+	if df.err != nil {
+		return &Resampler{df: df, err: df.err}
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return &Resampler{df: df, err: err}
+	}
+
+	if df.columns[column].Type != TimeType {
+		return &Resampler{df: df, err: newColumnError("Resample", column, "column must be of type time")}
+	}
+
+	every, err := parseResampleRule(rule)
+	if err != nil {
+		return &Resampler{df: df, err: wrapColumnError("Resample", column, err)}
+	}
+
+	return &Resampler{df: df, column: column, every: every}
+}
+
+// Sum calculates the sum of each numeric column per window
+func (r *Resampler) Sum() (*DataFrame, error) {
+	return r.aggregate("sum")
+}
+
+// Mean calculates the average of each numeric column per window
+func (r *Resampler) Mean() (*DataFrame, error) {
+	return r.aggregate("mean")
+}
+
+// Count calculates the number of rows per window
+func (r *Resampler) Count() (*DataFrame, error) {
+	return r.aggregate("count")
+}
+
+// Min calculates the minimum of each numeric column per window
+func (r *Resampler) Min() (*DataFrame, error) {
+	return r.aggregate("min")
+}
+
+// Max calculates the maximum of each numeric column per window
+func (r *Resampler) Max() (*DataFrame, error) {
+	return r.aggregate("max")
+}
+
+// aggregate buckets rows and aggregates each window. The result holds one
+// row per window from the earliest to the latest bucket, including empty
+// windows in between: their sum and count are 0, other aggregates NaN.
+func (r *Resampler) aggregate(operation string) (*DataFrame, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if err := r.df.validateNotEmpty(); err != nil {
+		return nil, err
+	}
+
+	buckets, first, n, err := r.buildBuckets()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, newColumnError("Resample", r.column, "column holds no non-zero times")
+	}
+
+	starts := make([]time.Time, n)
+	for b := range starts {
+		starts[b] = first.Add(time.Duration(b) * r.every)
+	}
+	timeSeries, err := newSeriesOwned(r.column, starts)
+	if err != nil {
+		return nil, wrapColumnError("Resample", r.column, err)
+	}
+
+	if operation == "count" {
+		counts := make([]int64, n)
+		for b, rows := range buckets {
+			counts[b] = int64(len(rows))
+		}
+		countName := "count"
+		if countName == r.column {
+			countName += "_"
+		}
+		countSeries, err := newSeriesOwned(countName, counts)
+		if err != nil {
+			return nil, wrapError("Resample", err)
+		}
+		return NewDataFrameFromSeries(timeSeries, countSeries)
+	}
+
+	resultSeries := []*Series{timeSeries}
+	for _, colName := range r.df.order {
+		series := r.df.columns[colName]
+		if series.Type != Int64Type && series.Type != Float64Type {
+			continue
+		}
+
+		values := make([]float64, n)
+		for b, rows := range buckets {
+			if len(rows) == 0 {
+				if operation == "sum" {
+					values[b] = 0
+				} else {
+					values[b] = math.NaN()
+				}
+				continue
+			}
+
+			var v float64
+			var err error
+			if series.Type == Int64Type {
+				v, err = aggregateInt64(series.Data.([]int64), rows, operation)
+			} else {
+				v, err = aggregateFloat64(series.Data.([]float64), rows, operation)
+			}
+			if err != nil {
+				return nil, wrapColumnError("Resample", colName, err)
+			}
+			values[b] = v
+		}
+
+		s, err := newSeriesOwned(colName, values)
+		if err != nil {
+			return nil, wrapColumnError("Resample", colName, err)
+		}
+		resultSeries = append(resultSeries, s)
+	}
+
+	return NewDataFrameFromSeries(resultSeries...)
+}
+
+// maxResampleWindows caps the windows from the first to the last time, so
+// a stray far-off timestamp fails instead of allocating a window for every
+// step of the gap.
+const maxResampleWindows = 10_000_000
+
+// buildBuckets assigns every row to its window. It returns the row indices
+// per window, the start of the first window, and the number of windows.
+// Rows holding the zero time (an empty CSV cell) belong to no window.
+func (r *Resampler) buildBuckets() ([][]int, time.Time, int, error) {
+	data := r.df.columns[r.column].Data.([]time.Time)
+
+	var first, last time.Time
+	for _, t := range data {
+		if t.IsZero() {
+			continue
+		}
+		start := t.Truncate(r.every)
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if last.IsZero() || start.After(last) {
+			last = start
+		}
+	}
+	if first.IsZero() {
+		return nil, first, 0, nil
+	}
+
+	// Sub saturates beyond about 292 years, which also exceeds the limit
+	span := last.Sub(first)
+	if !first.Add(span).Equal(last) || span/r.every >= maxResampleWindows {
+		return nil, first, 0, newColumnError("Resample", r.column,
+			fmt.Sprintf("times from %s to %s span more than %d windows of %s",
+				first.Format(time.RFC3339), last.Format(time.RFC3339), maxResampleWindows, r.every))
+	}
+	n := int(span/r.every) + 1
+	buckets := make([][]int, n)
+	for i, t := range data {
+		if t.IsZero() {
+			continue
+		}
+		b := int(t.Truncate(r.every).Sub(first) / r.every)
+		buckets[b] = append(buckets[b], i)
+	}
+
+	return buckets, first, n, nil
+}
+
+// parseResampleRule parses a fixed-width window rule like "15m" or "1d".
+func parseResampleRule(rule string) (time.Duration, error) {
+	rule = strings.TrimSpace(rule)
+
+	var every time.Duration
+	switch {
+	case strings.HasSuffix(rule, "d"), strings.HasSuffix(rule, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(rule, "w") {
+			unit *= 7
+		}
+		count, err := strconv.Atoi(rule[:len(rule)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid resample rule %q", rule)
+		}
+		every = time.Duration(count) * unit
+	default:
+		d, err := time.ParseDuration(rule)
+		if err != nil {
+			return 0, fmt.Errorf("invalid resample rule %q", rule)
+		}
+		every = d
+	}
+
+	if every <= 0 {
+		return 0, fmt.Errorf("resample rule %q must be positive", rule)
+	}
+	return every, nil
+}
+
+// parseTimeBucket parses a bucket rule for GroupByTime into a function
+// mapping a time to the start of its bucket. Besides the fixed-width rules
+// of Resample it accepts the calendar units "M" (months), "Q" (quarters)
+// and "Y" (years), which start at midnight on the first day of the period
+// in the time's own location. Multi-month buckets are aligned to the year,
+// so "6M" splits each year into halves. The zero time maps to itself.
+func parseTimeBucket(rule string) (func(time.Time) time.Time, error) {
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return nil, fmt.Errorf("invalid time bucket rule %q", rule)
+	}
+
+	months := 0
+	switch rule[len(rule)-1] {
+	case 'M':
+		months = 1
+	case 'Q':
+		months = 3
+	case 'Y':
+		months = 12
+	}
+	if months == 0 {
+		every, err := parseResampleRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		return func(t time.Time) time.Time {
+			if t.IsZero() {
+				return t
+			}
+			return t.Truncate(every)
+		}, nil
+	}
+
+	count, err := strconv.Atoi(rule[:len(rule)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid time bucket rule %q", rule)
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("time bucket rule %q must be positive", rule)
+	}
+	months *= count
+
+	return func(t time.Time) time.Time {
+		if t.IsZero() {
+			return t
+		}
+		total := t.Year()*12 + int(t.Month()) - 1
+		total -= total % months
+		return time.Date(total/12, time.Month(total%12+1), 1, 0, 0, 0, 0, t.Location())
+	}, nil
+}
@@ -0,0 +1,140 @@
+package otters
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func resampleTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "ts", []time.Time{
+			base.Add(5 * time.Minute),
+			base.Add(50 * time.Minute),
+			base.Add(70 * time.Minute),
+			base.Add(190 * time.Minute), // leaves 02:00 window empty
+		}),
+		mustSeries(t, "requests", []int64{10, 20, 5, 7}),
+		mustSeries(t, "latency", []float64{1.0, 3.0, 2.0, 4.0}),
+		mustSeries(t, "host", []string{"a", "b", "a", "b"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func mustSeries(t *testing.T, name string, data any) *Series {
+	t.Helper()
+	s, err := NewSeries(name, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestResampleSum(t *testing.T) {
+	df := resampleTestFrame(t)
+
+	result, err := df.Resample("ts", "1h").Sum()
+	if err != nil {
+		t.Fatalf("Resample Sum error: %v", err)
+	}
+
+	if got := result.Columns(); len(got) != 3 || got[0] != "ts" || got[1] != "requests" || got[2] != "latency" {
+		t.Fatalf("unexpected columns: %v", got)
+	}
+	if result.Len() != 4 {
+		t.Fatalf("expected 4 windows including the empty one, got %d", result.Len())
+	}
+
+	want := []float64{30, 5, 0, 7}
+	for i, w := range want {
+		v, _ := result.Get(i, "requests")
+		if v != w {
+			t.Errorf("window %d: requests = %v, want %v", i, v, w)
+		}
+	}
+
+	start, _ := result.Get(1, "ts")
+	if !start.(time.Time).Equal(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("window 1 should start at 01:00, got %v", start)
+	}
+}
+
+func TestResampleMeanAndCount(t *testing.T) {
+	df := resampleTestFrame(t)
+
+	mean, err := df.Resample("ts", "1h").Mean()
+	if err != nil {
+		t.Fatalf("Resample Mean error: %v", err)
+	}
+	v, _ := mean.Get(0, "latency")
+	if v != 2.0 {
+		t.Errorf("window 0 mean latency = %v, want 2", v)
+	}
+	empty, _ := mean.Get(2, "latency")
+	if !math.IsNaN(empty.(float64)) {
+		t.Errorf("empty window mean should be NaN, got %v", empty)
+	}
+
+	count, err := df.Resample("ts", "1h").Count()
+	if err != nil {
+		t.Fatalf("Resample Count error: %v", err)
+	}
+	want := []int64{2, 1, 0, 1}
+	for i, w := range want {
+		v, _ := count.Get(i, "count")
+		if v != w {
+			t.Errorf("window %d: count = %v, want %v", i, v, w)
+		}
+	}
+}
+
+func TestResampleDays(t *testing.T) {
+	df := resampleTestFrame(t)
+
+	result, err := df.Resample("ts", "1d").Max()
+	if err != nil {
+		t.Fatalf("Resample Max error: %v", err)
+	}
+	if result.Len() != 1 {
+		t.Fatalf("expected a single daily window, got %d", result.Len())
+	}
+	v, _ := result.Get(0, "requests")
+	if v != 20.0 {
+		t.Errorf("daily max requests = %v, want 20", v)
+	}
+}
+
+func TestResampleErrors(t *testing.T) {
+	df := resampleTestFrame(t)
+
+	if _, err := df.Resample("requests", "1h").Sum(); err == nil {
+		t.Error("expected error resampling a non-time column")
+	}
+	if _, err := df.Resample("missing", "1h").Sum(); err == nil {
+		t.Error("expected error resampling a missing column")
+	}
+	for _, rule := range []string{"", "abc", "0h", "-1d", "xd"} {
+		if _, err := df.Resample("ts", rule).Sum(); err == nil {
+			t.Errorf("expected error for rule %q", rule)
+		}
+	}
+	// A stray timestamp far from the rest would need too many windows
+	for _, stray := range []time.Time{
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2900, 1, 1, 0, 0, 0, 0, time.UTC), // beyond what time.Sub can measure
+	} {
+		far, err := NewDataFrameFromSeries(mustSeries(t, "ts", []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), stray}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := far.Resample("ts", "1s").Count(); err == nil || !strings.Contains(err.Error(), "windows") {
+			t.Errorf("stray %v: error = %v, want one about the window count", stray.Year(), err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package otters
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"time"
+)
+
+// Row is a read-only view of a single DataFrame row, passed to row
+// callbacks such as FilterFunc and yielded by Rows. A Row reads through to
+// its frame, so it sees later Set calls on that frame.
+//
+// The typed getters (Int64, Float64, ...) return the missing value for a
+// column that does not exist or has another type, and record the failure
+// for Err, so a misspelled column surfaces instead of reading as 0.
+type Row struct {
+	df    *DataFrame
+	index int
+	err   *error // First failed typed read, shared by the rows of one Rows or FilterFunc pass
+}
+
+// Rows returns an iterator over the DataFrame's rows in order, replacing
+// index loops over Get:
+//
+//	for row := range df.Rows() {
+//		fmt.Println(row.String("name"), row.Int64("age"))
+//	}
+//
+// A DataFrame carrying an error yields no rows. A failed typed read on any
+// row is reported by the Err of every later row of the same loop.
+func (df *DataFrame) Rows() iter.Seq[Row] {
+	return func(yield func(Row) bool) {
+		if df.err != nil {
+			return
+		}
+		var err error
+		for i := range df.length {
+			if !yield(Row{df: df, index: i, err: &err}) {
+				return
+			}
+		}
+	}
+}
+
+// RowAt returns the row at the given position, such as one found by IdxMax
+func (df *DataFrame) RowAt(index int) (Row, error) {
+	if df.err != nil {
+		return Row{}, df.err
+	}
+	if err := df.validateRowIndex(index); err != nil {
+		return Row{}, err
+	}
+	return Row{df: df, index: index, err: new(error)}, nil
+}
+
+// Index returns the row's position in the DataFrame
+func (r Row) Index() int {
+	return r.index
+}
+
+// Get returns the value in the given column
+func (r Row) Get(column string) (any, error) {
+	return r.df.Get(r.index, column)
+}
+
+// Err returns the first failed typed read on the row, or on an earlier row
+// of the same Rows loop or FilterFunc pass: a column that does not exist
+// (matching ErrColumnNotFound under errors.Is) or has another type.
+//
+//	for row := range df.Rows() {
+//		total += row.Float64("amount")
+//		if err := row.Err(); err != nil {
+//			return err
+//		}
+//	}
+func (r Row) Err() error {
+	if r.err == nil {
+		return nil
+	}
+	return *r.err
+}
+
+// series returns the column if it has one of the given types, recording a
+// failure for Err otherwise.
+func (r Row) series(column string, types ...ColumnType) (*Series, bool) {
+	s, ok := r.df.columns[column]
+	if ok && slices.Contains(types, s.Type) {
+		return s, true
+	}
+	if r.err != nil && *r.err == nil {
+		if !ok {
+			*r.err = &OtterError{Op: "Row", Column: column, Row: r.index, Message: "column does not exist", Cause: ErrColumnNotFound}
+		} else {
+			*r.err = &OtterError{Op: "Row", Column: column, Row: r.index,
+				Message: fmt.Sprintf("column is %s, read as %s", s.Type, types[0])}
+		}
+	}
+	return nil, false
+}
+
+// Int64 returns the value of an Int64 column, or 0 if the column is missing
+// or has another type (see Err)
+func (r Row) Int64(column string) int64 {
+	if s, ok := r.series(column, Int64Type); ok {
+		return s.Data.([]int64)[r.index]
+	}
+	return 0
+}
+
+// Float64 returns the value of a Float64 or Int64 column as float64, or NaN
+// if the column is missing or has another type (see Err)
+func (r Row) Float64(column string) float64 {
+	s, ok := r.series(column, Float64Type, Int64Type)
+	if !ok {
+		return math.NaN()
+	}
+	if s.Type == Int64Type {
+		return float64(s.Data.([]int64)[r.index])
+	}
+	return s.Data.([]float64)[r.index]
+}
+
+// String returns the value of a String column, or "" if the column is
+// missing or has another type (see Err)
+func (r Row) String(column string) string {
+	if s, ok := r.series(column, StringType); ok {
+		return s.Data.([]string)[r.index]
+	}
+	return ""
+}
+
+// Bool returns the value of a Bool column, or false if the column is
+// missing or has another type (see Err)
+func (r Row) Bool(column string) bool {
+	if s, ok := r.series(column, BoolType); ok {
+		return s.Data.([]bool)[r.index]
+	}
+	return false
+}
+
+// Time returns the value of a Time column, or the zero time if the column
+// is missing or has another type (see Err)
+func (r Row) Time(column string) time.Time {
+	if s, ok := r.series(column, TimeType); ok {
+		return s.Data.([]time.Time)[r.index]
+	}
+	return time.Time{}
+}
@@ -0,0 +1,139 @@
+package otters
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRowGetters(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice"}),
+		mustSeries(t, "age", []int64{30}),
+		mustSeries(t, "salary", []float64{5000.5}),
+		mustSeries(t, "active", []bool{true}),
+		mustSeries(t, "joined", []time.Time{day}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := Row{df: df, index: 0}
+	if r.String("name") != "Alice" || r.Int64("age") != 30 || r.Float64("salary") != 5000.5 ||
+		!r.Bool("active") || !r.Time("joined").Equal(day) {
+		t.Error("typed getters returned unexpected values")
+	}
+	if r.Float64("age") != 30 {
+		t.Errorf("Float64 of an int64 column = %v, want 30", r.Float64("age"))
+	}
+	if r.Int64("name") != 0 || r.String("missing") != "" || !r.Time("age").IsZero() {
+		t.Error("mismatched or missing columns should return zero values")
+	}
+	if _, err := r.Get("missing"); err == nil {
+		t.Error("expected error from Get on a missing column")
+	}
+}
+
+func TestRowErr(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", "Bob"}),
+		mustSeries(t, "salary", []float64{5000, 7000}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := df.RowAt(0)
+	if r.Float64("salary") != 5000 || r.Err() != nil {
+		t.Fatalf("a good read should not fail: %v", r.Err())
+	}
+	if v := r.Float64("salry"); !math.IsNaN(v) {
+		t.Errorf("Float64 of a missing column = %v, want NaN", v)
+	}
+	if !errors.Is(r.Err(), ErrColumnNotFound) {
+		t.Errorf("Err = %v, want a missing column", r.Err())
+	}
+	r.Int64("name")
+	if oe, ok := r.Err().(*OtterError); !ok || oe.Column != "salry" {
+		t.Errorf("Err = %v, want the first failure kept", r.Err())
+	}
+
+	typed, _ := df.RowAt(1)
+	typed.Int64("name")
+	if oe, ok := typed.Err().(*OtterError); !ok || oe.Column != "name" || oe.Row != 1 {
+		t.Errorf("Err = %v, want a type mismatch on name at row 1", typed.Err())
+	}
+
+	var last Row
+	for row := range df.Rows() {
+		if row.Index() == 0 {
+			row.Time("salary")
+		}
+		last = row
+	}
+	if last.Err() == nil {
+		t.Error("a failed read should be reported by later rows of the loop")
+	}
+
+	filtered := df.FilterFunc(func(r Row) bool { return r.Float64("salry") > 6000 })
+	if !errors.Is(filtered.Error(), ErrColumnNotFound) {
+		t.Errorf("FilterFunc error = %v, want a missing column", filtered.Error())
+	}
+}
+
+func TestRows(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", "Bob", "Carol"}),
+		mustSeries(t, "age", []int64{30, 25, 41}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total int64
+	var names []string
+	for row := range df.Rows() {
+		total += row.Int64("age")
+		names = append(names, row.String("name"))
+		if row.Index() != len(names)-1 {
+			t.Errorf("row %d has Index() %d", len(names)-1, row.Index())
+		}
+	}
+	if total != 96 || len(names) != 3 || names[2] != "Carol" {
+		t.Errorf("unexpected iteration: total=%d names=%v", total, names)
+	}
+
+	seen := 0
+	for range df.Rows() {
+		seen++
+		break
+	}
+	if seen != 1 {
+		t.Errorf("break should stop iteration, saw %d rows", seen)
+	}
+
+	for range df.Select("missing").Rows() {
+		t.Error("a frame with an error should yield no rows")
+	}
+}
+
+func TestRowAt(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", "Bob"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := df.RowAt(1)
+	if err != nil || row.String("name") != "Bob" || row.Index() != 1 {
+		t.Errorf("RowAt(1) = %v (%v), want Bob", row.String("name"), err)
+	}
+	for _, i := range []int{-1, 2} {
+		if _, err := df.RowAt(i); err == nil {
+			t.Errorf("RowAt(%d): expected an error", i)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Sample returns n rows chosen at random without replacement, in the order
+// they were drawn. The same seed always draws the same rows, so subsamples
+// are reproducible.
+func (df *DataFrame) Sample(n int, seed int64) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if n < 0 || n > df.length {
+		return df.setError(newOpError("Sample",
+			fmt.Sprintf("n must be between 0 and %d, got %d", df.length, n)))
+	}
+
+	return df.selectRows(sampleIndices(df.length, n, rand.New(rand.NewSource(seed))), "Sample")
+}
+
+// SampleFrac returns a fraction of the rows, between 0 and 1, chosen at
+// random without replacement. The row count is rounded to the nearest
+// integer.
+func (df *DataFrame) SampleFrac(frac float64, seed int64) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if frac < 0 || frac > 1 || math.IsNaN(frac) {
+		return df.setError(newOpError("SampleFrac", fmt.Sprintf("frac must be between 0 and 1, got %v", frac)))
+	}
+
+	n := int(math.Round(frac * float64(df.length)))
+	return df.selectRows(sampleIndices(df.length, n, rand.New(rand.NewSource(seed))), "SampleFrac")
+}
+
+// SampleWithReplacement returns n rows chosen at random with replacement,
+// so a row can appear more than once and n may exceed the row count.
+func (df *DataFrame) SampleWithReplacement(n int, seed int64) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if n < 0 {
+		return df.setError(newOpError("SampleWithReplacement", "n must not be negative"))
+	}
+	if n > 0 && df.length == 0 {
+		return df.setError(newOpError("SampleWithReplacement", "cannot sample rows from an empty DataFrame"))
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = rng.Intn(df.length)
+	}
+	return df.selectRows(indices, "SampleWithReplacement")
+}
+
+// SampleStratified samples a fraction of the rows of each group of
+// byColumn, so every class keeps its share of the result. Each group
+// contributes round(frac * group size) rows; the result keeps the original
+// row order.
+func (df *DataFrame) SampleStratified(byColumn string, frac float64, seed int64) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if err := df.validateColumnExists(byColumn); err != nil {
+		return df.setOpError("SampleStratified", err)
+	}
+
+	if frac < 0 || frac > 1 || math.IsNaN(frac) {
+		return df.setError(newOpError("SampleStratified", fmt.Sprintf("frac must be between 0 and 1, got %v", frac)))
+	}
+
+	gb := &GroupBy{df: df, columns: []string{byColumn}}
+	rng := rand.New(rand.NewSource(seed))
+	var indices []int
+	for _, g := range gb.buildGroups() {
+		n := int(math.Round(frac * float64(len(g.indices))))
+		for _, pos := range sampleIndices(len(g.indices), n, rng) {
+			indices = append(indices, g.indices[pos])
+		}
+	}
+	slices.Sort(indices)
+
+	return df.selectRows(indices, "SampleStratified")
+}
+
+// sampleIndices draws n distinct rows out of length with a partial
+// Fisher-Yates shuffle.
+func sampleIndices(length, n int, rng *rand.Rand) []int {
+	rows := rangeIndices(0, length)
+	for i := 0; i < n; i++ {
+		j := i + rng.Intn(length-i)
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+	return rows[:n]
+}
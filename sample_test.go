@@ -0,0 +1,102 @@
+package otters
+
+import (
+	"slices"
+	"testing"
+)
+
+func sampleTestFrame(t *testing.T, n int) *DataFrame {
+	t.Helper()
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(i)
+	}
+	df, err := NewDataFrameFromSeries(mustSeries(t, "id", ids))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestSample(t *testing.T) {
+	df := sampleTestFrame(t, 100)
+
+	a := df.Sample(10, 42)
+	b := df.Sample(10, 42)
+	if a.Error() != nil {
+		t.Fatalf("Sample error: %v", a.Error())
+	}
+	ids := a.columns["id"].Int64Slice()
+	if a.Len() != 10 || !slices.Equal(ids, b.columns["id"].Int64Slice()) {
+		t.Fatalf("same seed should draw the same 10 rows, got %v and %v", ids, b.columns["id"].Int64Slice())
+	}
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	if len(slices.Compact(sorted)) != 10 {
+		t.Errorf("sample without replacement repeated rows: %v", ids)
+	}
+
+	if got := df.SampleFrac(0.25, 1).Len(); got != 25 {
+		t.Errorf("SampleFrac(0.25) kept %d rows, want 25", got)
+	}
+	if got := df.Sample(0, 1).Len(); got != 0 {
+		t.Errorf("Sample(0) kept %d rows, want 0", got)
+	}
+	if df.Sample(101, 1).Error() == nil {
+		t.Error("expected error for n larger than the frame")
+	}
+	if df.SampleFrac(1.5, 1).Error() == nil {
+		t.Error("expected error for frac above 1")
+	}
+}
+
+func TestSampleWithReplacement(t *testing.T) {
+	df := sampleTestFrame(t, 3)
+
+	result := df.SampleWithReplacement(50, 7)
+	if result.Error() != nil {
+		t.Fatalf("SampleWithReplacement error: %v", result.Error())
+	}
+	if result.Len() != 50 {
+		t.Fatalf("got %d rows, want 50", result.Len())
+	}
+	for _, id := range result.columns["id"].Int64Slice() {
+		if id < 0 || id > 2 {
+			t.Fatalf("sampled id %d not in the frame", id)
+		}
+	}
+
+	if sampleTestFrame(t, 0).SampleWithReplacement(1, 7).Error() == nil {
+		t.Error("expected error sampling from an empty frame")
+	}
+}
+
+func TestSampleStratified(t *testing.T) {
+	labels := make([]string, 100)
+	for i := range labels {
+		labels[i] = "a"
+		if i%5 == 0 {
+			labels[i] = "b" // 20% minority class
+		}
+	}
+	df, err := NewDataFrameFromSeries(mustSeries(t, "label", labels))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.SampleStratified("label", 0.5, 3)
+	if result.Error() != nil {
+		t.Fatalf("SampleStratified error: %v", result.Error())
+	}
+	counts := map[string]int{}
+	for _, v := range result.columns["label"].StringSlice() {
+		counts[v]++
+	}
+	if counts["a"] != 40 || counts["b"] != 10 {
+		t.Errorf("class counts = %v, want a:40 b:10", counts)
+	}
+
+	if df.SampleStratified("missing", 0.5, 3).Error() == nil {
+		t.Error("expected error for missing column")
+	}
+}
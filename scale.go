@@ -0,0 +1,112 @@
+package otters
+
+import (
+	"math"
+)
+
+// ZScore returns a copy of the DataFrame with numeric columns standardized
+// to (x - mean) / std, using the sample standard deviation. With no
+// columns given, every numeric column is standardized. The results are
+// float64 columns; NaN values are left out of the mean and std and stay
+// NaN, and a column with no spread becomes NaN.
+func (df *DataFrame) ZScore(columns ...string) *DataFrame {
+	return df.scaleColumns(columns, "ZScore", func(values []float64) []float64 {
+		var mean float64
+		n := 0
+		for _, v := range values {
+			if !math.IsNaN(v) {
+				mean += v
+				n++
+			}
+		}
+		mean /= float64(n)
+
+		var sumSq float64
+		for _, v := range values {
+			if !math.IsNaN(v) {
+				sumSq += (v - mean) * (v - mean)
+			}
+		}
+		std := math.NaN()
+		if n > 1 {
+			std = math.Sqrt(sumSq / float64(n-1))
+		}
+
+		out := make([]float64, len(values))
+		for i, v := range values {
+			out[i] = (v - mean) / std
+		}
+		return out
+	})
+}
+
+// Normalize returns a copy of the DataFrame with numeric columns scaled to
+// [0, 1] by (x - min) / (max - min), each using its own minimum and
+// maximum. With no columns given, every numeric column is normalized. The
+// results are float64 columns; a column with no spread becomes NaN.
+func (df *DataFrame) Normalize(columns ...string) *DataFrame {
+	return df.scaleColumns(columns, "Normalize", func(values []float64) []float64 {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, v := range values {
+			if !math.IsNaN(v) {
+				lo = math.Min(lo, v)
+				hi = math.Max(hi, v)
+			}
+		}
+		return minMaxScale(values, lo, hi)
+	})
+}
+
+// NormalizeRange is Normalize with an explicit minimum and maximum, such as
+// the range seen in training data. Values outside the range map outside
+// [0, 1].
+func (df *DataFrame) NormalizeRange(min, max float64, columns ...string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+	if !(max > min) {
+		return df.setError(newOpError("NormalizeRange", "max must be greater than min"))
+	}
+	return df.scaleColumns(columns, "NormalizeRange", func(values []float64) []float64 {
+		return minMaxScale(values, min, max)
+	})
+}
+
+// minMaxScale maps lo to 0 and hi to 1.
+func minMaxScale(values []float64, lo, hi float64) []float64 {
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = (v - lo) / (hi - lo)
+	}
+	return out
+}
+
+// scaleColumns replaces each numeric column with scale applied to its
+// values. With no columns given it applies to every numeric column.
+func (df *DataFrame) scaleColumns(columns []string, operation string, scale func(values []float64) []float64) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if len(columns) == 0 {
+		for _, colName := range df.order {
+			if isNumericType(df.columns[colName].Type) {
+				columns = append(columns, colName)
+			}
+		}
+	}
+
+	newDf := df.Copy()
+	for _, column := range columns {
+		values, err := df.numericColumn(column, operation)
+		if err != nil {
+			return df.setOpError(operation, err)
+		}
+		series, err := newSeriesOwned(column, scale(values))
+		if err != nil {
+			return df.setError(wrapColumnError(operation, column, err))
+		}
+		newDf.columns[column] = series
+	}
+	return newDf
+}
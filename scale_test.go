@@ -0,0 +1,76 @@
+package otters
+
+import (
+	"math"
+	"testing"
+)
+
+func TestZScore(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"a":    []int64{1, 2, 3},
+		"b":    []float64{10, 10, 10},
+		"name": []string{"x", "y", "z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.ZScore()
+	if result.Error() != nil {
+		t.Fatalf("ZScore error: %v", result.Error())
+	}
+	a := result.columns["a"].Float64Slice()
+	if a == nil || a[0] != -1 || a[1] != 0 || a[2] != 1 {
+		t.Errorf("ZScore(a) = %v, want [-1 0 1]", a)
+	}
+	if b := result.columns["b"].Float64Slice(); !math.IsNaN(b[0]) {
+		t.Errorf("ZScore of a constant column = %v, want NaN", b)
+	}
+	if df.columns["a"].Type != Int64Type {
+		t.Error("ZScore modified the source DataFrame")
+	}
+
+	if df.ZScore("name").Error() == nil {
+		t.Error("expected error for non-numeric column")
+	}
+	missing, err := NewDataFrameFromSeries(mustSeries(t, "c", []float64{1, math.NaN(), 2, 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := missing.ZScore().columns["c"].Float64Slice()
+	if c[0] != -1 || !math.IsNaN(c[1]) || c[2] != 0 || c[3] != 1 {
+		t.Errorf("ZScore with a NaN = %v, want [-1 NaN 0 1]", c)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"a": []int64{5, 10, 15},
+		"b": []float64{2, 2, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.Normalize("a")
+	if result.Error() != nil {
+		t.Fatalf("Normalize error: %v", result.Error())
+	}
+	if a := result.columns["a"].Float64Slice(); a[0] != 0 || a[1] != 0.5 || a[2] != 1 {
+		t.Errorf("Normalize(a) = %v, want [0 0.5 1]", a)
+	}
+	if result.columns["b"].Float64Slice()[0] != 2 {
+		t.Error("Normalize touched a column that was not listed")
+	}
+	if b := df.Normalize().columns["b"].Float64Slice(); !math.IsNaN(b[0]) {
+		t.Errorf("Normalize of a constant column = %v, want NaN", b)
+	}
+
+	ranged := df.NormalizeRange(0, 10, "a")
+	if a := ranged.columns["a"].Float64Slice(); a[0] != 0.5 || a[2] != 1.5 {
+		t.Errorf("NormalizeRange(0, 10) = %v, want [0.5 1 1.5]", a)
+	}
+	if df.NormalizeRange(1, 1).Error() == nil {
+		t.Error("expected error for empty range")
+	}
+}
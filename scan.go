@@ -0,0 +1,213 @@
+package otters
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Scanning rows into structs
+//
+// ScanRow and EachStruct fill the exported fields of a struct from a row,
+// much like database/sql's Rows.Scan. A field takes the column named by its
+// `otters:"name"` tag, or else the column whose name matches the field name
+// ignoring case; `otters:"-"` skips a field. Columns without a field and
+// fields without a column are left alone, as are fields promoted
+// through an embedded pointer.
+//
+// A field must be able to hold its column's values: string columns fill
+// string fields, bool columns bool fields, time columns time.Time fields,
+// float64 columns float fields, and int64 columns integer or float fields
+// (an integer that overflows the field is an error). A field of interface
+// type, such as any, takes any column.
+
+// scanField pairs a column with the struct field it fills.
+type scanField struct {
+	series *Series
+	index  []int // field index path, through embedded structs
+}
+
+// scanPlan maps the DataFrame's columns onto the fields of a struct type.
+func (df *DataFrame) scanPlan(op string, t reflect.Type) ([]scanField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, newOpError(op, fmt.Sprintf("destination must be a struct, got %v", t))
+	}
+
+	var plan []scanField
+	var pointers [][]int // embedded struct pointers, which may be nil
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous && field.Type.Kind() == reflect.Pointer {
+			pointers = append(pointers, field.Index)
+			continue
+		}
+		if !field.IsExported() || (field.Anonymous && field.Type.Kind() == reflect.Struct) ||
+			slices.ContainsFunc(pointers, func(p []int) bool { return slices.Equal(p, field.Index[:min(len(p), len(field.Index))]) }) {
+			continue
+		}
+		tag := field.Tag.Get("otters")
+		if tag == "-" {
+			continue
+		}
+
+		var series *Series
+		if tag != "" {
+			series = df.columns[tag]
+		} else {
+			for _, name := range df.order {
+				if strings.EqualFold(name, field.Name) {
+					series = df.columns[name]
+					break
+				}
+			}
+		}
+		if series == nil {
+			continue
+		}
+		if !scanFits(series.Type, field.Type) {
+			return nil, newColumnError(op, series.Name,
+				fmt.Sprintf("cannot scan %s column into field %s of type %v", series.Type, field.Name, field.Type))
+		}
+		plan = append(plan, scanField{series: series, index: field.Index})
+	}
+	return plan, nil
+}
+
+// scanFits reports whether a field of type t can hold values of a column of
+// type columnType.
+func scanFits(columnType ColumnType, t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return t.NumMethod() == 0
+	}
+	switch columnType {
+	case StringType:
+		return t.Kind() == reflect.String
+	case BoolType:
+		return t.Kind() == reflect.Bool
+	case TimeType:
+		return t == reflect.TypeOf(time.Time{})
+	case Float64Type:
+		return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+	case Int64Type:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+	}
+	return false
+}
+
+// scanInto fills dest, a struct value, from the given row.
+func scanInto(op string, plan []scanField, row int, dest reflect.Value) error {
+	for _, f := range plan {
+		field := dest.FieldByIndex(f.index)
+		s := f.series
+		if field.Kind() == reflect.Interface {
+			value, err := s.Get(row)
+			if err != nil {
+				return err
+			}
+			field.Set(reflect.ValueOf(value))
+			continue
+		}
+
+		switch s.Type {
+		case StringType:
+			field.SetString(s.Data.([]string)[row])
+		case BoolType:
+			field.SetBool(s.Data.([]bool)[row])
+		case TimeType:
+			field.Set(reflect.ValueOf(s.Data.([]time.Time)[row]))
+		case Float64Type:
+			field.SetFloat(s.Data.([]float64)[row])
+		case Int64Type:
+			v := s.Data.([]int64)[row]
+			overflow := false
+			switch field.Kind() {
+			case reflect.Float32, reflect.Float64:
+				field.SetFloat(float64(v))
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				overflow = v < 0 || field.OverflowUint(uint64(v))
+				if !overflow {
+					field.SetUint(uint64(v))
+				}
+			default:
+				overflow = field.OverflowInt(v)
+				if !overflow {
+					field.SetInt(v)
+				}
+			}
+			if overflow {
+				return &OtterError{
+					Op:      op,
+					Column:  s.Name,
+					Row:     row,
+					Message: fmt.Sprintf("value %d overflows %v", v, field.Type()),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ScanRow fills the struct dest points to from the given row:
+//
+//	type Employee struct {
+//		Name   string
+//		Age    int
+//		Salary float64 `otters:"annual_salary"`
+//	}
+//	var e Employee
+//	err := df.ScanRow(0, &e)
+func (df *DataFrame) ScanRow(row int, dest any) error {
+	if df.err != nil {
+		return df.err
+	}
+	if err := df.validateRowIndex(row); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return newOpError("ScanRow", fmt.Sprintf("destination must be a non-nil pointer to a struct, got %T", dest))
+	}
+	plan, err := df.scanPlan("ScanRow", v.Type().Elem())
+	if err != nil {
+		return err
+	}
+	return scanInto("ScanRow", plan, row, v.Elem())
+}
+
+// EachStruct calls fn with every row of the DataFrame scanned into a T, in
+// order, stopping at the first error. Columns are matched to T's fields once
+// up front, so this is much cheaper than calling ScanRow per row:
+//
+//	err := otters.EachStruct(df, func(e Employee) error {
+//		fmt.Println(e.Name, e.Age)
+//		return nil
+//	})
+func EachStruct[T any](df *DataFrame, fn func(T) error) error {
+	if df.err != nil {
+		return df.err
+	}
+
+	var value, zero T
+	dest := reflect.ValueOf(&value).Elem()
+	plan, err := df.scanPlan("EachStruct", dest.Type())
+	if err != nil {
+		return err
+	}
+	for i := range df.length {
+		value = zero
+		if err := scanInto("EachStruct", plan, i, dest); err != nil {
+			return err
+		}
+		if err := fn(value); err != nil {
+			return &OtterError{Op: "EachStruct", Row: i, Message: err.Error(), Cause: err}
+		}
+	}
+	return nil
+}
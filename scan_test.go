@@ -0,0 +1,101 @@
+package otters
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type scanEmployee struct {
+	Name     string
+	Age      int
+	Salary   float32 `otters:"annual_salary"`
+	Active   bool
+	Joined   time.Time
+	Extra    any    `otters:"age"`
+	Ignored  string `otters:"-"`
+	Unmapped int
+}
+
+func scanTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"Alice", "Bob"}),
+		mustSeries(t, "age", []int64{30, 25}),
+		mustSeries(t, "annual_salary", []float64{5000.5, 4200}),
+		mustSeries(t, "active", []bool{true, false}),
+		mustSeries(t, "joined", []time.Time{day, day.AddDate(0, 1, 0)}),
+		mustSeries(t, "ignored", []string{"x", "y"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestScanRow(t *testing.T) {
+	df := scanTestFrame(t)
+
+	e := scanEmployee{Unmapped: 7}
+	if err := df.ScanRow(1, &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "Bob" || e.Age != 25 || e.Salary != 4200 || e.Active || e.Joined.Month() != time.April {
+		t.Errorf("unexpected scan result: %+v", e)
+	}
+	if e.Extra != int64(25) || e.Ignored != "" || e.Unmapped != 7 {
+		t.Errorf("tags or unmapped fields handled wrongly: %+v", e)
+	}
+
+	if err := df.ScanRow(5, &e); err == nil {
+		t.Error("expected error for an out-of-range row")
+	}
+	if err := df.ScanRow(0, e); err == nil {
+		t.Error("expected error for a non-pointer destination")
+	}
+	var wrong struct{ Name int }
+	if err := df.ScanRow(0, &wrong); err == nil {
+		t.Error("expected error scanning a string column into an int field")
+	}
+
+	type Base struct{ Name string }
+	var embedded struct {
+		*Base
+		Age int
+	}
+	if err := df.ScanRow(0, &embedded); err != nil || embedded.Age != 30 {
+		t.Errorf("fields behind an embedded pointer should be skipped: %v", err)
+	}
+
+	big, _ := NewDataFrameFromSeries(mustSeries(t, "n", []int64{300}))
+	var small struct{ N int8 }
+	var oe *OtterError
+	if err := big.ScanRow(0, &small); !errors.As(err, &oe) || oe.Column != "n" || oe.Row != 0 {
+		t.Errorf("expected an overflow error, got %v", err)
+	}
+}
+
+func TestEachStruct(t *testing.T) {
+	df := scanTestFrame(t)
+
+	var names []string
+	err := EachStruct(df, func(e scanEmployee) error {
+		names = append(names, e.Name)
+		return nil
+	})
+	if err != nil || len(names) != 2 || names[0] != "Alice" {
+		t.Errorf("EachStruct visited %v, err %v", names, err)
+	}
+
+	stop := errors.New("stop")
+	err = EachStruct(df, func(e scanEmployee) error { return stop })
+	var oe *OtterError
+	if !errors.Is(err, stop) || !errors.As(err, &oe) || oe.Row != 0 {
+		t.Errorf("expected callback error at row 0, got %v", err)
+	}
+
+	if err := EachStruct(df, func(int) error { return nil }); err == nil {
+		t.Error("expected error for a non-struct type")
+	}
+}
@@ -0,0 +1,160 @@
+package otters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field describes a single column of a DataFrame
+type Field struct {
+	Name     string
+	Type     ColumnType
+	Nullable bool // The column may hold missing values (NaN, zero time)
+}
+
+// Schema is the ordered list of a DataFrame's columns and their types
+type Schema []Field
+
+// Schema returns the DataFrame's columns and their types, in column order.
+// Float64 and time columns are Nullable, since they can hold a missing
+// value, whether or not they do now; the data is not read. Clear Nullable
+// on a field to require its values to be present, in ValidateSchema or
+// CSVOptions.Schema.
+func (df *DataFrame) Schema() Schema {
+	if df.err != nil {
+		return nil
+	}
+
+	schema := make(Schema, 0, len(df.order))
+	for _, colName := range df.order {
+		series := df.columns[colName]
+		schema = append(schema, Field{Name: colName, Type: series.Type, Nullable: holdsMissingValues(series.Type)})
+	}
+	return schema
+}
+
+// ValidateSchema checks the DataFrame against an expected schema, so a
+// pipeline can fail fast when its input changes shape:
+//
+//	err := df.ValidateSchema(otters.Schema{
+//		{Name: "id", Type: otters.Int64Type},
+//		{Name: "amount", Type: otters.Float64Type, Nullable: true},
+//	})
+//
+// The DataFrame must have exactly the expected columns, in any order, with
+// the expected types, and columns not marked Nullable must hold no missing
+// values. The error lists every mismatch found.
+func (df *DataFrame) ValidateSchema(expected Schema) error {
+	if df.err != nil {
+		return df.err
+	}
+
+	changes := SchemaDiff(expected, df.Schema())
+	var problems []string
+	for _, f := range changes.Removed {
+		problems = append(problems, fmt.Sprintf("missing column %s (%s)", f.Name, f.Type))
+	}
+	for _, f := range changes.Added {
+		problems = append(problems, fmt.Sprintf("unexpected column %s (%s)", f.Name, f.Type))
+	}
+	for _, ch := range changes.Retyped {
+		problems = append(problems, fmt.Sprintf("column %s is %s, expected %s", ch.Name, ch.NewType, ch.OldType))
+	}
+	for _, f := range expected {
+		series, ok := df.columns[f.Name]
+		if !ok || f.Nullable || series.Type != f.Type {
+			continue
+		}
+		if row := firstNull(series); row >= 0 {
+			problems = append(problems, fmt.Sprintf("column %s has a missing value at row %d", f.Name, row))
+		}
+	}
+
+	if len(problems) > 0 {
+		return newOpError("ValidateSchema", "schema mismatch: "+strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// FieldChange describes a column whose type differs between two schemas
+type FieldChange struct {
+	Name    string
+	OldType ColumnType
+	NewType ColumnType
+}
+
+// SchemaChanges is the column-level difference between two schemas
+type SchemaChanges struct {
+	Added       []Field       // Columns only in the new schema, in new-schema order
+	Removed     []Field       // Columns only in the old schema, in old-schema order
+	Retyped     []FieldChange // Columns in both with different types, in new-schema order
+	Nullability []Field       // Columns in both with the same type and different Nullable, as in the new schema
+}
+
+// SchemaDiff reports which columns were added, removed, changed type or
+// changed Nullable going from oldSchema to newSchema. Columns are matched by
+// name; moving a column to a different position is not a change.
+func SchemaDiff(oldSchema, newSchema Schema) SchemaChanges {
+	oldFields := make(map[string]Field, len(oldSchema))
+	for _, f := range oldSchema {
+		oldFields[f.Name] = f
+	}
+	newTypes := make(map[string]ColumnType, len(newSchema))
+	for _, f := range newSchema {
+		newTypes[f.Name] = f.Type
+	}
+
+	var changes SchemaChanges
+	for _, f := range newSchema {
+		old, existed := oldFields[f.Name]
+		switch {
+		case !existed:
+			changes.Added = append(changes.Added, f)
+		case old.Type != f.Type:
+			changes.Retyped = append(changes.Retyped, FieldChange{Name: f.Name, OldType: old.Type, NewType: f.Type})
+		case old.Nullable != f.Nullable:
+			changes.Nullability = append(changes.Nullability, f)
+		}
+	}
+	for _, f := range oldSchema {
+		if _, kept := newTypes[f.Name]; !kept {
+			changes.Removed = append(changes.Removed, f)
+		}
+	}
+
+	return changes
+}
+
+// HasChanges returns true if the schemas differ
+func (c SchemaChanges) HasChanges() bool {
+	return len(c.Added) > 0 || len(c.Removed) > 0 || len(c.Retyped) > 0 || len(c.Nullability) > 0
+}
+
+// String returns one line per change: "+ name (type)" for added columns,
+// "- name (type)" for removed ones, "~ name: old -> new" for retyped ones,
+// and "~ name: nullable" or "~ name: not nullable" for ones that changed
+// Nullable
+func (c SchemaChanges) String() string {
+	if !c.HasChanges() {
+		return "no schema changes"
+	}
+
+	var lines []string
+	for _, f := range c.Added {
+		lines = append(lines, fmt.Sprintf("+ %s (%s)", f.Name, f.Type))
+	}
+	for _, f := range c.Removed {
+		lines = append(lines, fmt.Sprintf("- %s (%s)", f.Name, f.Type))
+	}
+	for _, ch := range c.Retyped {
+		lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", ch.Name, ch.OldType, ch.NewType))
+	}
+	for _, f := range c.Nullability {
+		if f.Nullable {
+			lines = append(lines, fmt.Sprintf("~ %s: nullable", f.Name))
+		} else {
+			lines = append(lines, fmt.Sprintf("~ %s: not nullable", f.Name))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
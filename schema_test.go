@@ -0,0 +1,138 @@
+package otters
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDataFrameSchema(t *testing.T) {
+	df, _ := ReadCSVFromString("id,name,score\n1,Alice,9.5\n")
+
+	schema := df.Schema()
+	want := Schema{
+		{Name: "id", Type: Int64Type},
+		{Name: "name", Type: StringType},
+		{Name: "score", Type: Float64Type, Nullable: true},
+	}
+	if len(schema) != len(want) {
+		t.Fatalf("Schema() = %v, want %v", schema, want)
+	}
+	for i := range want {
+		if schema[i] != want[i] {
+			t.Errorf("field %d = %v, want %v", i, schema[i], want[i])
+		}
+	}
+
+	// A frame's own schema reads back the missing values it may hold
+	options := CSVOptions{HasHeader: true, Delimiter: ',', Schema: schema}
+	if _, err := ReadCSVFromStringWithOptions("id,name,score\n2,Bob,\n", options); err != nil {
+		t.Errorf("reading a missing score with the frame's own schema: %v", err)
+	}
+
+	bad := df.Select("missing")
+	if bad.Schema() != nil {
+		t.Error("Schema() of an error DataFrame should be nil")
+	}
+}
+
+func TestSchemaDiff(t *testing.T) {
+	oldSchema := Schema{
+		{Name: "id", Type: Int64Type},
+		{Name: "legacy_code", Type: StringType},
+		{Name: "amount", Type: Int64Type},
+	}
+	newSchema := Schema{
+		{Name: "amount", Type: Float64Type},
+		{Name: "id", Type: Int64Type},
+		{Name: "region", Type: StringType},
+	}
+
+	changes := SchemaDiff(oldSchema, newSchema)
+	if !changes.HasChanges() {
+		t.Fatal("expected changes")
+	}
+	if len(changes.Added) != 1 || changes.Added[0].Name != "region" {
+		t.Errorf("Added = %v", changes.Added)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0].Name != "legacy_code" {
+		t.Errorf("Removed = %v", changes.Removed)
+	}
+	if len(changes.Retyped) != 1 || changes.Retyped[0] != (FieldChange{"amount", Int64Type, Float64Type}) {
+		t.Errorf("Retyped = %v", changes.Retyped)
+	}
+
+	want := "+ region (string)\n- legacy_code (string)\n~ amount: int64 -> float64"
+	if got := changes.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSchemaDiffNoChanges(t *testing.T) {
+	schema := Schema{{Name: "a", Type: Int64Type}, {Name: "b", Type: StringType}}
+	reordered := Schema{{Name: "b", Type: StringType}, {Name: "a", Type: Int64Type}}
+
+	changes := SchemaDiff(schema, reordered)
+	if changes.HasChanges() {
+		t.Errorf("reordering should not be a change: %v", changes)
+	}
+	if changes.String() != "no schema changes" {
+		t.Errorf("String() = %q", changes.String())
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	df, _ := NewDataFrameFromSeries(
+		mustSeries(t, "id", []int64{1, 2}),
+		mustSeries(t, "name", []string{"Alice", "Bob"}),
+		mustSeries(t, "score", []float64{9.5, math.NaN()}),
+	)
+
+	expected := Schema{
+		{Name: "name", Type: StringType},
+		{Name: "id", Type: Int64Type},
+		{Name: "score", Type: Float64Type, Nullable: true},
+	}
+	if err := df.ValidateSchema(expected); err != nil {
+		t.Errorf("matching schema rejected: %v", err)
+	}
+	if err := df.ValidateSchema(df.Schema()); err != nil {
+		t.Errorf("a frame should match its own schema: %v", err)
+	}
+	if schema := df.Schema(); schema[0].Nullable || !schema[2].Nullable {
+		t.Errorf("Schema() = %v, want only the float64 column Nullable", schema)
+	}
+
+	err := df.ValidateSchema(Schema{
+		{Name: "id", Type: StringType},
+		{Name: "score", Type: Float64Type},
+		{Name: "region", Type: StringType},
+	})
+	if err == nil {
+		t.Fatal("expected a schema mismatch")
+	}
+	for _, want := range []string{
+		"missing column region (string)",
+		"unexpected column name (string)",
+		"column id is int64, expected string",
+		"column score has a missing value at row 1",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+}
+
+func TestSchemaDiffNullability(t *testing.T) {
+	oldSchema := Schema{{Name: "score", Type: Float64Type, Nullable: true}, {Name: "at", Type: TimeType}}
+	newSchema := Schema{{Name: "score", Type: Float64Type}, {Name: "at", Type: TimeType, Nullable: true}}
+
+	changes := SchemaDiff(oldSchema, newSchema)
+	if len(changes.Nullability) != 2 || changes.Nullability[0] != newSchema[0] {
+		t.Errorf("Nullability = %v", changes.Nullability)
+	}
+	want := "~ score: not nullable\n~ at: nullable"
+	if got := changes.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
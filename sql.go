@@ -0,0 +1,809 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SQL runs a SELECT statement against the named DataFrames, e.g.
+//
+//	otters.SQL("SELECT region, SUM(sales) AS total FROM df WHERE quarter = 1 GROUP BY region",
+//		map[string]*DataFrame{"df": df})
+//
+// The supported subset is
+//
+//	SELECT * | item, ... FROM table
+//	  [WHERE condition] [GROUP BY column, ...]
+//	  [ORDER BY item [ASC|DESC], ...] [LIMIT n]
+//
+// where an item is a column or one of COUNT(*), COUNT(column), SUM, AVG,
+// MIN and MAX, optionally followed by [AS] alias. Conditions compare a
+// column with a literal using =, !=, <>, <, <=, > or >= and combine with
+// AND, OR, NOT and parentheses. Keywords are case-insensitive; identifiers
+// that clash with keywords can be quoted with "double quotes" or
+// `backticks`, and string literals use 'single quotes'.
+//
+// COUNT produces an int64 column and the other aggregates float64, as with
+// GroupBy. Over no rows COUNT and SUM are 0 and AVG, MIN and MAX are NaN,
+// the package's NULL. Grouped results keep the key columns' types and come out in
+// GroupBy order unless ORDER BY says otherwise. ORDER BY refers to output
+// names (aliases, or e.g. SUM(sales)) or, in queries without aggregates,
+// to any column of the table.
+func SQL(query string, tables map[string]*DataFrame) (*DataFrame, error) {
+	stmt, err := parseSQL(query)
+	if err != nil {
+		return nil, err
+	}
+
+	df, exists := tables[stmt.from]
+	if !exists || df == nil {
+		return nil, newOpError("SQL", fmt.Sprintf("unknown table: %s", stmt.from))
+	}
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	return stmt.execute(df)
+}
+
+// sqlSelect is a parsed SELECT statement.
+type sqlSelect struct {
+	star    bool
+	items   []sqlItem
+	from    string
+	where   sqlExpr // nil = no WHERE clause
+	groupBy []string
+	orderBy []sqlOrder
+	limit   int // -1 = no LIMIT clause
+}
+
+// sqlItem is a select-list entry: a column, or an aggregate over a column.
+type sqlItem struct {
+	fn     string // upper-case aggregate name; "" for a plain column
+	column string // "*" for COUNT(*)
+	alias  string
+}
+
+// name returns the output column name of the item.
+func (it sqlItem) name() string {
+	switch {
+	case it.alias != "":
+		return it.alias
+	case it.fn != "":
+		return it.fn + "(" + it.column + ")"
+	default:
+		return it.column
+	}
+}
+
+// sqlOrder is an ORDER BY entry.
+type sqlOrder struct {
+	item      sqlItem
+	ascending bool
+}
+
+// sqlExpr is a WHERE condition, evaluated into a row mask.
+type sqlExpr interface {
+	mask(df *DataFrame) (Mask, error)
+}
+
+type sqlCompare struct {
+	column string
+	op     string
+	value  any
+	quoted bool // value came from a string literal
+}
+
+type sqlAnd struct{ left, right sqlExpr }
+
+type sqlOr struct{ left, right sqlExpr }
+
+type sqlNot struct{ expr sqlExpr }
+
+func (c sqlCompare) mask(df *DataFrame) (Mask, error) {
+	if err := df.validateColumnExists(c.column); err != nil {
+		return nil, err
+	}
+
+	// Times are written as string literals, like in CSV data
+	value := c.value
+	if c.quoted && df.columns[c.column].Type == TimeType {
+		converted, err := ConvertValue(c.value.(string), TimeType)
+		if err != nil {
+			return nil, wrapColumnError("SQL", c.column, err)
+		}
+		value = converted
+	}
+
+	mask, err := df.Mask(c.column, c.op, value)
+	if err != nil {
+		return nil, wrapColumnError("SQL", c.column, err)
+	}
+	return mask, nil
+}
+
+func (e sqlAnd) mask(df *DataFrame) (Mask, error) {
+	left, err := e.left.mask(df)
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.right.mask(df)
+	if err != nil {
+		return nil, err
+	}
+	return left.And(right), nil
+}
+
+func (e sqlOr) mask(df *DataFrame) (Mask, error) {
+	left, err := e.left.mask(df)
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.right.mask(df)
+	if err != nil {
+		return nil, err
+	}
+	return left.Or(right), nil
+}
+
+func (e sqlNot) mask(df *DataFrame) (Mask, error) {
+	m, err := e.expr.mask(df)
+	if err != nil {
+		return nil, err
+	}
+	return m.Not(), nil
+}
+
+// Execution
+
+// execute runs the statement against its table.
+func (stmt *sqlSelect) execute(df *DataFrame) (*DataFrame, error) {
+	if stmt.where != nil {
+		mask, err := stmt.where.mask(df)
+		if err != nil {
+			return nil, err
+		}
+		df = df.FilterByMask(mask)
+		if df.err != nil {
+			return nil, wrapError("SQL", df.err)
+		}
+	}
+
+	var result *DataFrame
+	var err error
+	if stmt.isAggregate() {
+		result, err = stmt.aggregate(df)
+	} else {
+		result, err = stmt.project(df)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.limit >= 0 && stmt.limit < result.length {
+		result = result.selectRows(rangeIndices(0, stmt.limit), "SQL")
+	}
+	return result, result.err
+}
+
+// isAggregate reports whether the statement groups rows.
+func (stmt *sqlSelect) isAggregate() bool {
+	if len(stmt.groupBy) > 0 {
+		return true
+	}
+	for _, it := range stmt.items {
+		if it.fn != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// project handles statements without aggregates: sort, then pick columns.
+func (stmt *sqlSelect) project(df *DataFrame) (*DataFrame, error) {
+	if len(stmt.orderBy) > 0 && df.length > 0 {
+		columns := make([]string, len(stmt.orderBy))
+		ascending := make([]bool, len(stmt.orderBy))
+		for i, o := range stmt.orderBy {
+			if o.item.fn != "" {
+				return nil, newOpError("SQL", fmt.Sprintf("cannot order by %s without GROUP BY", o.item.name()))
+			}
+			columns[i] = stmt.sourceColumn(o.item.column)
+			ascending[i] = o.ascending
+		}
+		df = df.SortBy(columns, ascending)
+		if df.err != nil {
+			return nil, wrapError("SQL", df.err)
+		}
+	}
+
+	if stmt.star {
+		return df, nil
+	}
+
+	newDf := NewDataFrame()
+	newDf.length = df.length
+	newDf.index = df.copyIndex()
+	for _, it := range stmt.items {
+		if err := df.validateColumnExists(it.column); err != nil {
+			return nil, err
+		}
+		if newDf.HasColumn(it.name()) {
+			return nil, newColumnError("SQL", it.name(), "output column specified more than once")
+		}
+		series := df.columns[it.column].share()
+		series.Name = it.name()
+		newDf.addSeriesUnsafe(series)
+	}
+	return newDf, nil
+}
+
+// sourceColumn resolves an ORDER BY name that may be a select-list alias.
+func (stmt *sqlSelect) sourceColumn(name string) string {
+	for _, it := range stmt.items {
+		if it.alias == name && it.fn == "" {
+			return it.column
+		}
+	}
+	return name
+}
+
+// aggregate handles statements with GROUP BY or aggregates. Without GROUP
+// BY all rows form a single group.
+func (stmt *sqlSelect) aggregate(df *DataFrame) (*DataFrame, error) {
+	if stmt.star {
+		return nil, newOpError("SQL", "SELECT * cannot be combined with GROUP BY or aggregates")
+	}
+	if err := df.validateColumnsExist(stmt.groupBy); err != nil {
+		return nil, err
+	}
+	for _, it := range stmt.items {
+		if it.fn == "" && !contains(stmt.groupBy, it.column) {
+			return nil, newColumnError("SQL", it.column, "column must appear in GROUP BY or be aggregated")
+		}
+		if it.fn == "" || it.column == "*" {
+			continue
+		}
+		if err := df.validateColumnExists(it.column); err != nil {
+			return nil, err
+		}
+		if it.fn != "COUNT" && !isNumericType(df.columns[it.column].Type) {
+			return nil, newColumnError("SQL", it.column,
+				fmt.Sprintf("%s requires a numeric column", it.fn))
+		}
+	}
+
+	gb := &GroupBy{df: df, columns: stmt.groupBy}
+	var groups []*groupKey
+	if len(stmt.groupBy) > 0 {
+		groups = gb.buildGroups()
+		gb.sortGroups(groups)
+	} else {
+		groups = []*groupKey{{indices: rangeIndices(0, df.length)}}
+	}
+
+	// Key columns take their values from each group's first row. Without
+	// GROUP BY there are no key columns, and the single group may be empty.
+	var firstRows []int
+	if len(stmt.groupBy) > 0 {
+		firstRows = make([]int, len(groups))
+		for g, group := range groups {
+			firstRows[g] = group.indices[0]
+		}
+	}
+
+	series := make([]*Series, 0, len(stmt.items))
+	for _, it := range stmt.items {
+		var data any
+		switch it.fn {
+		case "":
+			data = selectSeriesRows(df.columns[it.column], firstRows)
+		case "COUNT":
+			counts := make([]int64, len(groups))
+			for g, group := range groups {
+				counts[g] = int64(len(group.indices))
+			}
+			data = counts
+		default:
+			operation := strings.ToLower(it.fn)
+			if operation == "avg" {
+				operation = "mean"
+			}
+			values := make([]float64, len(groups))
+			for g, group := range groups {
+				// Only SUM of no rows has a value; MIN, MAX and AVG are NULL
+				if len(group.indices) == 0 && operation != "sum" {
+					values[g] = math.NaN()
+					continue
+				}
+				v, err := gb.calculateAggregation(it.column, group.indices, operation)
+				if err != nil {
+					return nil, wrapColumnError("SQL", it.column, err)
+				}
+				values[g] = v
+			}
+			data = values
+		}
+
+		for _, s := range series {
+			if s.Name == it.name() {
+				return nil, newColumnError("SQL", it.name(), "output column specified more than once")
+			}
+		}
+		s, err := newSeriesOwned(it.name(), data)
+		if err != nil {
+			return nil, wrapColumnError("SQL", it.name(), err)
+		}
+		series = append(series, s)
+	}
+
+	result, err := NewDataFrameFromSeries(series...)
+	if err != nil {
+		return nil, wrapError("SQL", err)
+	}
+
+	if len(stmt.orderBy) > 0 && result.length > 0 {
+		columns := make([]string, len(stmt.orderBy))
+		ascending := make([]bool, len(stmt.orderBy))
+		for i, o := range stmt.orderBy {
+			name := stmt.outputName(o.item)
+			if !result.HasColumn(name) {
+				return nil, newColumnError("SQL", name, "ORDER BY must refer to a selected column")
+			}
+			columns[i] = name
+			ascending[i] = o.ascending
+		}
+		result = result.SortBy(columns, ascending)
+		if result.err != nil {
+			return nil, wrapError("SQL", result.err)
+		}
+	}
+	return result, nil
+}
+
+// outputName maps an ORDER BY item to the output column it refers to, so
+// "ORDER BY SUM(sales)" finds an aliased "SUM(sales) AS total".
+func (stmt *sqlSelect) outputName(o sqlItem) string {
+	for _, it := range stmt.items {
+		if it.fn == o.fn && it.column == o.column && o.fn != "" {
+			return it.name()
+		}
+	}
+	return o.name()
+}
+
+// Parsing
+
+type sqlTokenKind int
+
+const (
+	sqlEOF    sqlTokenKind = iota
+	sqlIdent               // bare identifier or keyword
+	sqlQuoted              // "quoted" or `quoted` identifier
+	sqlString              // 'string literal'
+	sqlNumber
+	sqlSymbol
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+	pos  int // byte offset in the query
+}
+
+// sqlKeywords cannot be used as bare identifiers.
+var sqlKeywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true, "ORDER": true,
+	"BY": true, "LIMIT": true, "AS": true, "AND": true, "OR": true, "NOT": true,
+	"ASC": true, "DESC": true, "TRUE": true, "FALSE": true,
+}
+
+// sqlAggregates are the supported aggregate functions.
+var sqlAggregates = map[string]bool{
+	"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true,
+}
+
+// tokenizeSQL splits a query into tokens.
+func tokenizeSQL(query string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	i := 0
+	for i < len(query) {
+		c := query[i]
+		r, size := utf8.DecodeRuneInString(query[i:])
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(query) {
+				r, size := utf8.DecodeRuneInString(query[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, sqlToken{kind: sqlIdent, text: query[start:i], pos: start})
+
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9':
+			start := i
+			for i < len(query) && (query[i] >= '0' && query[i] <= '9' || query[i] == '.' ||
+				query[i] == 'e' || query[i] == 'E' ||
+				(query[i] == '-' || query[i] == '+') && (query[i-1] == 'e' || query[i-1] == 'E')) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlNumber, text: query[start:i], pos: start})
+
+		case c == '\'' || c == '"' || c == '`':
+			start := i
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(query) {
+					return nil, sqlSyntaxError(start, query[start:], "unterminated quote")
+				}
+				if query[i] == c {
+					// A doubled quote stands for itself
+					if i+1 < len(query) && query[i+1] == c {
+						sb.WriteByte(c)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteByte(query[i])
+				i++
+			}
+			kind := sqlQuoted
+			if c == '\'' {
+				kind = sqlString
+			}
+			tokens = append(tokens, sqlToken{kind: kind, text: sb.String(), pos: start})
+
+		default:
+			start := i
+			symbol := ""
+			for _, s := range []string{"<=", ">=", "<>", "!=", "=", "<", ">", ",", "(", ")", "*", "-", ";"} {
+				if strings.HasPrefix(query[i:], s) {
+					symbol = s
+					break
+				}
+			}
+			if symbol == "" {
+				return nil, sqlSyntaxError(start, query[i:i+size], fmt.Sprintf("unexpected character %q", r))
+			}
+			i += len(symbol)
+			tokens = append(tokens, sqlToken{kind: sqlSymbol, text: symbol, pos: start})
+		}
+	}
+	return append(tokens, sqlToken{kind: sqlEOF, pos: len(query)}), nil
+}
+
+// sqlSyntaxError reports a parse failure at token, found at a byte offset
+// of the query.
+func sqlSyntaxError(pos int, token, message string) error {
+	return newSyntaxError("SQL", pos, token, message)
+}
+
+// sqlParser is a recursive-descent parser over the token stream.
+type sqlParser struct {
+	tokens []sqlToken
+	pos    int
+}
+
+// parseSQL parses a SELECT statement.
+func parseSQL(query string) (*sqlSelect, error) {
+	tokens, err := tokenizeSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &sqlParser{tokens: tokens}
+	stmt, err := p.parseSelect()
+	if err != nil {
+		return nil, err
+	}
+
+	p.symbol(";")
+	if tok := p.peek(); tok.kind != sqlEOF {
+		return nil, sqlSyntaxError(tok.pos, tok.text, fmt.Sprintf("unexpected %q", tok.text))
+	}
+	return stmt, nil
+}
+
+func (p *sqlParser) peek() sqlToken {
+	return p.tokens[p.pos]
+}
+
+func (p *sqlParser) next() sqlToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != sqlEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword consumes the next token if it is the given keyword.
+func (p *sqlParser) keyword(kw string) bool {
+	tok := p.peek()
+	if tok.kind == sqlIdent && strings.EqualFold(tok.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// symbol consumes the next token if it is the given symbol.
+func (p *sqlParser) symbol(s string) bool {
+	tok := p.peek()
+	if tok.kind == sqlSymbol && tok.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expect reports a syntax error naming what was expected at the next token.
+func (p *sqlParser) expect(what string) error {
+	tok := p.peek()
+	if tok.kind == sqlEOF {
+		return sqlSyntaxError(tok.pos, "", fmt.Sprintf("expected %s, found end of query", what))
+	}
+	return sqlSyntaxError(tok.pos, tok.text, fmt.Sprintf("expected %s, found %q", what, tok.text))
+}
+
+// identifier consumes a column or table name.
+func (p *sqlParser) identifier() (string, bool) {
+	tok := p.peek()
+	switch {
+	case tok.kind == sqlQuoted:
+	case tok.kind == sqlIdent && !sqlKeywords[strings.ToUpper(tok.text)]:
+	default:
+		return "", false
+	}
+	p.pos++
+	return tok.text, true
+}
+
+func (p *sqlParser) parseSelect() (*sqlSelect, error) {
+	if !p.keyword("SELECT") {
+		return nil, p.expect("SELECT")
+	}
+	stmt := &sqlSelect{limit: -1}
+
+	if p.symbol("*") {
+		stmt.star = true
+	} else {
+		for {
+			it, err := p.parseItem()
+			if err != nil {
+				return nil, err
+			}
+			if p.keyword("AS") {
+				alias, ok := p.identifier()
+				if !ok {
+					return nil, p.expect("alias")
+				}
+				it.alias = alias
+			} else if alias, ok := p.identifier(); ok {
+				it.alias = alias
+			}
+			stmt.items = append(stmt.items, it)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+
+	if !p.keyword("FROM") {
+		return nil, p.expect("FROM")
+	}
+	table, ok := p.identifier()
+	if !ok {
+		return nil, p.expect("table name")
+	}
+	stmt.from = table
+
+	if p.keyword("WHERE") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		stmt.where = expr
+	}
+
+	if p.keyword("GROUP") {
+		if !p.keyword("BY") {
+			return nil, p.expect("BY")
+		}
+		for {
+			column, ok := p.identifier()
+			if !ok {
+				return nil, p.expect("column name")
+			}
+			stmt.groupBy = append(stmt.groupBy, column)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("ORDER") {
+		if !p.keyword("BY") {
+			return nil, p.expect("BY")
+		}
+		for {
+			it, err := p.parseItem()
+			if err != nil {
+				return nil, err
+			}
+			o := sqlOrder{item: it, ascending: true}
+			if p.keyword("DESC") {
+				o.ascending = false
+			} else {
+				p.keyword("ASC")
+			}
+			stmt.orderBy = append(stmt.orderBy, o)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("LIMIT") {
+		tok := p.peek()
+		n, err := strconv.Atoi(tok.text)
+		if tok.kind != sqlNumber || err != nil || n < 0 {
+			return nil, p.expect("non-negative integer")
+		}
+		p.pos++
+		stmt.limit = n
+	}
+
+	return stmt, nil
+}
+
+// parseItem parses a column name or an aggregate call, without alias.
+func (p *sqlParser) parseItem() (sqlItem, error) {
+	tok := p.peek()
+	fn := strings.ToUpper(tok.text)
+	if tok.kind == sqlIdent && sqlAggregates[fn] && p.tokens[p.pos+1].kind == sqlSymbol && p.tokens[p.pos+1].text == "(" {
+		p.pos += 2
+		it := sqlItem{fn: fn}
+		if fn == "COUNT" && p.symbol("*") {
+			it.column = "*"
+		} else if column, ok := p.identifier(); ok {
+			it.column = column
+		} else {
+			return sqlItem{}, p.expect("column name")
+		}
+		if !p.symbol(")") {
+			return sqlItem{}, p.expect(")")
+		}
+		return it, nil
+	}
+
+	column, ok := p.identifier()
+	if !ok {
+		return sqlItem{}, p.expect("column name")
+	}
+	return sqlItem{column: column}, nil
+}
+
+// parseOr parses conditions joined by OR, which binds looser than AND.
+func (p *sqlParser) parseOr() (sqlExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = sqlOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseAnd() (sqlExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = sqlAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseUnary() (sqlExpr, error) {
+	if p.keyword("NOT") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return sqlNot{expr: expr}, nil
+	}
+
+	if p.symbol("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.symbol(")") {
+			return nil, p.expect(")")
+		}
+		return expr, nil
+	}
+
+	column, ok := p.identifier()
+	if !ok {
+		return nil, p.expect("column name")
+	}
+
+	tok := p.peek()
+	switch tok.text {
+	case "=", "!=", "<>", "<", "<=", ">", ">=":
+		if tok.kind != sqlSymbol {
+			return nil, p.expect("comparison operator")
+		}
+		p.pos++
+	default:
+		return nil, p.expect("comparison operator")
+	}
+
+	cmp := sqlCompare{column: column, op: tok.text}
+	value, quoted, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	cmp.value = value
+	cmp.quoted = quoted
+	return cmp, nil
+}
+
+// parseLiteral parses a number, string or boolean literal. Integers become
+// int64 and other numbers float64.
+func (p *sqlParser) parseLiteral() (any, bool, error) {
+	if p.keyword("TRUE") {
+		return true, false, nil
+	}
+	if p.keyword("FALSE") {
+		return false, false, nil
+	}
+
+	tok := p.peek()
+	if tok.kind == sqlString {
+		p.pos++
+		return tok.text, true, nil
+	}
+
+	negative := p.symbol("-")
+	tok = p.peek()
+	if tok.kind != sqlNumber {
+		return nil, false, p.expect("literal value")
+	}
+	p.pos++
+
+	text := tok.text
+	if negative {
+		text = "-" + text
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n, false, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, false, sqlSyntaxError(tok.pos, tok.text, fmt.Sprintf("invalid number %q", tok.text))
+	}
+	return f, false, nil
+}
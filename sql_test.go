@@ -0,0 +1,170 @@
+package otters
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func sqlTestFrame(t *testing.T) *DataFrame {
+	t.Helper()
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "region", []string{"North", "South", "North", "East", "South"}),
+		mustSeries(t, "quarter", []int64{1, 1, 2, 1, 1}),
+		mustSeries(t, "sales", []float64{100, 250, 300, 80, 50}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return df
+}
+
+func TestSQLGroupBy(t *testing.T) {
+	df := sqlTestFrame(t)
+
+	result, err := SQL("SELECT region, SUM(sales) AS total, COUNT(*) FROM df WHERE quarter = 1 GROUP BY region ORDER BY total DESC",
+		map[string]*DataFrame{"df": df})
+	if err != nil {
+		t.Fatalf("SQL error: %v", err)
+	}
+
+	if got := result.Columns(); len(got) != 3 || got[0] != "region" || got[1] != "total" || got[2] != "COUNT(*)" {
+		t.Fatalf("unexpected columns: %v", got)
+	}
+	want := []struct {
+		region string
+		total  float64
+		count  int64
+	}{{"South", 300, 2}, {"North", 100, 1}, {"East", 80, 1}}
+	if result.Len() != len(want) {
+		t.Fatalf("expected %d rows, got %d", len(want), result.Len())
+	}
+	for i, w := range want {
+		if v, _ := result.Get(i, "region"); v != w.region {
+			t.Errorf("row %d: region = %v, want %s", i, v, w.region)
+		}
+		if v, _ := result.Get(i, "total"); v != w.total {
+			t.Errorf("row %d: total = %v, want %v", i, v, w.total)
+		}
+		if v, _ := result.Get(i, "COUNT(*)"); v != w.count {
+			t.Errorf("row %d: count = %v, want %d", i, v, w.count)
+		}
+	}
+}
+
+func TestSQLProjection(t *testing.T) {
+	df := sqlTestFrame(t)
+	tables := map[string]*DataFrame{"sales": df}
+
+	result, err := SQL("select region as r, sales from sales where (region = 'North' or sales < 60) and not quarter > 1 order by sales limit 5;", tables)
+	if err != nil {
+		t.Fatalf("SQL error: %v", err)
+	}
+	if result.Len() != 2 {
+		t.Fatalf("expected 2 rows, got %d", result.Len())
+	}
+	for i, want := range []string{"South", "North"} {
+		if v, _ := result.Get(i, "r"); v != want {
+			t.Errorf("row %d: r = %v, want %s", i, v, want)
+		}
+	}
+
+	all, err := SQL("SELECT * FROM sales LIMIT 2", tables)
+	if err != nil {
+		t.Fatalf("SQL error: %v", err)
+	}
+	if rows, cols := all.Shape(); rows != 2 || cols != 3 {
+		t.Errorf("SELECT * LIMIT 2: got shape (%d, %d)", rows, cols)
+	}
+
+	total, err := SQL("SELECT AVG(sales), MAX(sales) FROM sales", tables)
+	if err != nil {
+		t.Fatalf("SQL error: %v", err)
+	}
+	if v, _ := total.Get(0, "AVG(sales)"); v != 156.0 {
+		t.Errorf("AVG(sales) = %v, want 156", v)
+	}
+}
+
+func TestSQLAggregateNoRows(t *testing.T) {
+	tables := map[string]*DataFrame{"df": sqlTestFrame(t)}
+
+	result, err := SQL("SELECT MIN(sales), MAX(sales), AVG(sales), SUM(sales), COUNT(*) FROM df WHERE sales > 1000", tables)
+	if err != nil {
+		t.Fatalf("SQL error: %v", err)
+	}
+	for _, column := range []string{"MIN(sales)", "MAX(sales)", "AVG(sales)"} {
+		if v, _ := result.Get(0, column); !math.IsNaN(v.(float64)) {
+			t.Errorf("%s of no rows = %v, want NaN", column, v)
+		}
+	}
+	if v, _ := result.Get(0, "SUM(sales)"); v != 0.0 {
+		t.Errorf("SUM(sales) of no rows = %v, want 0", v)
+	}
+	if v, _ := result.Get(0, "COUNT(*)"); v != int64(0) {
+		t.Errorf("COUNT(*) of no rows = %v, want 0", v)
+	}
+}
+
+func TestSQLUnicodeIdentifiers(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "café", []string{"latte", "mocha"}),
+		mustSeries(t, "prix", []float64{3.5, 4}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := SQL("SELECT café FROM menü WHERE prix > 3.8", map[string]*DataFrame{"menü": df})
+	if err != nil {
+		t.Fatalf("SQL error: %v", err)
+	}
+	if v, _ := result.Get(0, "café"); result.Len() != 1 || v != "mocha" {
+		t.Errorf("got %d rows, café = %v; want 1 row, mocha", result.Len(), v)
+	}
+
+	var oe *OtterError
+	if _, err := SQL("SELECT café FROM menü WHERE prix > 3 → 4", map[string]*DataFrame{"menü": df}); !errors.As(err, &oe) || oe.Token != "→" {
+		t.Errorf("error = %v, want one at token →", err)
+	}
+}
+
+func TestSQLErrors(t *testing.T) {
+	tables := map[string]*DataFrame{"df": sqlTestFrame(t)}
+
+	cases := []string{
+		"SELECT region FROM missing",
+		"SELECT region FROM df WHERE",
+		"SELECT region, SUM(sales) FROM df",
+		"SELECT SUM(region) FROM df",
+		"SELECT region FROM df WHERE sales = 'x'",
+		"SELECT region FROM df LIMIT -1",
+		"SELECT region FROM df WHERE region = 'unterminated",
+		"UPDATE df SET sales = 0",
+	}
+	for _, query := range cases {
+		if _, err := SQL(query, tables); err == nil {
+			t.Errorf("%q: expected error", query)
+		}
+	}
+
+	_, err := SQL("SELECT nope FROM df", tables)
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Errorf("expected ErrColumnNotFound, got %v", err)
+	}
+
+	// Syntax errors point at the offending token
+	for query, want := range map[string]struct {
+		token  string
+		offset int
+	}{
+		"SELECT region FROM df LIMIT x":         {"x", 28},
+		"SELECT region FROM df WHERE":           {"", 27},
+		"SELECT region FROM df WHERE sales # 3": {"#", 34},
+	} {
+		var otterErr *OtterError
+		if _, err := SQL(query, tables); !errors.As(err, &otterErr) || otterErr.Token != want.token || otterErr.Offset != want.offset {
+			t.Errorf("%q: got %v, want token %q at offset %d", query, err, want.token, want.offset)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package otters
 import (
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strconv"
+	"time"
 )
 
 // Statistical Functions for DataFrames
@@ -17,41 +19,129 @@ func (df *DataFrame) Count() int {
 	return df.length
 }
 
-// Sum calculates the sum of a numeric column
-func (df *DataFrame) Sum(column string) (float64, error) {
+// StatOptions configures the WithOptions variants of the column statistics
+type StatOptions struct {
+	// SkipNA leaves missing values (NaN) out, as if their rows had been
+	// filtered away first. By default a single NaN makes the result NaN.
+	SkipNA bool
+}
+
+// statValues returns a numeric column as float64, leaving out NaNs if
+// options.SkipNA is set.
+func (df *DataFrame) statValues(column, operation string, options StatOptions) ([]float64, error) {
 	if df.err != nil {
-		return 0, df.err
+		return nil, df.err
 	}
 
 	if err := df.validateColumnExists(column); err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	series := df.columns[column]
-	if series.Type != Int64Type && series.Type != Float64Type {
-		return 0, newColumnError("Sum", column, "column must be numeric (int64 or float64)")
+	if !isNumericType(series.Type) {
+		return nil, newColumnError(operation, column, "column must be numeric (int64 or float64)")
+	}
+
+	values := numericAsFloat64(series)
+	if options.SkipNA && series.Type == Float64Type {
+		present := make([]float64, 0, len(values))
+		for _, v := range values {
+			if !math.IsNaN(v) {
+				present = append(present, v)
+			}
+		}
+		values = present
+	}
+	return values, nil
+}
+
+// Sum calculates the sum of a numeric column
+func (df *DataFrame) Sum(column string) (float64, error) {
+	return df.SumWithOptions(column, StatOptions{})
+}
+
+// SumWithOptions is Sum with options controlling missing values. With
+// SkipNA, a column of only missing values sums to 0.
+func (df *DataFrame) SumWithOptions(column string, options StatOptions) (float64, error) {
+	values, err := df.statValues(column, "Sum", options)
+	if err != nil {
+		return 0, err
 	}
 
 	sum := 0.0
-	for i := 0; i < series.Length; i++ {
-		value, err := series.Get(i)
-		if err != nil {
-			return 0, wrapColumnError("Sum", column, err)
+	for _, v := range values {
+		sum += v
+	}
+	return sum, nil
+}
+
+// CountWhere counts the rows where the condition holds, with the operators
+// of Filter, in one pass and without building the filtered DataFrame:
+//
+//	late, err := df.CountWhere("days_overdue", ">", 30)
+func (df *DataFrame) CountWhere(column, operator string, value any) (int, error) {
+	pred, err := df.conditionPredicate(column, operator, value, "CountWhere")
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for row := 0; row < df.length; row++ {
+		if pred(row) {
+			count++
 		}
+	}
+	return count, nil
+}
+
+// SumWhere sums the numeric column sumCol over the rows where the condition
+// on condCol holds, like Filter followed by Sum but in one pass and without
+// the intermediate DataFrame. As with Sum, a missing value among the summed
+// rows makes the result NaN.
+func (df *DataFrame) SumWhere(sumCol, condCol, operator string, value any) (float64, error) {
+	pred, err := df.conditionPredicate(condCol, operator, value, "SumWhere")
+	if err != nil {
+		return 0, err
+	}
+	values, err := df.numericColumn(sumCol, "SumWhere")
+	if err != nil {
+		return 0, err
+	}
 
-		switch v := value.(type) {
-		case int64:
-			sum += float64(v)
-		case float64:
+	sum := 0.0
+	for row, v := range values {
+		if pred(row) {
 			sum += v
 		}
 	}
-
 	return sum, nil
 }
 
+// conditionPredicate builds the row predicate of a Filter condition for the
+// fused aggregations.
+func (df *DataFrame) conditionPredicate(column, operator string, value any, operation string) (func(row int) bool, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+	if err := df.validateColumnExists(column); err != nil {
+		return nil, err
+	}
+	pred, err := typedPredicate(df.columns[column], operator, value)
+	if err != nil {
+		return nil, wrapColumnError(operation, column, err)
+	}
+	return pred, nil
+}
+
 // Mean calculates the average of a numeric column
 func (df *DataFrame) Mean(column string) (float64, error) {
+	return df.MeanWithOptions(column, StatOptions{})
+}
+
+// MeanWithOptions is Mean with options controlling missing values. With
+// SkipNA, the average is over the values present, and a column of only
+// missing values is an error.
+func (df *DataFrame) MeanWithOptions(column string, options StatOptions) (float64, error) {
 	if df.err != nil {
 		return 0, df.err
 	}
@@ -60,16 +150,56 @@ func (df *DataFrame) Mean(column string) (float64, error) {
 		return 0, err
 	}
 
-	sum, err := df.Sum(column)
+	values, err := df.statValues(column, "Mean", options)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, newColumnError("Mean", column, "column has no values that are not missing")
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values)), nil
+}
+
+// GeoMean calculates the geometric mean of a numeric column, the right
+// average for growth rates and ratios. All values must be positive.
+func (df *DataFrame) GeoMean(column string) (float64, error) {
+	values, err := df.positiveValues(column, "GeoMean")
 	if err != nil {
 		return 0, err
 	}
 
-	return sum / float64(df.length), nil
+	// Averaging logarithms avoids overflowing the running product
+	var sumLog float64
+	for _, v := range values {
+		sumLog += math.Log(v)
+	}
+	return math.Exp(sumLog / float64(len(values))), nil
 }
 
-// Min finds the minimum value in a numeric column
-func (df *DataFrame) Min(column string) (any, error) {
+// HarmonicMean calculates the harmonic mean of a numeric column, the right
+// average for rates such as speeds or prices per unit. All values must be
+// positive.
+func (df *DataFrame) HarmonicMean(column string) (float64, error) {
+	values, err := df.positiveValues(column, "HarmonicMean")
+	if err != nil {
+		return 0, err
+	}
+
+	var sumInv float64
+	for _, v := range values {
+		sumInv += 1 / v
+	}
+	return float64(len(values)) / sumInv, nil
+}
+
+// positiveValues returns a non-empty numeric column as float64, reporting
+// the first zero or negative value as an error.
+func (df *DataFrame) positiveValues(column, operation string) ([]float64, error) {
 	if df.err != nil {
 		return nil, df.err
 	}
@@ -83,37 +213,49 @@ func (df *DataFrame) Min(column string) (any, error) {
 	}
 
 	series := df.columns[column]
-	if series.Type != Int64Type && series.Type != Float64Type {
-		return nil, newColumnError("Min", column, "column must be numeric (int64 or float64)")
-	}
-
-	firstValue, err := series.Get(0)
-	if err != nil {
-		return nil, wrapColumnError("Min", column, err)
-	}
-
-	min := convertToFloat64(firstValue)
-	for i := 1; i < series.Length; i++ {
-		value, err := series.Get(i)
-		if err != nil {
-			return nil, wrapColumnError("Min", column, err)
-		}
-
-		floatValue := convertToFloat64(value)
-		if floatValue < min {
-			min = floatValue
+	if !isNumericType(series.Type) {
+		return nil, newColumnError(operation, column, "column must be numeric (int64 or float64)")
+	}
+
+	values := numericAsFloat64(series)
+	for i, v := range values {
+		if v <= 0 {
+			return nil, &OtterError{
+				Op:      operation,
+				Column:  column,
+				Row:     i,
+				Message: fmt.Sprintf("value %v is not positive", v),
+			}
 		}
 	}
+	return values, nil
+}
 
-	// Return in original type
-	if series.Type == Int64Type {
-		return int64(min), nil
-	}
-	return min, nil
+// Min finds the minimum value in a numeric column
+func (df *DataFrame) Min(column string) (any, error) {
+	return df.MinWithOptions(column, StatOptions{})
+}
+
+// MinWithOptions is Min with options controlling missing values. With
+// SkipNA, a column of only missing values is an error.
+func (df *DataFrame) MinWithOptions(column string, options StatOptions) (any, error) {
+	return df.extreme(column, "Min", options, -1)
 }
 
 // Max finds the maximum value in a numeric column
 func (df *DataFrame) Max(column string) (any, error) {
+	return df.MaxWithOptions(column, StatOptions{})
+}
+
+// MaxWithOptions is Max with options controlling missing values. With
+// SkipNA, a column of only missing values is an error.
+func (df *DataFrame) MaxWithOptions(column string, options StatOptions) (any, error) {
+	return df.extreme(column, "Max", options, 1)
+}
+
+// extreme returns the smallest (sign -1) or largest (sign 1) value of a
+// numeric column, in the column's type.
+func (df *DataFrame) extreme(column, operation string, options StatOptions, sign int) (any, error) {
 	if df.err != nil {
 		return nil, df.err
 	}
@@ -127,84 +269,171 @@ func (df *DataFrame) Max(column string) (any, error) {
 	}
 
 	series := df.columns[column]
-	if series.Type != Int64Type && series.Type != Float64Type {
-		return nil, newColumnError("Max", column, "column must be numeric (int64 or float64)")
+	if series.Type == Int64Type {
+		// Compared as int64, which float64 cannot hold exactly
+		data := series.Data.([]int64)
+		best := data[0]
+		for _, v := range data[1:] {
+			if (sign < 0 && v < best) || (sign > 0 && v > best) {
+				best = v
+			}
+		}
+		return best, nil
 	}
 
-	firstValue, err := series.Get(0)
+	values, err := df.statValues(column, operation, options)
 	if err != nil {
-		return nil, wrapColumnError("Max", column, err)
+		return nil, err
 	}
-
-	max := convertToFloat64(firstValue)
-	for i := 1; i < series.Length; i++ {
-		value, err := series.Get(i)
-		if err != nil {
-			return nil, wrapColumnError("Max", column, err)
+	if len(values) == 0 {
+		return nil, newColumnError(operation, column, "column has no values that are not missing")
+	}
+	best := values[0]
+	for _, v := range values[1:] {
+		if math.IsNaN(v) || (sign < 0 && v < best) || (sign > 0 && v > best) {
+			best = v
 		}
-
-		floatValue := convertToFloat64(value)
-		if floatValue > max {
-			max = floatValue
+		if math.IsNaN(best) {
+			break
 		}
 	}
+	return best, nil
+}
 
-	// Return in original type
-	if series.Type == Int64Type {
-		return int64(max), nil
-	}
-	return max, nil
+// IdxMax returns the position of the row holding the largest value of a
+// numeric column, the first one if several do; fetch the row with RowAt:
+//
+//	peak, err := df.IdxMax("sales")
+//	row, _ := df.RowAt(peak)
+//	fmt.Println(row.Time("date"))
+//
+// Missing values are skipped, and a column of only missing values is an
+// error.
+func (df *DataFrame) IdxMax(column string) (int, error) {
+	return df.argExtreme(column, "IdxMax", 1)
 }
 
-// Std calculates the standard deviation of a numeric column
-func (df *DataFrame) Std(column string) (float64, error) {
+// IdxMin returns the position of the row holding the smallest value of a
+// numeric column, like IdxMax.
+func (df *DataFrame) IdxMin(column string) (int, error) {
+	return df.argExtreme(column, "IdxMin", -1)
+}
+
+// argExtreme returns the first row holding the minimum (sign < 0) or
+// maximum (sign > 0) of a numeric column, skipping missing values.
+func (df *DataFrame) argExtreme(column, operation string, sign int) (int, error) {
 	if df.err != nil {
 		return 0, df.err
 	}
-
 	if err := df.validateColumnExists(column); err != nil {
 		return 0, err
 	}
 
-	series := df.columns[column]
-	if series.Type != Int64Type && series.Type != Float64Type {
-		return 0, newColumnError("Std", column, "column must be numeric (int64 or float64)")
+	switch data := df.columns[column].Data.(type) {
+	case []int64:
+		// Compared as int64, which float64 cannot hold exactly
+		return argBest(data, func(v int64) bool { return true }, sign, column, operation)
+	case []float64:
+		return argBest(data, func(v float64) bool { return !math.IsNaN(v) }, sign, column, operation)
 	}
+	return 0, newColumnError(operation, column, "column must be numeric (int64 or float64)")
+}
 
-	if series.Length <= 1 {
-		return 0, newColumnError("Std", column, "need at least 2 values to calculate standard deviation")
+// argBest returns the first position of the smallest or largest value
+// among those present.
+func argBest[T int64 | float64](values []T, present func(T) bool, sign int, column, operation string) (int, error) {
+	best := -1
+	for i, v := range values {
+		if !present(v) {
+			continue
+		}
+		if best < 0 || (sign < 0 && v < values[best]) || (sign > 0 && v > values[best]) {
+			best = i
+		}
 	}
-
-	// Calculate mean
-	mean, err := df.Mean(column)
-	if err != nil {
-		return 0, err
+	if best < 0 {
+		return 0, newColumnError(operation, column, "column has no values that are not missing")
 	}
+	return best, nil
+}
 
-	// Calculate variance
-	variance := 0.0
-	for i := 0; i < series.Length; i++ {
-		value, err := series.Get(i)
-		if err != nil {
-			return 0, wrapColumnError("Std", column, err)
-		}
+// Std calculates the sample standard deviation (n-1 denominator) of a
+// numeric column
+func (df *DataFrame) Std(column string) (float64, error) {
+	return df.stdDDof(column, 1, "Std", StatOptions{})
+}
 
-		floatValue := convertToFloat64(value)
-		diff := floatValue - mean
-		variance += diff * diff
-	}
+// StdWithOptions is Std with options controlling missing values. With
+// SkipNA, the deviation is over the values present, and fewer than two of
+// them is an error.
+func (df *DataFrame) StdWithOptions(column string, options StatOptions) (float64, error) {
+	return df.stdDDof(column, 1, "Std", options)
+}
 
-	variance /= float64(series.Length - 1) // Sample standard deviation
-	return math.Sqrt(variance), nil
+// StdP calculates the population standard deviation (n denominator) of a
+// numeric column, for data covering the whole population
+func (df *DataFrame) StdP(column string) (float64, error) {
+	return df.stdDDof(column, 0, "StdP", StatOptions{})
+}
+
+// StdDDof calculates the standard deviation with an n-ddof denominator:
+// ddof 1 gives the sample and ddof 0 the population standard deviation
+func (df *DataFrame) StdDDof(column string, ddof int) (float64, error) {
+	return df.stdDDof(column, ddof, "StdDDof", StatOptions{})
 }
 
-// Var calculates the variance of a numeric column
+// Var calculates the sample variance (n-1 denominator) of a numeric column
 func (df *DataFrame) Var(column string) (float64, error) {
-	std, err := df.Std(column)
+	return df.variance(column, 1, "Var", StatOptions{})
+}
+
+// VarP calculates the population variance (n denominator) of a numeric column
+func (df *DataFrame) VarP(column string) (float64, error) {
+	return df.variance(column, 0, "VarP", StatOptions{})
+}
+
+// VarDDof calculates the variance with an n-ddof denominator
+func (df *DataFrame) VarDDof(column string, ddof int) (float64, error) {
+	return df.variance(column, ddof, "VarDDof", StatOptions{})
+}
+
+func (df *DataFrame) stdDDof(column string, ddof int, operation string, options StatOptions) (float64, error) {
+	variance, err := df.variance(column, ddof, operation, options)
 	if err != nil {
 		return 0, err
 	}
-	return std * std, nil
+	return math.Sqrt(variance), nil
+}
+
+// variance computes the sum of squared deviations divided by n-ddof.
+func (df *DataFrame) variance(column string, ddof int, operation string, options StatOptions) (float64, error) {
+	values, err := df.statValues(column, operation, options)
+	if err != nil {
+		return 0, err
+	}
+
+	if ddof < 0 {
+		return 0, newColumnError(operation, column, "ddof must not be negative")
+	}
+
+	if len(values) <= ddof {
+		return 0, newColumnError(operation, column,
+			fmt.Sprintf("need at least %d values to calculate variance with ddof %d", ddof+1, ddof))
+	}
+
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		diff := v - mean
+		variance += diff * diff
+	}
+
+	return variance / float64(len(values)-ddof), nil
 }
 
 // Median calculates the median of a numeric column
@@ -282,47 +511,135 @@ func (df *DataFrame) Quantile(column string, q float64) (float64, error) {
 
 	sort.Float64s(values)
 
-	// Calculate quantile using linear interpolation
-	n := float64(len(values))
-	index := q * (n - 1)
+	return quantileSorted(values, q, "linear"), nil
+}
+
+// Quantiles calculates several quantiles of a numeric column from a single
+// sort. method decides how a quantile falling between two values is
+// computed: "linear" interpolates (as Quantile does), "nearest" takes the
+// closer value (the even position on a tie), and "lower" and "higher" take
+// the value below or above.
+func (df *DataFrame) Quantiles(column string, qs []float64, method string) ([]float64, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	switch method {
+	case "linear", "nearest", "lower", "higher":
+	default:
+		return nil, newOpError("Quantiles", fmt.Sprintf("unsupported interpolation method: %s", method))
+	}
+
+	for _, q := range qs {
+		if q < 0 || q > 1 || math.IsNaN(q) {
+			return nil, newOpError("Quantiles", "quantile must be between 0 and 1")
+		}
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return nil, err
+	}
+
+	series := df.columns[column]
+	if !isNumericType(series.Type) {
+		return nil, newColumnError("Quantiles", column, "column must be numeric (int64 or float64)")
+	}
 
-	if index == math.Trunc(index) {
-		return values[int(index)], nil
+	if err := df.validateNotEmpty(); err != nil {
+		return nil, err
 	}
 
+	values := make([]float64, series.Length)
+	copy(values, numericAsFloat64(series))
+	sort.Float64s(values)
+
+	result := make([]float64, len(qs))
+	for i, q := range qs {
+		result[i] = quantileSorted(values, q, method)
+	}
+	return result, nil
+}
+
+// quantileSorted returns the q-th quantile of sorted, non-empty values.
+func quantileSorted(values []float64, q float64, method string) float64 {
+	index := q * float64(len(values)-1)
 	lower := int(math.Floor(index))
 	upper := int(math.Ceil(index))
-	weight := index - math.Floor(index)
 
-	return values[lower]*(1-weight) + values[upper]*weight, nil
+	switch method {
+	case "lower":
+		return values[lower]
+	case "higher":
+		return values[upper]
+	case "nearest":
+		return values[int(math.RoundToEven(index))]
+	}
+
+	if lower == upper {
+		return values[lower]
+	}
+	weight := index - float64(lower)
+	return values[lower]*(1-weight) + values[upper]*weight
 }
 
 // Describe generates summary statistics for all numeric columns (like Pandas describe())
 func (df *DataFrame) Describe() (*DataFrame, error) {
+	return df.DescribeWithOptions(DescribeOptions{})
+}
+
+// DescribeOptions configures DescribeWithOptions
+type DescribeOptions struct {
+	// IncludeAll summarizes every column, not only numeric ones: string,
+	// bool and time columns report count, unique, top (the most frequent
+	// value) and freq (its count). Statistics that do not apply to a
+	// column are "NaN".
+	IncludeAll bool
+
+	// SkipInf leaves +Inf and -Inf out of a numeric column's statistics,
+	// including its count. By default an infinite value is an error, since
+	// it would turn the mean, std and quantiles into Inf or NaN.
+	SkipInf bool
+}
+
+// DescribeWithOptions generates summary statistics with custom options
+func (df *DataFrame) DescribeWithOptions(options DescribeOptions) (*DataFrame, error) {
 	if df.err != nil {
 		return nil, df.err
 	}
 
-	// Find numeric columns
-	var numericColumns []string
+	// Find the columns to summarize
+	var columns []string
 	for _, colName := range df.order {
 		series := df.columns[colName]
-		if series.Type == Int64Type || series.Type == Float64Type {
-			numericColumns = append(numericColumns, colName)
+		if options.IncludeAll || series.Type == Int64Type || series.Type == Float64Type {
+			columns = append(columns, colName)
 		}
 	}
 
-	if len(numericColumns) == 0 {
+	if len(columns) == 0 {
+		if options.IncludeAll {
+			return nil, newOpError("Describe", "no columns found")
+		}
 		return nil, newOpError("Describe", "no numeric columns found")
 	}
 
+	if !options.SkipInf {
+		if err := df.infError(columns, "Describe"); err != nil {
+			return nil, err
+		}
+	}
+
 	// Statistics to calculate
-	stats := []string{"count", "mean", "std", "min", "25%", "50%", "75%", "max"}
+	numericStats := []string{"count", "mean", "std", "min", "25%", "50%", "75%", "max"}
+	stats := numericStats
+	if options.IncludeAll {
+		stats = []string{"count", "unique", "top", "freq", "mean", "std", "min", "25%", "50%", "75%", "max"}
+	}
 
 	// The label column leads the result; avoid colliding with a data column
 	// named "statistic"
 	labelColumn := "statistic"
-	for contains(numericColumns, labelColumn) {
+	for contains(columns, labelColumn) {
 		labelColumn += "_"
 	}
 
@@ -330,63 +647,29 @@ func (df *DataFrame) Describe() (*DataFrame, error) {
 	if err != nil {
 		return nil, wrapError("Describe", err)
 	}
-	resultSeries := make([]*Series, 0, len(numericColumns)+1)
+	resultSeries := make([]*Series, 0, len(columns)+1)
 	resultSeries = append(resultSeries, labelSeries)
 
-	// Calculate statistics for each numeric column
-	for _, colName := range numericColumns {
-		values := make([]string, len(stats))
-
-		// Count
-		values[0] = strconv.Itoa(df.length)
-
-		// Mean
-		if mean, err := df.Mean(colName); err == nil {
-			values[1] = fmt.Sprintf("%.6f", mean)
-		} else {
-			values[1] = "NaN"
-		}
-
-		// Standard deviation
-		if std, err := df.Std(colName); err == nil {
-			values[2] = fmt.Sprintf("%.6f", std)
-		} else {
-			values[2] = "NaN"
-		}
-
-		// Min
-		if min, err := df.Min(colName); err == nil {
-			values[3] = fmt.Sprintf("%.6f", convertToFloat64(min))
-		} else {
-			values[3] = "NaN"
-		}
-
-		// 25th percentile
-		if q25, err := df.Quantile(colName, 0.25); err == nil {
-			values[4] = fmt.Sprintf("%.6f", q25)
-		} else {
-			values[4] = "NaN"
-		}
-
-		// Median (50th percentile)
-		if median, err := df.Median(colName); err == nil {
-			values[5] = fmt.Sprintf("%.6f", median)
-		} else {
-			values[5] = "NaN"
-		}
-
-		// 75th percentile
-		if q75, err := df.Quantile(colName, 0.75); err == nil {
-			values[6] = fmt.Sprintf("%.6f", q75)
-		} else {
-			values[6] = "NaN"
+	for _, colName := range columns {
+		source := df
+		if options.SkipInf && firstInf(df.columns[colName]) >= 0 {
+			source = df.Select(colName)
+			source = source.selectRows(source.finiteRows([]string{colName}), "Describe")
+			if err := source.Error(); err != nil {
+				return nil, err
+			}
 		}
 
-		// Max
-		if max, err := df.Max(colName); err == nil {
-			values[7] = fmt.Sprintf("%.6f", convertToFloat64(max))
-		} else {
-			values[7] = "NaN"
+		var values []string
+		switch {
+		case !options.IncludeAll:
+			values = source.describeNumeric(colName)
+		case isNumericType(df.columns[colName].Type):
+			numeric := source.describeNumeric(colName)
+			values = append([]string{numeric[0], "NaN", "NaN", "NaN"}, numeric[1:]...)
+		default:
+			values = append(df.describeCategorical(colName),
+				"NaN", "NaN", "NaN", "NaN", "NaN", "NaN", "NaN")
 		}
 
 		colSeries, err := newSeriesOwned(colName, values)
@@ -399,66 +682,185 @@ func (df *DataFrame) Describe() (*DataFrame, error) {
 	return NewDataFrameFromSeries(resultSeries...)
 }
 
-// ValueCounts returns the frequency of each unique value in a column
-func (df *DataFrame) ValueCounts(column string) (*DataFrame, error) {
-	if df.err != nil {
-		return nil, df.err
+// describeNumeric formats count, mean, std, min, 25%, 50%, 75% and max of
+// a numeric column.
+func (df *DataFrame) describeNumeric(colName string) []string {
+	values := make([]string, 8)
+
+	// Count
+	values[0] = strconv.Itoa(df.length)
+
+	// Mean
+	if mean, err := df.Mean(colName); err == nil {
+		values[1] = fmt.Sprintf("%.6f", mean)
+	} else {
+		values[1] = "NaN"
 	}
 
-	if err := df.validateColumnExists(column); err != nil {
-		return nil, err
+	// Standard deviation
+	if std, err := df.Std(colName); err == nil {
+		values[2] = fmt.Sprintf("%.6f", std)
+	} else {
+		values[2] = "NaN"
 	}
 
-	series := df.columns[column]
-	counts := make(map[string]int)
+	// Min
+	if min, err := df.Min(colName); err == nil {
+		values[3] = fmt.Sprintf("%.6f", convertToFloat64(min))
+	} else {
+		values[3] = "NaN"
+	}
 
-	// Count occurrences
-	for i := 0; i < series.Length; i++ {
-		value, err := series.Get(i)
-		if err != nil {
-			return nil, wrapColumnError("ValueCounts", column, err)
-		}
+	// 25th percentile
+	if q25, err := df.Quantile(colName, 0.25); err == nil {
+		values[4] = fmt.Sprintf("%.6f", q25)
+	} else {
+		values[4] = "NaN"
+	}
 
-		key := fmt.Sprintf("%v", value)
-		counts[key]++
+	// Median (50th percentile)
+	if median, err := df.Median(colName); err == nil {
+		values[5] = fmt.Sprintf("%.6f", median)
+	} else {
+		values[5] = "NaN"
 	}
 
-	// Create result DataFrame
-	var values []string
-	var frequencies []int64
+	// 75th percentile
+	if q75, err := df.Quantile(colName, 0.75); err == nil {
+		values[6] = fmt.Sprintf("%.6f", q75)
+	} else {
+		values[6] = "NaN"
+	}
 
-	// Sort by count (descending)
-	type countPair struct {
-		value string
-		count int
+	// Max
+	if max, err := df.Max(colName); err == nil {
+		values[7] = fmt.Sprintf("%.6f", convertToFloat64(max))
+	} else {
+		values[7] = "NaN"
 	}
 
-	var pairs []countPair
+	return values
+}
+
+// describeCategorical formats count, unique, top and freq of a column. Ties
+// for the most frequent value go to the smallest formatted value.
+func (df *DataFrame) describeCategorical(colName string) []string {
+	series := df.columns[colName]
+	counts := make(map[string]int)
+	for i := 0; i < series.Length; i++ {
+		value, _ := series.Get(i)
+		counts[formatValueForCSV(value)]++
+	}
+
+	top, freq := "NaN", 0
 	for value, count := range counts {
-		pairs = append(pairs, countPair{value, count})
+		if count > freq || count == freq && value < top {
+			top, freq = value, count
+		}
 	}
+	if freq == 0 {
+		return []string{"0", "0", "NaN", "NaN"}
+	}
+
+	return []string{strconv.Itoa(df.length), strconv.Itoa(len(counts)), top, strconv.Itoa(freq)}
+}
 
-	// Sort by count descending, then value ascending so ties are deterministic
-	sort.Slice(pairs, func(i, j int) bool {
-		if pairs[i].count != pairs[j].count {
-			return pairs[i].count > pairs[j].count
+// ValueCounts returns the frequency of each unique value in a column, most
+// frequent first; see ValueCountsWithOptions.
+func (df *DataFrame) ValueCounts(column string) (*DataFrame, error) {
+	return df.ValueCountsWithOptions(column, ValueCountsOptions{})
+}
+
+// ValueCountsOptions configures ValueCountsWithOptions
+type ValueCountsOptions struct {
+	// Normalize reports each value's share of the counted rows in a float64
+	// "proportion" column instead of an int64 "count" column.
+	Normalize bool
+
+	// Ascending lists the least frequent values first.
+	Ascending bool
+
+	// DropNA leaves missing values (NaN, zero time) out. By default they
+	// are counted together as one value.
+	DropNA bool
+
+	// Bins, if positive, counts a numeric column in that many equal-width
+	// bins spanning its minimum to maximum instead of by distinct value.
+	// The value column then holds the bin intervals as Cut labels them,
+	// empty bins are listed with a count of 0, and missing values are never
+	// counted.
+	Bins int
+}
+
+// ValueCountsWithOptions returns the frequency of each unique value in a
+// column. The result's first column is named after column and holds the
+// values with their original type; the second is "count" (or "proportion"
+// with Normalize). Rows are ordered by frequency, and values with the same
+// frequency keep the order in which they first appear in the column (bins
+// keep their order).
+func (df *DataFrame) ValueCountsWithOptions(column string, options ValueCountsOptions) (*DataFrame, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return nil, err
+	}
+
+	var valueSeries *Series
+	var counts []int
+	var err error
+	if options.Bins > 0 {
+		valueSeries, counts, err = df.binCounts(column, options.Bins)
+	} else {
+		valueSeries, counts, err = distinctCounts(df.columns[column], options.DropNA)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]int, len(counts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if options.Ascending {
+			return counts[order[i]] < counts[order[j]]
 		}
-		return pairs[i].value < pairs[j].value
+		return counts[order[i]] > counts[order[j]]
 	})
 
-	for _, pair := range pairs {
-		values = append(values, pair.value)
-		frequencies = append(frequencies, int64(pair.count))
+	total := 0
+	for _, count := range counts {
+		total += count
 	}
 
 	// Value column leads, count column follows; avoid colliding with a data
-	// column literally named "count"
+	// column literally named "count" or "proportion"
 	countColumn := "count"
+	if options.Normalize {
+		countColumn = "proportion"
+	}
 	if column == countColumn {
-		countColumn = "count_"
+		countColumn += "_"
 	}
 
-	valueSeries, err := newSeriesOwned(column, values)
+	var frequencies any
+	if options.Normalize {
+		proportions := make([]float64, len(order))
+		for i, g := range order {
+			proportions[i] = float64(counts[g]) / float64(total)
+		}
+		frequencies = proportions
+	} else {
+		values := make([]int64, len(order))
+		for i, g := range order {
+			values[i] = int64(counts[g])
+		}
+		frequencies = values
+	}
+
+	sortedValues, err := newSeriesOwned(column, selectSeriesRows(valueSeries, order))
 	if err != nil {
 		return nil, wrapColumnError("ValueCounts", column, err)
 	}
@@ -467,15 +869,184 @@ func (df *DataFrame) ValueCounts(column string) (*DataFrame, error) {
 		return nil, wrapColumnError("ValueCounts", column, err)
 	}
 
-	return NewDataFrameFromSeries(valueSeries, countSeries)
+	return NewDataFrameFromSeries(sortedValues, countSeries)
+}
+
+// distinctCounts returns the distinct values of the series, in order of
+// first appearance, and how often each occurs.
+func distinctCounts(series *Series, dropNA bool) (*Series, []int, error) {
+	groups, _ := buildGroupsHashed([]*Series{series}, 0, series.Length)
+	isNull := nullPredicate(series)
+	var firstRows, counts []int
+	for _, g := range groups {
+		if dropNA && isNull(g.indices[0]) {
+			continue
+		}
+		firstRows = append(firstRows, g.indices[0])
+		counts = append(counts, len(g.indices))
+	}
+
+	values, err := newSeriesOwned(series.Name, selectSeriesRows(series, firstRows))
+	if err != nil {
+		return nil, nil, wrapColumnError("ValueCounts", series.Name, err)
+	}
+	return values, counts, nil
+}
+
+// binCounts counts the values of a numeric column in nbins equal-width
+// bins, returning the bins' labels and counts in bin order.
+func (df *DataFrame) binCounts(column string, nbins int) (*Series, []int, error) {
+	values, err := df.numericColumn(column, "ValueCounts")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	if lo > hi {
+		return nil, nil, newColumnError("ValueCounts", column, "column has no values to bin")
+	}
+	if lo == hi {
+		// A single distinct value still gets a bin of non-zero width
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	edges := make([]float64, nbins+1)
+	for i := range edges {
+		edges[i] = lo + float64(i)*(hi-lo)/float64(nbins)
+	}
+	edges[nbins] = hi
+
+	counts := make([]int, nbins)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		// The first edge not below v closes v's bin
+		bin := max(sort.SearchFloat64s(edges, v)-1, 0)
+		counts[min(bin, nbins-1)]++
+	}
+
+	labels, err := newSeriesOwned(column, intervalLabels(edges))
+	if err != nil {
+		return nil, nil, wrapColumnError("ValueCounts", column, err)
+	}
+	return labels, counts, nil
+}
+
+// NUnique counts the distinct values of a column. Missing values (NaN,
+// zero times) are not counted, as in GroupBy.NUnique.
+func (df *DataFrame) NUnique(column string) (int, error) {
+	if df.err != nil {
+		return 0, df.err
+	}
+	if err := df.validateColumnExists(column); err != nil {
+		return 0, err
+	}
+	return countDistinct(df.columns[column]), nil
+}
+
+// NUniqueAll counts the distinct values of every column, like NUnique. The
+// result has one row per column, in order, with a string "column" and an
+// int64 "nunique" column.
+func (df *DataFrame) NUniqueAll() (*DataFrame, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	columns := slices.Clone(df.order)
+	counts := make([]int64, len(columns))
+	for i, column := range columns {
+		counts[i] = int64(countDistinct(df.columns[column]))
+	}
+
+	columnSeries, err := newSeriesOwned("column", columns)
+	if err != nil {
+		return nil, wrapError("NUniqueAll", err)
+	}
+	countSeries, err := newSeriesOwned("nunique", counts)
+	if err != nil {
+		return nil, wrapError("NUniqueAll", err)
+	}
+	return NewDataFrameFromSeries(columnSeries, countSeries)
+}
+
+// countDistinct counts the distinct non-missing values of the series with
+// a hash set of its own type.
+func countDistinct(series *Series) int {
+	switch data := series.Data.(type) {
+	case []int64:
+		return distinctKeys(data, func(v int64) (int64, bool) { return v, true })
+	case []float64:
+		return distinctKeys(data, func(v float64) (float64, bool) { return v, !math.IsNaN(v) })
+	case []string:
+		return distinctKeys(data, func(v string) (string, bool) { return v, true })
+	case []bool:
+		return distinctKeys(data, func(v bool) (bool, bool) { return v, true })
+	case []time.Time:
+		return distinctKeys(data, timeIndexKey)
+	}
+	return 0
+}
+
+// distinctKeys counts the distinct keys of values; key reports false for a
+// value to leave out.
+func distinctKeys[T any, K comparable](values []T, key func(T) (K, bool)) int {
+	seen := make(map[K]struct{})
+	for _, v := range values {
+		if k, ok := key(v); ok {
+			seen[k] = struct{}{}
+		}
+	}
+	return len(seen)
 }
 
 // Correlation calculates correlation matrix for numeric columns
 func (df *DataFrame) Correlation() (*DataFrame, error) {
+	return df.CorrelationWith("pearson")
+}
+
+// CorrelationWith calculates the correlation matrix for numeric columns
+// with the given method: "pearson" (linear), or the rank-based "spearman"
+// and "kendall" (tau-b), which also capture monotonic non-linear
+// relationships
+func (df *DataFrame) CorrelationWith(method string) (*DataFrame, error) {
+	return df.CorrelationWithOptions(CorrelationOptions{Method: method})
+}
+
+// CorrelationOptions configures CorrelationWithOptions
+type CorrelationOptions struct {
+	// Method is "pearson" (the default), "spearman" or "kendall"; see
+	// CorrelationWith.
+	Method string
+
+	// SkipInf leaves out every row holding +Inf or -Inf in a numeric
+	// column. By default an infinite value is an error.
+	SkipInf bool
+}
+
+// CorrelationWithOptions calculates the correlation matrix for numeric
+// columns with custom options
+func (df *DataFrame) CorrelationWithOptions(options CorrelationOptions) (*DataFrame, error) {
 	if df.err != nil {
 		return nil, df.err
 	}
 
+	method := options.Method
+	if method == "" {
+		method = "pearson"
+	}
+	switch method {
+	case "pearson", "spearman", "kendall":
+	default:
+		return nil, newOpError("Correlation", fmt.Sprintf("unsupported correlation method: %s", method))
+	}
+
 	// Find numeric columns
 	var numericColumns []string
 	for _, colName := range df.order {
@@ -489,6 +1060,16 @@ func (df *DataFrame) Correlation() (*DataFrame, error) {
 		return nil, newOpError("Correlation", "need at least 2 numeric columns for correlation")
 	}
 
+	if options.SkipInf {
+		df = df.Select(numericColumns...)
+		df = df.selectRows(df.finiteRows(numericColumns), "Correlation")
+		if err := df.Error(); err != nil {
+			return nil, err
+		}
+	} else if err := df.infError(numericColumns, "Correlation"); err != nil {
+		return nil, err
+	}
+
 	// The label column leads the result, matrix columns follow in DataFrame
 	// order; avoid colliding with a column named "column"
 	labelColumn := "column"
@@ -506,13 +1087,37 @@ func (df *DataFrame) Correlation() (*DataFrame, error) {
 	resultSeries := make([]*Series, 0, n+1)
 	resultSeries = append(resultSeries, labelSeries)
 
+	// Rank-based methods work on ranks; compute them once per column
+	var ranks map[string][]float64
+	if method != "pearson" {
+		ranks = make(map[string][]float64, n)
+		for _, colName := range numericColumns {
+			r, err := rankSeries(df.columns[colName], "average", true)
+			if err != nil {
+				return nil, wrapColumnError("Correlation", colName, err)
+			}
+			ranks[colName] = r
+		}
+	}
+
 	for _, col1 := range numericColumns {
 		correlations := make([]float64, n)
 
 		for j, col2 := range numericColumns {
-			corr, err := df.calculateCorrelation(col1, col2)
-			if err != nil {
-				return nil, err
+			var corr float64
+			switch {
+			case col1 == col2:
+				corr = 1.0
+			case method == "spearman":
+				corr = pearson(ranks[col1], ranks[col2])
+			case method == "kendall":
+				corr = kendallTau(ranks[col1], ranks[col2])
+			default:
+				var err error
+				corr, err = df.calculateCorrelation(col1, col2)
+				if err != nil {
+					return nil, err
+				}
 			}
 			correlations[j] = corr
 		}
@@ -527,6 +1132,104 @@ func (df *DataFrame) Correlation() (*DataFrame, error) {
 	return NewDataFrameFromSeries(resultSeries...)
 }
 
+// Cov calculates the sample covariance (n-1 denominator) of two numeric columns
+func (df *DataFrame) Cov(col1, col2 string) (float64, error) {
+	if df.err != nil {
+		return 0, df.err
+	}
+
+	for _, column := range []string{col1, col2} {
+		if err := df.validateColumnExists(column); err != nil {
+			return 0, err
+		}
+		if !isNumericType(df.columns[column].Type) {
+			return 0, newColumnError("Cov", column, "column must be numeric (int64 or float64)")
+		}
+	}
+
+	if df.length <= 1 {
+		return 0, newOpError("Cov", "need at least 2 values to calculate covariance")
+	}
+
+	return covariance(numericAsFloat64(df.columns[col1]), numericAsFloat64(df.columns[col2])), nil
+}
+
+// CovMatrix calculates the covariance matrix for numeric columns, laid out
+// like Correlation
+func (df *DataFrame) CovMatrix() (*DataFrame, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	// Find numeric columns
+	var numericColumns []string
+	for _, colName := range df.order {
+		if isNumericType(df.columns[colName].Type) {
+			numericColumns = append(numericColumns, colName)
+		}
+	}
+
+	if len(numericColumns) == 0 {
+		return nil, newOpError("CovMatrix", "no numeric columns found")
+	}
+
+	if df.length <= 1 {
+		return nil, newOpError("CovMatrix", "need at least 2 values to calculate covariance")
+	}
+
+	labelColumn := "column"
+	for contains(numericColumns, labelColumn) {
+		labelColumn += "_"
+	}
+	n := len(numericColumns)
+
+	labels := make([]string, n)
+	copy(labels, numericColumns)
+	labelSeries, err := newSeriesOwned(labelColumn, labels)
+	if err != nil {
+		return nil, wrapError("CovMatrix", err)
+	}
+	resultSeries := make([]*Series, 0, n+1)
+	resultSeries = append(resultSeries, labelSeries)
+
+	values := make([][]float64, n)
+	for i, colName := range numericColumns {
+		values[i] = numericAsFloat64(df.columns[colName])
+	}
+
+	for i, col1 := range numericColumns {
+		covs := make([]float64, n)
+		for j := range numericColumns {
+			covs[j] = covariance(values[i], values[j])
+		}
+
+		colSeries, err := newSeriesOwned(col1, covs)
+		if err != nil {
+			return nil, wrapColumnError("CovMatrix", col1, err)
+		}
+		resultSeries = append(resultSeries, colSeries)
+	}
+
+	return NewDataFrameFromSeries(resultSeries...)
+}
+
+// covariance computes the sample covariance of two equal-length slices.
+func covariance(x, y []float64) float64 {
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	var sum float64
+	for i := range x {
+		sum += (x[i] - meanX) * (y[i] - meanY)
+	}
+	return sum / float64(len(x)-1)
+}
+
 // Helper functions
 
 // convertToFloat64 converts numeric values to float64
@@ -597,6 +1300,62 @@ func (df *DataFrame) calculateCorrelation(col1, col2 string) (float64, error) {
 	return numerator / denominator, nil
 }
 
+// pearson calculates the Pearson correlation of two equal-length slices,
+// or 0 if either has no variance (as calculateCorrelation does).
+func pearson(x, y []float64) float64 {
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	var numerator, sumSq1, sumSq2 float64
+	for i := range x {
+		dx := x[i] - meanX
+		dy := y[i] - meanY
+		numerator += dx * dy
+		sumSq1 += dx * dx
+		sumSq2 += dy * dy
+	}
+
+	denominator := math.Sqrt(sumSq1 * sumSq2)
+	if denominator == 0 {
+		return 0
+	}
+	return numerator / denominator
+}
+
+// kendallTau calculates Kendall's tau-b, which corrects for ties, by
+// comparing every pair of rows. Returns 0 if either side is all ties.
+func kendallTau(x, y []float64) float64 {
+	var concordant, discordant, tiesX, tiesY float64
+	for i := 0; i < len(x); i++ {
+		for j := i + 1; j < len(x); j++ {
+			dx := compareFloat64(x[i], x[j])
+			dy := compareFloat64(y[i], y[j])
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tiesX++
+			case dy == 0:
+				tiesY++
+			case dx == dy:
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+
+	denominator := math.Sqrt((concordant + discordant + tiesX) * (concordant + discordant + tiesY))
+	if denominator == 0 {
+		return 0
+	}
+	return (concordant - discordant) / denominator
+}
+
 // NumericSummary provides a quick summary of a numeric column
 func (df *DataFrame) NumericSummary(column string) (*NumericStats, error) {
 	if df.err != nil {
@@ -678,3 +1437,102 @@ func (ns *NumericStats) String() string {
   Median: %.6f`,
 		ns.Column, ns.Count, ns.Sum, ns.Mean, ns.Std, ns.Min, ns.Max, ns.Median)
 }
+
+// Rank appends a float64 column "<column>_rank" holding each row's 1-based
+// rank within the column. The method decides how ties are ranked:
+// "average" (mean of the tied positions), "min", "max", "dense" (like min,
+// but ranks increase by one between groups), or "first" (tied rows ranked
+// in row order). NaN values get a NaN rank.
+func (df *DataFrame) Rank(column, method string, ascending bool) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return df.setOpError("Rank", err)
+	}
+
+	series := df.columns[column]
+	ranks, err := rankSeries(series, method, ascending)
+	if err != nil {
+		return df.setError(wrapColumnError("Rank", column, err))
+	}
+
+	ranked, err := newSeriesOwned(column+"_rank", ranks)
+	if err != nil {
+		return df.setError(wrapColumnError("Rank", column, err))
+	}
+	return df.withAppendedColumn(ranked, "Rank")
+}
+
+// rankSeries computes the ranks of a series' values with the given tie method.
+func rankSeries(series *Series, method string, ascending bool) ([]float64, error) {
+	switch method {
+	case "average", "min", "max", "dense", "first":
+	default:
+		return nil, newOpError("Rank", fmt.Sprintf("unsupported rank method: %s", method))
+	}
+
+	compare := typedComparator(series)
+	if compare == nil {
+		return nil, newOpError("Rank", "unsupported column type for ranking")
+	}
+
+	ranks := make([]float64, series.Length)
+	rows := make([]int, 0, series.Length)
+	if series.Type == Float64Type {
+		for i, v := range series.Data.([]float64) {
+			if math.IsNaN(v) {
+				ranks[i] = math.NaN()
+			} else {
+				rows = append(rows, i)
+			}
+		}
+	} else {
+		for i := 0; i < series.Length; i++ {
+			rows = append(rows, i)
+		}
+	}
+
+	// rows is in ascending row order, so breaking ties on the row index
+	// keeps "first" ranking tied rows in row order
+	sort.Slice(rows, func(i, j int) bool {
+		cmp := compare(rows[i], rows[j])
+		if cmp != 0 {
+			if ascending {
+				return cmp < 0
+			}
+			return cmp > 0
+		}
+		return rows[i] < rows[j]
+	})
+
+	dense := 0
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && compare(rows[start], rows[end]) == 0 {
+			end++
+		}
+		dense++
+
+		for pos := start; pos < end; pos++ {
+			var rank float64
+			switch method {
+			case "average":
+				rank = float64(start+1+end) / 2
+			case "min":
+				rank = float64(start + 1)
+			case "max":
+				rank = float64(end)
+			case "dense":
+				rank = float64(dense)
+			case "first":
+				rank = float64(pos + 1)
+			}
+			ranks[rows[pos]] = rank
+		}
+		start = end
+	}
+
+	return ranks, nil
+}
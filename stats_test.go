@@ -1,8 +1,12 @@
 package otters
 
 import (
+	"errors"
+	"math"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDataFrame_Count(t *testing.T) {
@@ -414,3 +418,584 @@ func TestStatsOperations(t *testing.T) {
 		t.Errorf("NumericSummary.Max = %v, want 50", ns.Max)
 	}
 }
+
+func TestRankMethods(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"score": []int64{30, 10, 30, 20, 30},
+	})
+
+	cases := []struct {
+		method    string
+		ascending bool
+		want      []float64
+	}{
+		{"average", true, []float64{4, 1, 4, 2, 4}},
+		{"min", true, []float64{3, 1, 3, 2, 3}},
+		{"max", true, []float64{5, 1, 5, 2, 5}},
+		{"dense", true, []float64{3, 1, 3, 2, 3}},
+		{"first", true, []float64{3, 1, 4, 2, 5}},
+		{"first", false, []float64{1, 5, 2, 4, 3}},
+		{"dense", false, []float64{1, 3, 1, 2, 1}},
+	}
+	for _, c := range cases {
+		result := df.Rank("score", c.method, c.ascending)
+		series, err := result.GetSeries("score_rank")
+		if err != nil {
+			t.Fatalf("%s: %v", c.method, err)
+		}
+		got := series.Float64Slice()
+		for i := range c.want {
+			if got[i] != c.want[i] {
+				t.Errorf("Rank(%s, asc=%v) = %v, want %v", c.method, c.ascending, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestRankNaNAndStrings(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"x":    []float64{2.5, math.NaN(), 1.5},
+		"name": []string{"carol", "alice", "bob"},
+	})
+
+	x, _ := df.Rank("x", "average", true).GetSeries("x_rank")
+	got := x.Float64Slice()
+	if got[0] != 2 || !math.IsNaN(got[1]) || got[2] != 1 {
+		t.Errorf("NaN ranking = %v, want [2 NaN 1]", got)
+	}
+
+	names, _ := df.Rank("name", "min", true).GetSeries("name_rank")
+	if got := names.Float64Slice(); got[0] != 3 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("string ranking = %v, want [3 1 2]", got)
+	}
+
+	if df.Rank("x", "median", true).Error() == nil {
+		t.Error("expected error for unknown method")
+	}
+	if df.Rank("missing", "min", true).Error() == nil {
+		t.Error("expected error for missing column")
+	}
+}
+
+func TestCovariance(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"x":    []int64{1, 2, 3, 4},
+		"y":    []float64{2, 4, 6, 8},
+		"name": []string{"a", "b", "c", "d"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cov, err := df.Cov("x", "y")
+	if err != nil {
+		t.Fatalf("Cov error: %v", err)
+	}
+	if math.Abs(cov-10.0/3) > 1e-12 {
+		t.Errorf("Cov(x, y) = %v, want %v", cov, 10.0/3)
+	}
+	if _, err := df.Cov("x", "name"); err == nil {
+		t.Error("expected error for non-numeric column")
+	}
+
+	matrix, err := df.CovMatrix()
+	if err != nil {
+		t.Fatalf("CovMatrix error: %v", err)
+	}
+	if got := matrix.Columns(); !slices.Equal(got, []string{"column", "x", "y"}) {
+		t.Fatalf("CovMatrix columns = %v", got)
+	}
+	variance, _ := df.Var("x")
+	if v, _ := matrix.Get(0, "x"); math.Abs(v.(float64)-variance) > 1e-12 {
+		t.Errorf("CovMatrix diagonal = %v, want Var(x) = %v", v, variance)
+	}
+	if v, _ := matrix.Get(1, "x"); math.Abs(v.(float64)-cov) > 1e-12 {
+		t.Errorf("CovMatrix[y][x] = %v, want %v", v, cov)
+	}
+}
+
+func TestGeoMeanHarmonicMean(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"growth": []float64{1.1, 1.5, 0.9},
+		"speed":  []int64{40, 60, 60},
+		"delta":  []float64{1, 0, -1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	geo, err := df.GeoMean("growth")
+	if err != nil {
+		t.Fatalf("GeoMean error: %v", err)
+	}
+	if want := math.Cbrt(1.1 * 1.5 * 0.9); math.Abs(geo-want) > 1e-12 {
+		t.Errorf("GeoMean = %v, want %v", geo, want)
+	}
+
+	harmonic, err := df.HarmonicMean("speed")
+	if err != nil {
+		t.Fatalf("HarmonicMean error: %v", err)
+	}
+	if math.Abs(harmonic-360.0/7) > 1e-12 {
+		t.Errorf("HarmonicMean = %v, want %v", harmonic, 360.0/7)
+	}
+
+	for _, stat := range []func(string) (float64, error){df.GeoMean, df.HarmonicMean} {
+		_, err := stat("delta")
+		oe, ok := err.(*OtterError)
+		if !ok || oe.Row != 1 {
+			t.Errorf("expected error at row 1 for zero value, got %v", err)
+		}
+	}
+}
+
+func TestQuantilesMethods(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"x": []int64{40, 10, 30, 20},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	qs := []float64{0, 0.5, 0.9, 1}
+	cases := map[string][]float64{
+		"linear":  {10, 25, 37, 40},
+		"nearest": {10, 30, 40, 40},
+		"lower":   {10, 20, 30, 40},
+		"higher":  {10, 30, 40, 40},
+	}
+	for method, want := range cases {
+		got, err := df.Quantiles("x", qs, method)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		for i := range want {
+			if math.Abs(got[i]-want[i]) > 1e-9 {
+				t.Errorf("%s: Quantiles = %v, want %v", method, got, want)
+				break
+			}
+		}
+	}
+
+	if q, _ := df.Quantile("x", 0.9); math.Abs(q-37) > 1e-9 {
+		t.Errorf("Quantile(0.9) = %v, want 37", q)
+	}
+	if _, err := df.Quantiles("x", qs, "cubic"); err == nil {
+		t.Error("expected error for unknown method")
+	}
+	if _, err := df.Quantiles("x", []float64{1.5}, "linear"); err == nil {
+		t.Error("expected error for quantile above 1")
+	}
+}
+
+func TestStdVarDDof(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"x": []int64{2, 4, 4, 4, 5, 5, 7, 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdP, err := df.StdP("x")
+	if err != nil || stdP != 2 {
+		t.Errorf("StdP = %v, %v; want 2", stdP, err)
+	}
+	if varP, _ := df.VarP("x"); varP != 4 {
+		t.Errorf("VarP = %v, want 4", varP)
+	}
+	if v, _ := df.VarDDof("x", 1); math.Abs(v-32.0/7) > 1e-12 {
+		t.Errorf("VarDDof(1) = %v, want %v", v, 32.0/7)
+	}
+	std, _ := df.Std("x")
+	if sd, _ := df.StdDDof("x", 1); sd != std {
+		t.Errorf("StdDDof(1) = %v, want Std = %v", sd, std)
+	}
+
+	if _, err := df.StdDDof("x", 8); err == nil {
+		t.Error("expected error for ddof >= n")
+	}
+	if _, err := df.VarDDof("x", -1); err == nil {
+		t.Error("expected error for negative ddof")
+	}
+}
+
+func TestDescribeIncludeAll(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"city":  []string{"Oslo", "Rome", "Oslo", "Lima"},
+		"ok":    []bool{true, false, true, true},
+		"sales": []int64{1, 2, 3, 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numericOnly, err := df.Describe()
+	if err != nil {
+		t.Fatalf("Describe error: %v", err)
+	}
+	if numericOnly.Width() != 2 {
+		t.Errorf("Describe() should only summarize sales, got columns %v", numericOnly.Columns())
+	}
+
+	desc, err := df.DescribeWithOptions(DescribeOptions{IncludeAll: true})
+	if err != nil {
+		t.Fatalf("DescribeWithOptions error: %v", err)
+	}
+	if got := desc.Columns(); !slices.Equal(got, []string{"statistic", "city", "ok", "sales"}) {
+		t.Fatalf("columns = %v", got)
+	}
+
+	want := map[string][]string{
+		"city":  {"4", "3", "Oslo", "2"},
+		"ok":    {"4", "2", "true", "3"},
+		"sales": {"4", "NaN", "NaN", "NaN"},
+	}
+	for column, values := range want {
+		got := desc.columns[column].StringSlice()[:4]
+		if !slices.Equal(got, values) {
+			t.Errorf("%s count/unique/top/freq = %v, want %v", column, got, values)
+		}
+	}
+	if mean := desc.columns["sales"].StringSlice()[4]; mean != "2.500000" {
+		t.Errorf("sales mean = %s, want 2.500000", mean)
+	}
+	if mean := desc.columns["city"].StringSlice()[4]; mean != "NaN" {
+		t.Errorf("city mean = %s, want NaN", mean)
+	}
+}
+
+func TestCorrelationMethods(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"x": []float64{1, 2, 3, 4, 5},
+		"y": []float64{1, 8, 27, 64, 125}, // monotonic but not linear
+		"z": []float64{2, 1, 4, 3, 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(method, row, column string) float64 {
+		t.Helper()
+		matrix, err := df.CorrelationWith(method)
+		if err != nil {
+			t.Fatalf("CorrelationWith(%s) error: %v", method, err)
+		}
+		for i, label := range matrix.columns["column"].StringSlice() {
+			if label == row {
+				return matrix.columns[column].Float64Slice()[i]
+			}
+		}
+		t.Fatalf("row %s not found", row)
+		return 0
+	}
+
+	if p := get("pearson", "x", "y"); p >= 0.99 {
+		t.Errorf("pearson(x, y) = %v, want below 0.99", p)
+	}
+	if s := get("spearman", "x", "y"); math.Abs(s-1) > 1e-12 {
+		t.Errorf("spearman(x, y) = %v, want 1", s)
+	}
+	if k := get("kendall", "x", "y"); math.Abs(k-1) > 1e-12 {
+		t.Errorf("kendall(x, y) = %v, want 1", k)
+	}
+	// z has 8 concordant and 2 discordant pairs with x
+	if k := get("kendall", "x", "z"); math.Abs(k-0.6) > 1e-12 {
+		t.Errorf("kendall(x, z) = %v, want 0.6", k)
+	}
+	if s := get("spearman", "x", "z"); math.Abs(s-0.8) > 1e-12 {
+		t.Errorf("spearman(x, z) = %v, want 0.8", s)
+	}
+
+	if _, err := df.CorrelationWith("distance"); err == nil {
+		t.Error("expected error for unknown method")
+	}
+}
+
+func TestStatsSkipNA(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "x", []float64{4, math.NaN(), 1, 7}),
+		mustSeries(t, "none", []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()}),
+		mustSeries(t, "n", []int64{1<<62 + 1, 1 << 62, 3, 2}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// By default a NaN makes every result NaN
+	sum, _ := df.Sum("x")
+	mean, _ := df.Mean("x")
+	std, _ := df.Std("x")
+	min, _ := df.Min("x")
+	max, _ := df.Max("x")
+	for name, v := range map[string]any{"Sum": sum, "Mean": mean, "Std": std, "Min": min, "Max": max} {
+		if !math.IsNaN(v.(float64)) {
+			t.Errorf("%s = %v, want NaN", name, v)
+		}
+	}
+
+	skip := StatOptions{SkipNA: true}
+	if sum, _ := df.SumWithOptions("x", skip); sum != 12 {
+		t.Errorf("Sum = %v, want 12", sum)
+	}
+	if mean, _ := df.MeanWithOptions("x", skip); mean != 4 {
+		t.Errorf("Mean = %v, want 4", mean)
+	}
+	if std, _ := df.StdWithOptions("x", skip); std != 3 {
+		t.Errorf("Std = %v, want 3", std)
+	}
+	if min, _ := df.MinWithOptions("x", skip); min != 1.0 {
+		t.Errorf("Min = %v, want 1", min)
+	}
+	if max, _ := df.MaxWithOptions("x", skip); max != 7.0 {
+		t.Errorf("Max = %v, want 7", max)
+	}
+
+	if sum, err := df.SumWithOptions("none", skip); err != nil || sum != 0 {
+		t.Errorf("Sum of only NaN = %v, %v; want 0", sum, err)
+	}
+	if _, err := df.MeanWithOptions("none", skip); err == nil {
+		t.Error("Mean of only NaN: expected an error")
+	}
+	if _, err := df.MaxWithOptions("none", skip); err == nil {
+		t.Error("Max of only NaN: expected an error")
+	}
+
+	// int64 extremes are exact
+	if max, _ := df.Max("n"); max != int64(1<<62+1) {
+		t.Errorf("Max = %v, want %d", max, int64(1<<62+1))
+	}
+}
+
+func TestStatsInfinity(t *testing.T) {
+	df, err := ReadCSVFromString("x,y\n1,2\n2,4\ninf,6\n3,7\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = df.Describe()
+	var oe *OtterError
+	if !errors.As(err, &oe) || oe.Column != "x" || oe.Row != 2 {
+		t.Errorf("Describe error = %v, want column x row 2", err)
+	}
+	if _, err := df.Correlation(); err == nil {
+		t.Error("Correlation: expected an error")
+	}
+
+	desc, err := df.DescribeWithOptions(DescribeOptions{SkipInf: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := desc.Get(0, "x"); count != "3" {
+		t.Errorf("x count = %v, want 3", count)
+	}
+	if mean, _ := desc.Get(1, "x"); mean != "2.000000" {
+		t.Errorf("x mean = %v, want 2.000000", mean)
+	}
+	if count, _ := desc.Get(0, "y"); count != "4" {
+		t.Errorf("y count = %v, want 4", count)
+	}
+
+	corr, err := df.CorrelationWithOptions(CorrelationOptions{SkipInf: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without the infinite row, x = 1, 2, 3 and y = 2, 4, 7
+	want := pearson([]float64{1, 2, 3}, []float64{2, 4, 7})
+	if got, _ := corr.Get(0, "y"); math.Abs(got.(float64)-want) > 1e-12 {
+		t.Errorf("corr(x, y) = %v, want %v", got, want)
+	}
+
+	fixed := df.ReplaceInf("x", 4)
+	if _, err := fixed.Describe(); err != nil {
+		t.Errorf("Describe after ReplaceInf: %v", err)
+	}
+}
+
+func TestValueCountsOptions(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "code", []int64{3, 1, 3, 2, 1, 3}),
+		mustSeries(t, "score", []float64{1, math.NaN(), 2, 9, math.NaN(), 10}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := df.ValueCounts("code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ, _ := counts.GetColumnType("code"); typ != Int64Type {
+		t.Errorf("value column type = %s, want int64", typ)
+	}
+	values, _ := counts.GetSeries("code")
+	if got := values.Data.([]int64); !slices.Equal(got, []int64{3, 1, 2}) {
+		t.Errorf("values = %v, want [3 1 2]", got)
+	}
+
+	ascending, _ := df.ValueCountsWithOptions("code", ValueCountsOptions{Ascending: true, Normalize: true})
+	values, _ = ascending.GetSeries("code")
+	proportions, _ := ascending.GetSeries("proportion")
+	if got := values.Data.([]int64); !slices.Equal(got, []int64{2, 1, 3}) {
+		t.Errorf("ascending values = %v, want [2 1 3]", got)
+	}
+	if got := proportions.Data.([]float64); !slices.Equal(got, []float64{1.0 / 6, 2.0 / 6, 3.0 / 6}) {
+		t.Errorf("proportions = %v", got)
+	}
+
+	// NaN is counted as one value unless dropped; ties keep first appearance
+	withNA, _ := df.ValueCounts("score")
+	if withNA.Len() != 5 {
+		t.Errorf("with NaN: %d rows, want 5", withNA.Len())
+	}
+	if first, _ := withNA.Get(0, "score"); !math.IsNaN(first.(float64)) {
+		t.Errorf("most frequent score = %v, want NaN", first)
+	}
+	dropped, _ := df.ValueCountsWithOptions("score", ValueCountsOptions{DropNA: true})
+	values, _ = dropped.GetSeries("score")
+	if got := values.Data.([]float64); !slices.Equal(got, []float64{1, 2, 9, 10}) {
+		t.Errorf("DropNA values = %v, want [1 2 9 10]", got)
+	}
+
+	binned, err := df.ValueCountsWithOptions("score", ValueCountsOptions{Bins: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels, _ := binned.GetSeries("score")
+	binCounts, _ := binned.GetSeries("count")
+	if got := labels.Data.([]string); !slices.Equal(got, []string{"[1, 4]", "(7, 10]", "(4, 7]"}) {
+		t.Errorf("bins = %v", got)
+	}
+	if got := binCounts.Data.([]int64); !slices.Equal(got, []int64{2, 2, 0}) {
+		t.Errorf("bin counts = %v, want [2 2 0]", got)
+	}
+
+	if _, err := df.ValueCountsWithOptions("code", ValueCountsOptions{Bins: 2}); err != nil {
+		t.Errorf("bins on int64: %v", err)
+	}
+	text, _ := NewDataFrameFromSeries(mustSeries(t, "s", []string{"a"}))
+	if _, err := text.ValueCountsWithOptions("s", ValueCountsOptions{Bins: 2}); err == nil {
+		t.Error("bins on a string column: expected an error")
+	}
+}
+
+func TestNUnique(t *testing.T) {
+	day := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "code", []int64{3, 1, 3, 2}),
+		mustSeries(t, "score", []float64{1, math.NaN(), 1, math.NaN()}),
+		mustSeries(t, "seen", []time.Time{day, day.In(time.FixedZone("X", 3600)), {}, day.Add(time.Hour)}),
+		mustSeries(t, "ok", []bool{true, true, true, true}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for column, want := range map[string]int{"code": 3, "score": 1, "seen": 2, "ok": 1} {
+		if got, err := df.NUnique(column); err != nil || got != want {
+			t.Errorf("NUnique(%s) = %d, %v; want %d", column, got, err, want)
+		}
+	}
+	if _, err := df.NUnique("missing"); err == nil {
+		t.Error("missing column: expected an error")
+	}
+
+	all, err := df.NUniqueAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns, _ := all.GetSeries("column")
+	counts, _ := all.GetSeries("nunique")
+	if got := columns.Data.([]string); !slices.Equal(got, []string{"code", "score", "seen", "ok"}) {
+		t.Errorf("columns = %v", got)
+	}
+	if got := counts.Data.([]int64); !slices.Equal(got, []int64{3, 1, 2, 1}) {
+		t.Errorf("nunique = %v, want [3 1 2 1]", got)
+	}
+}
+
+func TestCountSumWhere(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "region", []string{"North", "South", "North", "East"}),
+		mustSeries(t, "sales", []float64{100, 250, 300, 80}),
+		mustSeries(t, "units", []int64{1, 2, 3, 4}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := df.CountWhere("region", "==", "North"); err != nil || n != 2 {
+		t.Errorf("CountWhere = %d, %v; want 2", n, err)
+	}
+	if n, _ := df.CountWhere("sales", ">", 1000.0); n != 0 {
+		t.Errorf("CountWhere with no match = %d, want 0", n)
+	}
+
+	for _, c := range []struct {
+		sumCol, condCol, op string
+		value               any
+		want                float64
+	}{
+		{"sales", "region", "==", "North", 400},
+		{"units", "sales", ">=", 250.0, 5},
+		{"sales", "region", "in", []string{"South", "East"}, 330},
+	} {
+		got, err := df.SumWhere(c.sumCol, c.condCol, c.op, c.value)
+		if err != nil {
+			t.Fatalf("SumWhere(%s, %s %s %v): %v", c.sumCol, c.condCol, c.op, c.value, err)
+		}
+		filtered, _ := df.Filter(c.condCol, c.op, c.value).Sum(c.sumCol)
+		if got != c.want || got != filtered {
+			t.Errorf("SumWhere(%s, %s %s %v) = %v, want %v (Filter+Sum %v)", c.sumCol, c.condCol, c.op, c.value, got, c.want, filtered)
+		}
+	}
+
+	if _, err := df.SumWhere("region", "sales", ">", 0.0); err == nil {
+		t.Error("SumWhere of a string column: expected an error")
+	}
+	if _, err := df.CountWhere("region", "==", 1.0); err == nil {
+		t.Error("CountWhere comparing a string column with a number: expected an error")
+	}
+	if _, err := df.CountWhere("missing", "==", 1); err == nil {
+		t.Error("CountWhere on a missing column: expected an error")
+	}
+}
+
+func TestIdxMinMax(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "day", []string{"mon", "tue", "wed", "thu"}),
+		mustSeries(t, "sales", []float64{math.NaN(), 7, 2, 7}),
+		mustSeries(t, "big", []int64{1<<62 + 1, 1 << 62, 3, 1<<62 + 1}),
+		mustSeries(t, "none", []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		column   string
+		min, max int
+	}{
+		{"sales", 2, 1}, // NaN skipped, first of the tied maxima
+		{"big", 2, 0},   // int64 compared exactly
+	}
+	for _, c := range cases {
+		if got, err := df.IdxMin(c.column); err != nil || got != c.min {
+			t.Errorf("IdxMin(%s) = %d, %v; want %d", c.column, got, err, c.min)
+		}
+		if got, err := df.IdxMax(c.column); err != nil || got != c.max {
+			t.Errorf("IdxMax(%s) = %d, %v; want %d", c.column, got, err, c.max)
+		}
+	}
+
+	peak, _ := df.IdxMax("sales")
+	if row, _ := df.RowAt(peak); row.String("day") != "tue" {
+		t.Errorf("peak day = %s, want tue", row.String("day"))
+	}
+
+	for _, column := range []string{"none", "day", "missing"} {
+		if _, err := df.IdxMax(column); err == nil {
+			t.Errorf("IdxMax(%s): expected an error", column)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package otters
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// NLargest returns the n rows with the largest values in the column,
+// largest first. Tied rows keep their original order and missing values
+// are skipped. It keeps only the current top n rows in a heap while
+// scanning, so it is much cheaper than Sort followed by Head when n is
+// small.
+func (df *DataFrame) NLargest(n int, column string) *DataFrame {
+	return df.topN(n, column, true, "NLargest")
+}
+
+// NSmallest returns the n rows with the smallest values in the column,
+// smallest first. See NLargest.
+func (df *DataFrame) NSmallest(n int, column string) *DataFrame {
+	return df.topN(n, column, false, "NSmallest")
+}
+
+// topN selects the n best rows by the column, largest or smallest first.
+func (df *DataFrame) topN(n int, column string, largest bool, operation string) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	if n <= 0 {
+		return df.setError(newOpError(operation, "n must be positive"))
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return df.setOpError(operation, err)
+	}
+
+	series := df.columns[column]
+	compare := typedComparator(series)
+	if compare == nil {
+		return df.setError(newColumnError(operation, column,
+			fmt.Sprintf("unsupported column type %s", series.Type)))
+	}
+
+	// better reports whether row a ranks ahead of row b; earlier rows win ties
+	better := func(a, b int) bool {
+		if cmp := compare(a, b); cmp != 0 {
+			return cmp > 0 == largest
+		}
+		return a < b
+	}
+
+	isNull := nullPredicate(series)
+	h := &rowHeap{rows: make([]int, 0, min(n, df.length)), worse: func(a, b int) bool { return better(b, a) }}
+	for row := 0; row < df.length; row++ {
+		if isNull(row) {
+			continue
+		}
+		if len(h.rows) < n {
+			heap.Push(h, row)
+		} else if better(row, h.rows[0]) {
+			h.rows[0] = row
+			heap.Fix(h, 0)
+		}
+	}
+
+	indices := h.rows
+	sort.Slice(indices, func(i, j int) bool { return better(indices[i], indices[j]) })
+	return df.selectRows(indices, operation)
+}
+
+// rowHeap is a heap of row indices with the worst row at the root.
+type rowHeap struct {
+	rows  []int
+	worse func(a, b int) bool
+}
+
+func (h *rowHeap) Len() int           { return len(h.rows) }
+func (h *rowHeap) Less(i, j int) bool { return h.worse(h.rows[i], h.rows[j]) }
+func (h *rowHeap) Swap(i, j int)      { h.rows[i], h.rows[j] = h.rows[j], h.rows[i] }
+func (h *rowHeap) Push(x any)         { h.rows = append(h.rows, x.(int)) }
+
+func (h *rowHeap) Pop() any {
+	last := h.rows[len(h.rows)-1]
+	h.rows = h.rows[:len(h.rows)-1]
+	return last
+}
@@ -0,0 +1,48 @@
+package otters
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNLargestNSmallest(t *testing.T) {
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "name", []string{"a", "b", "c", "d", "e", "f"}),
+		mustSeries(t, "score", []float64{3, 9, math.NaN(), 7, 9, 1}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		result *DataFrame
+		want   []string
+	}{
+		{"NLargest(3)", df.NLargest(3, "score"), []string{"b", "e", "d"}},
+		{"NSmallest(2)", df.NSmallest(2, "score"), []string{"f", "a"}},
+		{"NLargest(10)", df.NLargest(10, "score"), []string{"b", "e", "d", "a", "f"}},
+		{"NSmallest(name)", df.NSmallest(2, "name"), []string{"a", "b"}},
+	}
+	for _, c := range cases {
+		if c.result.Error() != nil {
+			t.Fatalf("%s error: %v", c.name, c.result.Error())
+		}
+		if got := c.result.columns["name"].StringSlice(); !slices.Equal(got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	if df.NLargest(0, "score").Error() == nil {
+		t.Error("expected error for n = 0")
+	}
+	if df.NLargest(1, "missing").Error() == nil {
+		t.Error("expected error for missing column")
+	}
+	missing, _ := NewDataFrameFromSeries(mustSeries(t, "score", []float64{math.NaN(), math.NaN()}))
+	top := missing.WithRowIndex().NLargest(1, "score")
+	if levels := top.IndexLevels(); top.Len() != 0 || levels[0].Length != 0 {
+		t.Errorf("NLargest of missing values = %d rows, %d index labels; want none", top.Len(), levels[0].Length)
+	}
+}
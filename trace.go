@@ -0,0 +1,93 @@
+package otters
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TraceStep is one operation in an ErrorTrace: the operation that failed,
+// or one that wrapped its error, with the shape of the DataFrame it was
+// given.
+type TraceStep struct {
+	Op      string // Operation name, as in OtterError.Op
+	Args    string // Column, row and query token named by the error, if any
+	Rows    int    // Rows of the operation's input
+	Columns int    // Columns of the operation's input
+}
+
+// String formats the step as "Op(args) on <rows>x<columns>"
+func (s TraceStep) String() string {
+	return fmt.Sprintf("%s(%s) on %dx%d", s.Op, s.Args, s.Rows, s.Columns)
+}
+
+// ErrorTrace returns the operations that produced the DataFrame's error:
+// the call that failed, followed by any that wrapped its error, such as
+// Assign around a failing Arith inside one of its functions. Later steps of
+// a fluent chain pass the error on unchanged and are not listed, so the
+// first step is where the chain broke:
+//
+//	result := df.Filter("price", ">", 0).Select("region", "pirce").Sort("region", true)
+//	for _, step := range result.ErrorTrace() {
+//		fmt.Println(step) // Select(column=pirce) on 120x6
+//	}
+//
+// It returns nil if the DataFrame has no error, or if the error did not
+// come from an otters operation (such as a canceled context).
+func (df *DataFrame) ErrorTrace() []TraceStep {
+	return traceOf(df.err)
+}
+
+// traceOf returns the trace of the first OtterError in err's chain that
+// has one.
+func traceOf(err error) []TraceStep {
+	for err != nil {
+		var e *OtterError
+		if !errors.As(err, &e) {
+			return nil
+		}
+		if e.trace != nil {
+			return e.trace
+		}
+		err = e.Cause
+	}
+	return nil
+}
+
+// withTraceStep returns err with a step for the operation that failed on
+// input added to its trace. The step is named op, or the error's own Op if
+// op is empty, and lists the error's column, row and token. If the error
+// already ends in a step for the same operation, that step is replaced, so
+// the step shows the caller's own input. The error is copied, not modified.
+func withTraceStep(err error, op string, input *DataFrame) error {
+	e, ok := err.(*OtterError)
+	if !ok {
+		return err
+	}
+	if op == "" {
+		op = e.Op
+	}
+	inner := e.trace
+	if inner == nil {
+		inner = traceOf(e.Cause)
+	}
+	if len(inner) > 0 && inner[len(inner)-1].Op == op {
+		inner = inner[:len(inner)-1]
+	}
+
+	var args []string
+	if e.Column != "" {
+		args = append(args, "column="+e.Column)
+	}
+	if e.Row >= 0 {
+		args = append(args, fmt.Sprintf("row=%d", e.Row))
+	}
+	if e.Token != "" {
+		args = append(args, fmt.Sprintf("token=%q", e.Token))
+	}
+	step := TraceStep{Op: op, Args: strings.Join(args, ", "), Rows: input.length, Columns: len(input.order)}
+
+	traced := *e
+	traced.trace = append(inner[:len(inner):len(inner)], step)
+	return &traced
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -36,12 +37,21 @@ func (ct ColumnType) String() string {
 	}
 }
 
-// Series represents a single column of data with a specific type
+// Series represents a single column of data with a specific type.
+//
+// DataFrame operations that keep a column unchanged (Copy, Select, Drop,
+// AddColumn, ...) share its data between Series instead of copying it.
+// Set copies shared data before writing, so a change through one Series is
+// never visible through another; writing into Data directly bypasses this
+// and is not supported.
 type Series struct {
 	Name   string     // Column name
 	Type   ColumnType // Data type
 	Data   any        // Actual data: []string, []int64, []float64, []bool, []time.Time
 	Length int        // Number of elements
+
+	shared *atomic.Bool // set once Data is shared with another Series
+	dict   *stringDict  // Dictionary encoding from InternStrings (nil = none)
 }
 
 // NewSeries creates a new Series with the given name and data.
@@ -60,8 +70,9 @@ func NewSeries(name string, data any) (*Series, error) {
 // the slice after handing it over.
 func newSeriesOwned(name string, data any) (*Series, error) {
 	s := &Series{
-		Name: name,
-		Data: data,
+		Name:   name,
+		Data:   data,
+		shared: new(atomic.Bool),
 	}
 
 	// Determine type and length based on data
@@ -196,6 +207,9 @@ func (s *Series) Set(index int, value any) error {
 		}
 	}
 
+	s.own()
+	s.dict = nil
+
 	switch s.Type {
 	case StringType:
 		if v, ok := value.(string); ok {
@@ -264,6 +278,7 @@ func (s *Series) Copy() *Series {
 		Name:   s.Name,
 		Type:   s.Type,
 		Length: s.Length,
+		shared: new(atomic.Bool),
 	}
 
 	// Deep copy the data slice
@@ -293,21 +308,75 @@ func (s *Series) Copy() *Series {
 	return newSeries
 }
 
+// share returns a new Series backed by the same data, without copying it.
+// Both Series then copy the data before their next Set.
+func (s *Series) share() *Series {
+	if s.shared == nil {
+		return s.Copy()
+	}
+	s.shared.Store(true)
+	return &Series{Name: s.Name, Type: s.Type, Data: s.Data, Length: s.Length, shared: s.shared, dict: s.dict}
+}
+
+// view returns a Series over rows start to end (exclusive) that re-slices
+// the data instead of copying it. Like share, both Series copy the data
+// before their next Set.
+func (s *Series) view(start, end int) *Series {
+	if s.shared == nil {
+		s = s.Copy()
+	}
+	s.shared.Store(true)
+
+	var data any
+	var dict *stringDict
+	switch d := s.Data.(type) {
+	case []string:
+		sub := d[start:end:end]
+		data = sub
+		if sd := s.dictionary(); sd != nil {
+			dict = sd.view(sub, start, end)
+		}
+	case []int64:
+		data = d[start:end:end]
+	case []float64:
+		data = d[start:end:end]
+	case []bool:
+		data = d[start:end:end]
+	case []time.Time:
+		data = d[start:end:end]
+	}
+	return &Series{Name: s.Name, Type: s.Type, Data: data, Length: end - start, shared: s.shared, dict: dict}
+}
+
+// own gives the Series a private copy of its data if the data may be
+// shared, so it can be written in place.
+func (s *Series) own() {
+	if s.shared == nil || s.shared.Load() {
+		owned := s.Copy()
+		s.Data, s.shared = owned.Data, owned.shared
+	}
+}
+
 // DataFrame represents a collection of Series with aligned indices
 type DataFrame struct {
 	columns map[string]*Series // Column name -> Series mapping
 	order   []string           // Maintains column order
 	length  int                // Number of rows
+	index   []*Series          // Optional row index levels carried through row selections (nil = none)
 	err     error              // Error state for chaining operations
+
+	warnings    []Warning             // Non-fatal events, carried like index (see Warnings)
+	hashIndexes map[string]*hashIndex // Column name -> hash index (see CreateIndex)
 }
 
 // NewDataFrame creates a new empty DataFrame
 func NewDataFrame() *DataFrame {
 	return &DataFrame{
-		columns: make(map[string]*Series),
-		order:   make([]string, 0),
-		length:  0,
-		err:     nil,
+		columns:     make(map[string]*Series),
+		order:       make([]string, 0),
+		length:      0,
+		err:         nil,
+		hashIndexes: make(map[string]*hashIndex),
 	}
 }
 
@@ -515,8 +584,23 @@ func parseTimeValue(value string) (time.Time, error) {
 
 // CSVOptions provides options for CSV reading/writing
 type CSVOptions struct {
-	HasHeader bool // Whether the first row contains headers
-	Delimiter rune // Field delimiter (default: ',')
-	SkipRows  int  // Number of rows to skip at the beginning
-	MaxRows   int  // Maximum number of rows to read (0 = unlimited)
+	HasHeader        bool                  // Whether the first row contains headers
+	Delimiter        rune                  // Field delimiter (default: ',')
+	SkipRows         int                   // Number of rows to skip at the beginning
+	MaxRows          int                   // Maximum number of rows to read (0 = unlimited)
+	Progress         ProgressFunc          // Called with bytes read and total bytes while reading (nil = none)
+	Schema           Schema                // Expected columns and types for ReadCSV; nil = infer types
+	DuplicateColumns DuplicateColumnPolicy // What to do with repeated header names (default: error)
+	FloatPrecision   int                   // Digits after the decimal point when writing float64 values (0 = shortest exact form)
+	FloatScientific  bool                  // Write float64 values in scientific notation, e.g. 1.5e+06
+	TimeLayout       string                // time.Format layout for writing time values ("" = "2006-01-02 15:04:05")
+	Atomic           bool                  // Write to a temporary file renamed over the target on success, so a failed write leaves no partial file
+}
+
+// delimiter returns the field delimiter, ',' if none is set
+func (o CSVOptions) delimiter() rune {
+	if o.Delimiter == 0 {
+		return ','
+	}
+	return o.Delimiter
 }
@@ -0,0 +1,209 @@
+package otters
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Rule is one data-quality check on a column, run by a Validator. Build
+// rules with NotNull, Unique, InRange, Matches and References.
+type Rule struct {
+	Column string // Column the rule checks
+	Name   string // Rule name reported with each violation, e.g. "not_null"
+
+	// check calls violate for every failing row of the column, or returns an
+	// error if the rule cannot apply to it.
+	check func(series *Series, violate func(row int, message string)) error
+}
+
+// NotNull requires every value of column to be present: no NaN in a float64
+// column and no zero time in a time column. Columns of other types cannot
+// hold a missing value and always pass.
+func NotNull(column string) Rule {
+	return Rule{Column: column, Name: "not_null", check: func(series *Series, violate func(int, string)) error {
+		isNull := nullPredicate(series)
+		for row := 0; row < series.Length; row++ {
+			if isNull(row) {
+				violate(row, "missing value")
+			}
+		}
+		return nil
+	}}
+}
+
+// Unique requires the values of column to be distinct. Every row repeating
+// an earlier value is a violation; the first occurrence is not. Missing
+// values are left to NotNull and never duplicate each other.
+func Unique(column string) Rule {
+	return Rule{Column: column, Name: "unique", check: func(series *Series, violate func(int, string)) error {
+		groups, _ := buildGroupsHashed([]*Series{series}, 0, series.Length)
+		isNull := nullPredicate(series)
+		firstOf := make([]int, series.Length)
+		for _, g := range groups {
+			for _, row := range g.indices {
+				firstOf[row] = g.indices[0]
+			}
+		}
+		for row, first := range firstOf {
+			if first != row && !isNull(row) {
+				violate(row, fmt.Sprintf("duplicate of row %d", first))
+			}
+		}
+		return nil
+	}}
+}
+
+// InRange requires the values of a numeric column to lie within [min, max].
+// Missing values are left to NotNull.
+func InRange(column string, min, max float64) Rule {
+	return Rule{Column: column, Name: "range", check: func(series *Series, violate func(int, string)) error {
+		if series.Type != Int64Type && series.Type != Float64Type {
+			return newColumnError("Validate", series.Name, fmt.Sprintf("range rule needs a numeric column, got %s", series.Type))
+		}
+		isNull := nullPredicate(series)
+		for row := 0; row < series.Length; row++ {
+			value, _ := series.Get(row)
+			v, _ := toFloat64(value)
+			if !isNull(row) && (v < min || v > max) {
+				violate(row, fmt.Sprintf("value %v outside [%v, %v]", value, min, max))
+			}
+		}
+		return nil
+	}}
+}
+
+// Matches requires every value of a string column to match pattern, e.g.
+//
+//	otters.Matches("email", regexp.MustCompile(`^[^@]+@[^@]+$`))
+//
+// Use an anchored pattern to match whole values.
+func Matches(column string, pattern *regexp.Regexp) Rule {
+	return Rule{Column: column, Name: "regex", check: func(series *Series, violate func(int, string)) error {
+		if series.Type != StringType {
+			return newColumnError("Validate", series.Name, fmt.Sprintf("regex rule needs a string column, got %s", series.Type))
+		}
+		for row, value := range series.Data.([]string) {
+			if !pattern.MatchString(value) {
+				violate(row, fmt.Sprintf("value %q does not match %s", value, pattern))
+			}
+		}
+		return nil
+	}}
+}
+
+// References requires every value of column to appear in otherColumn of
+// other, like a foreign key: order rows must name a customer that exists.
+// Values are compared as by Filter with "=="; missing values are left to
+// NotNull.
+func References(column string, other *DataFrame, otherColumn string) Rule {
+	return Rule{Column: column, Name: "references", check: func(series *Series, violate func(int, string)) error {
+		if other.err != nil {
+			return wrapColumnError("Validate", series.Name, other.err)
+		}
+		if err := other.validateColumnExists(otherColumn); err != nil {
+			return err
+		}
+		target := other.columns[otherColumn]
+		numeric := func(t ColumnType) bool { return t == Int64Type || t == Float64Type }
+		if series.Type != target.Type && !(numeric(series.Type) && numeric(target.Type)) {
+			return newColumnError("Validate", series.Name,
+				fmt.Sprintf("cannot compare %s values with %s column %s", series.Type, target.Type, otherColumn))
+		}
+		index := newSeriesHashIndex(target)
+		if index == nil {
+			return newColumnError("Validate", otherColumn, "unsupported column type")
+		}
+
+		isNull := nullPredicate(series)
+		for row := 0; row < series.Length; row++ {
+			if isNull(row) {
+				continue
+			}
+			value, _ := series.Get(row)
+			if rows, _ := index.lookup(value); len(rows) == 0 {
+				violate(row, fmt.Sprintf("value %v not found in %s", value, otherColumn))
+			}
+		}
+		return nil
+	}}
+}
+
+// Validator checks DataFrames against a set of rules and reports every
+// failing cell, for data-quality checks at pipeline boundaries:
+//
+//	v := otters.NewValidator(
+//		otters.NotNull("amount"),
+//		otters.Unique("order_id"),
+//		otters.InRange("amount", 0, 10000),
+//		otters.References("customer_id", customers, "id"),
+//	)
+//	violations, err := v.Validate(orders)
+type Validator struct {
+	rules []Rule
+}
+
+// NewValidator creates a Validator with the given rules
+func NewValidator(rules ...Rule) *Validator {
+	return &Validator{rules: rules}
+}
+
+// Add appends rules to the Validator and returns it for chaining
+func (v *Validator) Add(rules ...Rule) *Validator {
+	v.rules = append(v.rules, rules...)
+	return v
+}
+
+// Validate runs every rule against df and returns one row per violation,
+// with columns "row" (int64, the failing row's position), "column", "rule",
+// "value" (formatted as in CSV output) and "message". Violations are listed
+// rule by rule in the order the rules were added, and by row within a rule;
+// a DataFrame that passes gives an empty result. A rule naming a missing
+// column, or one that cannot apply to its column's type, is an error.
+func (v *Validator) Validate(df *DataFrame) (*DataFrame, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+
+	var rows []int64
+	var columns, rules, values, messages []string
+	for _, rule := range v.rules {
+		if rule.check == nil {
+			return nil, newOpError("Validate", "rule has no check; build rules with NotNull, Unique, InRange, Matches or References")
+		}
+		if err := df.validateColumnExists(rule.Column); err != nil {
+			return nil, err
+		}
+		series := df.columns[rule.Column]
+		err := rule.check(series, func(row int, message string) {
+			value, _ := series.Get(row)
+			rows = append(rows, int64(row))
+			columns = append(columns, rule.Column)
+			rules = append(rules, rule.Name)
+			values = append(values, formatValueForCSV(value))
+			messages = append(messages, message)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := NewDataFrame()
+	for _, column := range []struct {
+		name string
+		data any
+	}{
+		{"row", rows},
+		{"column", columns},
+		{"rule", rules},
+		{"value", values},
+		{"message", messages},
+	} {
+		series, err := newSeriesOwned(column.name, column.data)
+		if err != nil {
+			return nil, wrapError("Validate", err)
+		}
+		result.addSeriesUnsafe(series)
+	}
+	result.length = len(rows)
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package otters
+
+import (
+	"math"
+	"regexp"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	customers, err := NewDataFrameFromSeries(mustSeries(t, "id", []int64{1, 2, 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	orders, err := NewDataFrameFromSeries(
+		mustSeries(t, "order_id", []int64{10, 11, 10, 12}),
+		mustSeries(t, "customer_id", []int64{1, 4, 2, 3}),
+		mustSeries(t, "amount", []float64{5, math.NaN(), -1, 20000}),
+		mustSeries(t, "email", []string{"a@x.com", "b@x.com", "bad", "c@x.com"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := NewValidator(
+		NotNull("amount"),
+		Unique("order_id"),
+		InRange("amount", 0, 10000),
+	).Add(
+		Matches("email", regexp.MustCompile(`^[^@]+@[^@]+$`)),
+		References("customer_id", customers, "id"),
+	)
+	violations, err := v.Validate(orders)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		row          int64
+		column, rule string
+		value        string
+	}{
+		{1, "amount", "not_null", "NaN"},
+		{2, "order_id", "unique", "10"},
+		{2, "amount", "range", "-1"},
+		{3, "amount", "range", "20000"},
+		{2, "email", "regex", "bad"},
+		{1, "customer_id", "references", "4"},
+	}
+	if violations.Len() != len(want) {
+		t.Fatalf("got %d violations, want %d:\n%v", violations.Len(), len(want), violations)
+	}
+	for i, w := range want {
+		row, _ := violations.Get(i, "row")
+		column, _ := violations.Get(i, "column")
+		rule, _ := violations.Get(i, "rule")
+		value, _ := violations.Get(i, "value")
+		message, _ := violations.Get(i, "message")
+		if row != w.row || column != w.column || rule != w.rule || value != w.value || message == "" {
+			t.Errorf("violation %d = %v %v %v %v %q, want %v", i, row, column, rule, value, message, w)
+		}
+	}
+
+	clean := orders.Filter("email", "!=", "bad")
+	violations, err = NewValidator(Matches("email", regexp.MustCompile("@"))).Validate(clean)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations.Len() != 0 || violations.Width() != 5 {
+		t.Errorf("clean frame: %d violations in %d columns, want 0 in 5", violations.Len(), violations.Width())
+	}
+
+	for name, rule := range map[string]Rule{
+		"missing column":    NotNull("nope"),
+		"range on string":   InRange("email", 0, 1),
+		"regex on int":      Matches("order_id", regexp.MustCompile(".")),
+		"missing reference": References("customer_id", customers, "nope"),
+		"reference types":   References("email", customers, "id"),
+		"zero rule":         {Column: "email"},
+	} {
+		if _, err := NewValidator(rule).Validate(orders); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestUniqueSkipsMissingValues(t *testing.T) {
+	df, err := NewDataFrameFromSeries(mustSeries(t, "score", []float64{math.NaN(), 1, math.NaN(), 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations, err := NewValidator(Unique("score")).Validate(df)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations.Len() != 1 {
+		t.Fatalf("got %d violations, want 1:\n%v", violations.Len(), violations)
+	}
+	if row, _ := violations.Get(0, "row"); row != int64(3) {
+		t.Errorf("violation on row %v, want 3", row)
+	}
+}
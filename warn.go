@@ -0,0 +1,183 @@
+package otters
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// StrictConversions makes conversions that would change a value errors
+// instead of Warnings:
+//   - the ReadCSV family fails on an empty cell in an int64, float64 or
+//     bool column whose type it inferred, instead of reading 0 or false
+//   - Filter and its variants (FilterAll, Mask, LazyFrame.Filter) fail on a
+//     fractional or out-of-range float64 compared with an int64 column,
+//     instead of comparing it as float64
+//   - Query fails on an empty value for a non-string column, instead of
+//     comparing with 0 or false
+//
+// It applies to the whole package; set it once at startup.
+var StrictConversions bool
+
+// Warning is a non-fatal event in an operation: the result is usable, but
+// some values were not what the data said or were left out, so it may not
+// be what was meant.
+type Warning struct {
+	Op      string // Operation that raised the warning
+	Column  string // Column concerned (if applicable)
+	Row     int    // First row concerned (-1 if not applicable)
+	Count   int    // Number of rows concerned
+	Message string // Human-readable description
+}
+
+// String formats the warning like an OtterError
+func (w Warning) String() string {
+	if w.Column != "" {
+		return fmt.Sprintf("otters.%s: %s (column: %s)", w.Op, w.Message, w.Column)
+	}
+	return fmt.Sprintf("otters.%s: %s", w.Op, w.Message)
+}
+
+// Warnings returns the warnings raised while producing the DataFrame, oldest
+// first. They are kept by operations that select rows or columns of a
+// DataFrame (Filter, Sort, Select, Head, Copy and the like), so the end of
+// a pipeline still reports what happened at its start:
+//
+//	df, _ := otters.ReadCSV("sales.csv")
+//	result := df.Filter("amount", ">", 100).Select("region", "amount")
+//	for _, w := range result.Warnings() {
+//		log.Println(w) // otters.ReadCSV: 3 empty cells read as 0 (column: amount)
+//	}
+//
+// Warnings are raised for:
+//   - empty cells read as 0 or false in an int64, float64 or bool column
+//     inferred by the ReadCSV family
+//   - rows with a missing value left out by a Filter comparison, except
+//     an equality lookup on a column with CreateIndex, which never reads
+//     them
+//   - a fractional or out-of-range float64 compared with an int64 column,
+//     which is compared as float64 rather than converted
+func (df *DataFrame) Warnings() []Warning {
+	return slices.Clone(df.warnings)
+}
+
+// warn records a warning on the DataFrame, which must be one the current
+// operation created. The slice may be shared with the frame it was copied
+// from, so it is never appended to in place.
+func (df *DataFrame) warn(w Warning) {
+	df.warnings = append(slices.Clip(df.warnings), w)
+}
+
+// plural returns noun, with an s unless count is 1
+func plural(count int, noun string) string {
+	if count == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+// floatIsInt64 reports whether f is a whole number in the range of int64,
+// so that converting it loses nothing.
+func floatIsInt64(f float64) bool {
+	return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64
+}
+
+// filterWarnings returns the warnings for filtering series with operator
+// and value in operation op: the missing rows the filter's scan left out,
+// which no comparison matches, and float64 values an int64 column can only
+// compare as float64.
+func filterWarnings(series *Series, operator string, value any, missing missingRows, op string) []Warning {
+	if operator == "isnull" || operator == "notnull" {
+		return nil
+	}
+
+	var warnings []Warning
+	for _, f := range inexactInt64Values(series, operator, value) {
+		warnings = append(warnings, Warning{Op: op, Column: series.Name, Row: -1,
+			Message: fmt.Sprintf("float64 value %v compared with int64 column as float64", f)})
+	}
+	if missing.count > 0 {
+		warnings = append(warnings, Warning{Op: op, Column: series.Name, Row: missing.first, Count: missing.count,
+			Message: fmt.Sprintf("%d %s with missing values left out", missing.count, plural(missing.count, "row"))})
+	}
+	return warnings
+}
+
+// inexactInt64Values returns the float64 values in a filter value (the
+// value itself, the candidates of "in" or the bounds of "between") that an
+// int64 series can only compare as float64: fractional, out of range or
+// NaN.
+func inexactInt64Values(series *Series, operator string, value any) []float64 {
+	if series.Type != Int64Type {
+		return nil
+	}
+	values := []any{value}
+	switch operator {
+	case "in":
+		values, _ = inCandidates(value)
+	case "between":
+		lo, hi, _ := betweenBounds(value)
+		values = []any{lo, hi}
+	}
+
+	var inexact []float64
+	for _, v := range values {
+		if f, isFloat := v.(float64); isFloat && !floatIsInt64(f) {
+			inexact = append(inexact, f)
+		}
+	}
+	return inexact
+}
+
+// strictFilterValue returns an error, if StrictConversions is set, when
+// the filter value does not convert exactly to the series' type.
+func strictFilterValue(series *Series, operator string, value any) error {
+	if !StrictConversions {
+		return nil
+	}
+	if inexact := inexactInt64Values(series, operator, value); inexact != nil {
+		return newOpError("Filter", fmt.Sprintf("float64 value %v is not an int64 (StrictConversions)", inexact[0]))
+	}
+	return nil
+}
+
+// strictEmptyCells returns an error, if StrictConversions is set, for the
+// first of the empty cells reported by emptyCellWarnings.
+func strictEmptyCells(warnings []Warning) error {
+	if !StrictConversions || len(warnings) == 0 {
+		return nil
+	}
+	w := warnings[0]
+	return &OtterError{Op: w.Op, Column: w.Column, Row: w.Row, Message: "empty cell in a non-string column (StrictConversions)"}
+}
+
+// emptyCellWarnings returns a warning for each int64, float64 or bool
+// column of df with empty cells in rows, which were read as 0 or false.
+func emptyCellWarnings(df *DataFrame, rows [][]string, op string) []Warning {
+	var warnings []Warning
+	for col, name := range df.order {
+		series := df.columns[name]
+		if series.Type != Int64Type && series.Type != Float64Type && series.Type != BoolType {
+			continue
+		}
+		first, count := -1, 0
+		for r, row := range rows {
+			if strings.TrimSpace(row[col]) == "" {
+				if count == 0 {
+					first = r
+				}
+				count++
+			}
+		}
+		if count > 0 {
+			zero := "0"
+			if series.Type == BoolType {
+				zero = "false"
+			}
+			warnings = append(warnings, Warning{Op: op, Column: name, Row: first, Count: count,
+				Message: fmt.Sprintf("%d empty %s read as %s", count, plural(count, "cell"), zero)})
+		}
+	}
+	return warnings
+}
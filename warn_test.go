@@ -0,0 +1,102 @@
+package otters
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWarnings(t *testing.T) {
+	df, err := ReadCSVFromString("region,amount,units,ok\nNorth,10.5,,true\nSouth,,2,\nEast,3,,false\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := df.Warnings()
+	if len(warnings) != 3 {
+		t.Fatalf("warnings = %v, want one per column with empty cells", warnings)
+	}
+	if w := warnings[1]; w.Op != "ReadCSVFromString" || w.Column != "units" || w.Row != 0 || w.Count != 2 {
+		t.Errorf("units warning = %+v, want 2 empty cells from row 0", w)
+	}
+	if got := warnings[2].String(); got != "otters.ReadCSVFromString: 1 empty cell read as false (column: ok)" {
+		t.Errorf("ok warning = %s", got)
+	}
+
+	// Warnings carry through row and column selections
+	result := df.Filter("units", ">=", int64(0)).Sort("region", true).Select("region", "units").Head(2)
+	if got := len(result.Warnings()); got != 3 {
+		t.Errorf("after a pipeline: %d warnings, want 3", got)
+	}
+	if got := len(df.Warnings()); got != 3 {
+		t.Errorf("pipeline changed the source's warnings: %d", got)
+	}
+
+	prices, err := NewDataFrameFromSeries(
+		mustSeries(t, "price", []float64{1, math.NaN(), 3, math.NaN()}),
+		mustSeries(t, "qty", []int64{1, 2, 3, 4}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := prices.Filter("price", "isnull", nil).Warnings(); got != nil {
+		t.Errorf("isnull: warnings = %v, want none", got)
+	}
+	warnings = prices.Filter("price", ">", 0.0).Warnings()
+	if len(warnings) != 1 || warnings[0].Row != 1 || warnings[0].Count != 2 {
+		t.Errorf("missing values: warnings = %v, want 2 rows from row 1", warnings)
+	}
+
+	warnings = prices.FilterAll(C("qty", ">", 2.5), C("qty", "in", []any{int64(1), 1e19})).Warnings()
+	if len(warnings) != 2 || !strings.Contains(warnings[0].Message, "2.5") || !strings.Contains(warnings[1].Message, "1e+19") {
+		t.Errorf("float values on int64 column: warnings = %v", warnings)
+	}
+	if got := prices.Filter("qty", "<", 1e19).Len(); got != 4 {
+		t.Errorf("out-of-range bound: %d rows, want 4", got)
+	}
+	if got := prices.Filter("qty", "==", 2.0).Warnings(); got != nil {
+		t.Errorf("whole float value: warnings = %v, want none", got)
+	}
+}
+
+func TestStrictConversions(t *testing.T) {
+	StrictConversions = true
+	defer func() { StrictConversions = false }()
+
+	df, err := NewDataFrameFromSeries(
+		mustSeries(t, "age", []int64{25, 42, 43}),
+		mustSeries(t, "name", []string{"a", "b", "c"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, result := range map[string]*DataFrame{
+		"Filter":    df.Filter("age", ">", 42.7),
+		"in":        df.Filter("age", "in", []any{int64(25), 42.5}),
+		"between":   df.Filter("age", "between", [2]float64{0, 1e19}),
+		"FilterAll": df.FilterAll(C("age", "==", 42.7)),
+		"Query":     df.Query("age == ''"),
+	} {
+		if result.Error() == nil {
+			t.Errorf("%s: expected an error in strict mode", name)
+		}
+	}
+	if _, err := df.Lazy().Filter("age", "<", 30.5).Collect(); err == nil {
+		t.Error("LazyFrame.Filter: expected an error in strict mode")
+	}
+
+	// Exact conversions still work
+	if got := df.Filter("age", ">=", 42.0).Len(); got != 2 {
+		t.Errorf("whole float value: %d rows, want 2", got)
+	}
+	if got := df.Query("name == ''").Error(); got != nil {
+		t.Errorf("empty string value: %v", got)
+	}
+
+	var otterErr *OtterError
+	_, err = ReadCSVFromString("id,score\n1,10\n2,\n")
+	if !errors.As(err, &otterErr) || otterErr.Column != "score" || otterErr.Row != 1 {
+		t.Errorf("empty CSV cell: got %v, want an error at column score, row 1", err)
+	}
+}
@@ -0,0 +1,344 @@
+package otters
+
+import (
+	"math"
+)
+
+// Rolling computes statistics over a sliding window of rows, like Pandas'
+// rolling. Each result is a new float64 column named
+// "<column>_rolling_<stat>" appended to a copy of the DataFrame. A row's
+// value covers the window ending at that row; the first window-1 rows, and
+// any window containing NaN, are NaN.
+type Rolling struct {
+	df     *DataFrame
+	column string
+	window int
+	err    error
+}
+
+// Rolling creates a sliding window of the given size over a numeric column
+func (df *DataFrame) Rolling(column string, window int) *Rolling {
+	if df.err != nil {
+		return &Rolling{df: df, err: df.err}
+	}
+
+	if err := df.validateColumnExists(column); err != nil {
+		return &Rolling{df: df, err: err}
+	}
+
+	if !isNumericType(df.columns[column].Type) {
+		return &Rolling{df: df, err: newColumnError("Rolling", column, "column must be numeric (int64 or float64)")}
+	}
+
+	if window <= 0 {
+		return &Rolling{df: df, err: newColumnError("Rolling", column, "window must be positive")}
+	}
+
+	return &Rolling{df: df, column: column, window: window}
+}
+
+// Sum calculates the rolling sum
+func (r *Rolling) Sum() *DataFrame {
+	return r.apply("sum", rollingSum)
+}
+
+// Mean calculates the rolling average
+func (r *Rolling) Mean() *DataFrame {
+	return r.apply("mean", func(values []float64, window int) []float64 {
+		out := rollingSum(values, window)
+		for i := range out {
+			out[i] /= float64(window)
+		}
+		return out
+	})
+}
+
+// Std calculates the rolling sample standard deviation
+func (r *Rolling) Std() *DataFrame {
+	return r.apply("std", rollingStd)
+}
+
+// Min calculates the rolling minimum
+func (r *Rolling) Min() *DataFrame {
+	return r.apply("min", func(values []float64, window int) []float64 {
+		return rollingExtreme(values, window, func(a, b float64) bool { return a <= b })
+	})
+}
+
+// Max calculates the rolling maximum
+func (r *Rolling) Max() *DataFrame {
+	return r.apply("max", func(values []float64, window int) []float64 {
+		return rollingExtreme(values, window, func(a, b float64) bool { return a >= b })
+	})
+}
+
+// apply runs a sliding-window kernel over the column and appends the result.
+func (r *Rolling) apply(stat string, kernel func(values []float64, window int) []float64) *DataFrame {
+	if r.df.err != nil {
+		return r.df
+	}
+	if r.err != nil {
+		return r.df.setOpError("Rolling", r.err)
+	}
+
+	values := numericAsFloat64(r.df.columns[r.column])
+	result := kernel(values, r.window)
+	maskIncompleteWindows(result, values, r.window)
+
+	series, err := newSeriesOwned(r.column+"_rolling_"+stat, result)
+	if err != nil {
+		return r.df.setError(wrapColumnError("Rolling", r.column, err))
+	}
+	return r.df.withAppendedColumn(series, "Rolling")
+}
+
+// RollingCorr appends a float64 column "<col1>_<col2>_rolling_corr" with the
+// Pearson correlation of the two numeric columns over a sliding window of
+// rows. As with Rolling, the first window-1 rows and any window containing
+// NaN are NaN; so is a window in which either column is constant.
+func (df *DataFrame) RollingCorr(col1, col2 string, window int) *DataFrame {
+	if df.err != nil {
+		return df
+	}
+
+	for _, column := range []string{col1, col2} {
+		if err := df.validateColumnExists(column); err != nil {
+			return df.setOpError("RollingCorr", err)
+		}
+		if !isNumericType(df.columns[column].Type) {
+			return df.setError(newColumnError("RollingCorr", column, "column must be numeric (int64 or float64)"))
+		}
+	}
+
+	if window <= 0 {
+		return df.setError(newOpError("RollingCorr", "window must be positive"))
+	}
+
+	x := numericAsFloat64(df.columns[col1])
+	y := numericAsFloat64(df.columns[col2])
+	result := rollingCorr(x, y, window)
+	maskIncompleteWindows(result, x, window)
+	maskIncompleteWindows(result, y, window)
+
+	series, err := newSeriesOwned(col1+"_"+col2+"_rolling_corr", result)
+	if err != nil {
+		return df.setError(wrapColumnError("RollingCorr", col1, err))
+	}
+	return df.withAppendedColumn(series, "RollingCorr")
+}
+
+// rollingCorr keeps running sums of both columns, their squares and their
+// products, each shifted by the column's first finite value to limit
+// cancellation error. Rows where either value is NaN are left out of the
+// sums; those windows are masked by the caller. Infinite values are counted
+// instead of summed, so they cannot poison later windows; a window holding
+// one has no correlation.
+func rollingCorr(x, y []float64, window int) []float64 {
+	out := make([]float64, len(x))
+	shiftX, shiftY := firstFinite(x), firstFinite(y)
+	valid := func(i int) bool { return isFinite(x[i]) && isFinite(y[i]) }
+
+	var infsX, infsY windowInfs
+	var sx, sy, sxx, syy, sxy float64
+	n := float64(window)
+	for i := range x {
+		infsX.add(x[i], 1)
+		infsY.add(y[i], 1)
+		if valid(i) {
+			dx, dy := x[i]-shiftX, y[i]-shiftY
+			sx, sy, sxx, syy, sxy = sx+dx, sy+dy, sxx+dx*dx, syy+dy*dy, sxy+dx*dy
+		}
+		if j := i - window; j >= 0 {
+			infsX.add(x[j], -1)
+			infsY.add(y[j], -1)
+			if valid(j) {
+				dx, dy := x[j]-shiftX, y[j]-shiftY
+				sx, sy, sxx, syy, sxy = sx-dx, sy-dy, sxx-dx*dx, syy-dy*dy, sxy-dx*dy
+			}
+		}
+
+		varX := sxx - sx*sx/n
+		varY := syy - sy*sy/n
+		if infsX != (windowInfs{}) || infsY != (windowInfs{}) || varX <= 0 || varY <= 0 {
+			out[i] = math.NaN()
+			continue
+		}
+		corr := (sxy - sx*sy/n) / math.Sqrt(varX*varY)
+		out[i] = math.Max(-1, math.Min(1, corr)) // rounding
+	}
+	return out
+}
+
+// firstFinite returns the first value that is neither NaN nor infinite, or
+// 0.
+func firstFinite(values []float64) float64 {
+	for _, v := range values {
+		if isFinite(v) {
+			return v
+		}
+	}
+	return 0
+}
+
+// numericAsFloat64 returns a numeric series' values as float64. Float64
+// data is returned without copying and must not be modified.
+func numericAsFloat64(series *Series) []float64 {
+	if series.Type == Float64Type {
+		return series.Data.([]float64)
+	}
+	data := series.Data.([]int64)
+	values := make([]float64, len(data))
+	for i, v := range data {
+		values[i] = float64(v)
+	}
+	return values
+}
+
+// maskIncompleteWindows sets rows without a full window, and rows whose
+// window contains NaN, to NaN.
+func maskIncompleteWindows(result, values []float64, window int) {
+	nans := 0
+	for i, v := range values {
+		if math.IsNaN(v) {
+			nans++
+		}
+		if i >= window && math.IsNaN(values[i-window]) {
+			nans--
+		}
+		if i < window-1 || nans > 0 {
+			result[i] = math.NaN()
+		}
+	}
+}
+
+// rollingSum keeps a running sum of the finite values in the window.
+// Infinities are counted instead of added, so one leaving the window does
+// not leave Inf-Inf = NaN behind; a window holding them sums to ±Inf, or
+// NaN if it holds both signs.
+func rollingSum(values []float64, window int) []float64 {
+	out := make([]float64, len(values))
+	var sum float64
+	var infs windowInfs
+	for i, v := range values {
+		if isFinite(v) {
+			sum += v
+		}
+		infs.add(v, 1)
+		if i >= window {
+			if old := values[i-window]; isFinite(old) {
+				sum -= old
+			}
+			infs.add(values[i-window], -1)
+		}
+		out[i] = infs.apply(sum)
+	}
+	return out
+}
+
+// rollingStd keeps running sums of the finite values and squares in the
+// window, shifted by the first finite value to limit cancellation error. A
+// window holding an infinity has no finite deviation and gives NaN.
+func rollingStd(values []float64, window int) []float64 {
+	out := make([]float64, len(values))
+	if window < 2 {
+		for i := range out {
+			out[i] = math.NaN()
+		}
+		return out
+	}
+
+	shift := 0.0
+	for _, v := range values {
+		if isFinite(v) {
+			shift = v
+			break
+		}
+	}
+
+	var sum, sumSq float64
+	var infs windowInfs
+	for i, v := range values {
+		if isFinite(v) {
+			d := v - shift
+			sum += d
+			sumSq += d * d
+		}
+		infs.add(v, 1)
+		if i >= window {
+			if old := values[i-window]; isFinite(old) {
+				d := old - shift
+				sum -= d
+				sumSq -= d * d
+			}
+			infs.add(values[i-window], -1)
+		}
+		if infs.pos > 0 || infs.neg > 0 {
+			out[i] = math.NaN()
+			continue
+		}
+		n := float64(window)
+		variance := (sumSq - sum*sum/n) / (n - 1)
+		if variance < 0 {
+			variance = 0 // rounding
+		}
+		out[i] = math.Sqrt(variance)
+	}
+	return out
+}
+
+// windowInfs counts the +Inf and -Inf values in a rolling window.
+type windowInfs struct {
+	pos, neg int
+}
+
+// add counts v into the window (delta 1) or out of it (delta -1) if it is
+// infinite.
+func (w *windowInfs) add(v float64, delta int) {
+	switch {
+	case math.IsInf(v, 1):
+		w.pos += delta
+	case math.IsInf(v, -1):
+		w.neg += delta
+	}
+}
+
+// apply returns the sum of a window with finite sum and the counted
+// infinities.
+func (w *windowInfs) apply(sum float64) float64 {
+	switch {
+	case w.pos > 0 && w.neg > 0:
+		return math.NaN()
+	case w.pos > 0:
+		return math.Inf(1)
+	case w.neg > 0:
+		return math.Inf(-1)
+	}
+	return sum
+}
+
+// isFinite reports whether v is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// rollingExtreme computes a sliding minimum or maximum with a monotonic
+// deque of row indices: keep(a, b) reports whether a newer value b makes an
+// older value a irrelevant. Each row enters and leaves the deque once.
+func rollingExtreme(values []float64, window int, keep func(a, b float64) bool) []float64 {
+	out := make([]float64, len(values))
+	deque := make([]int, 0, window)
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue // the window is masked to NaN anyway
+		}
+		for len(deque) > 0 && deque[0] <= i-window {
+			deque = deque[1:]
+		}
+		for len(deque) > 0 && keep(v, values[deque[len(deque)-1]]) {
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, i)
+		out[i] = values[deque[0]]
+	}
+	return out
+}
@@ -0,0 +1,164 @@
+package otters
+
+import (
+	"math"
+	"testing"
+)
+
+func assertFloatColumn(t *testing.T, df *DataFrame, column string, want []float64) {
+	t.Helper()
+	if err := df.Error(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	series, err := df.GetSeries(column)
+	if err != nil {
+		t.Fatalf("GetSeries(%q): %v", column, err)
+	}
+	got := series.Float64Slice()
+	if len(got) != len(want) {
+		t.Fatalf("%s = %v, want %v", column, got, want)
+	}
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("%s[%d] = %v, want NaN", column, i, got[i])
+			}
+			continue
+		}
+		if math.IsInf(want[i], 0) || math.IsInf(got[i], 0) {
+			if got[i] != want[i] {
+				t.Errorf("%s[%d] = %v, want %v", column, i, got[i], want[i])
+			}
+			continue
+		}
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("%s[%d] = %v, want %v", column, i, got[i], want[i])
+		}
+	}
+}
+
+func TestRollingAggregations(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"price": []int64{1, 3, 2, 5, 4},
+	})
+	nan := math.NaN()
+
+	assertFloatColumn(t, df.Rolling("price", 3).Sum(), "price_rolling_sum", []float64{nan, nan, 6, 10, 11})
+	assertFloatColumn(t, df.Rolling("price", 3).Mean(), "price_rolling_mean", []float64{nan, nan, 2, 10.0 / 3, 11.0 / 3})
+	assertFloatColumn(t, df.Rolling("price", 3).Min(), "price_rolling_min", []float64{nan, nan, 1, 2, 2})
+	assertFloatColumn(t, df.Rolling("price", 3).Max(), "price_rolling_max", []float64{nan, nan, 3, 5, 5})
+	assertFloatColumn(t, df.Rolling("price", 3).Std(), "price_rolling_std", []float64{nan, nan, 1, math.Sqrt(7.0 / 3), math.Sqrt(7.0 / 3)})
+	assertFloatColumn(t, df.Rolling("price", 1).Max(), "price_rolling_max", []float64{1, 3, 2, 5, 4})
+
+	// The source frame is untouched
+	if df.Width() != 1 {
+		t.Errorf("Rolling modified the source DataFrame")
+	}
+}
+
+func TestRollingNaN(t *testing.T) {
+	nan := math.NaN()
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"x": []float64{1, nan, 3, 4, 5, 6},
+	})
+
+	assertFloatColumn(t, df.Rolling("x", 2).Sum(), "x_rolling_sum", []float64{nan, nan, nan, 7, 9, 11})
+	assertFloatColumn(t, df.Rolling("x", 2).Min(), "x_rolling_min", []float64{nan, nan, nan, 3, 4, 5})
+	assertFloatColumn(t, df.Rolling("x", 2).Std(), "x_rolling_std", []float64{nan, nan, nan, math.Sqrt(0.5), math.Sqrt(0.5), math.Sqrt(0.5)})
+}
+
+func TestRollingInf(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"x": []float64{1, 2, inf, 4, 5, -inf, inf, 8, 9},
+	})
+
+	// Windows after the infinity are finite again
+	assertFloatColumn(t, df.Rolling("x", 2).Sum(), "x_rolling_sum",
+		[]float64{nan, 3, inf, inf, 9, -inf, nan, inf, 17})
+	assertFloatColumn(t, df.Rolling("x", 2).Mean(), "x_rolling_mean",
+		[]float64{nan, 1.5, inf, inf, 4.5, -inf, nan, inf, 8.5})
+	assertFloatColumn(t, df.Rolling("x", 2).Std(), "x_rolling_std",
+		[]float64{nan, math.Sqrt(0.5), nan, nan, math.Sqrt(0.5), nan, nan, nan, math.Sqrt(0.5)})
+}
+
+func TestRollingErrors(t *testing.T) {
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"x":    []float64{1, 2, 3},
+		"name": []string{"a", "b", "c"},
+	})
+
+	cases := map[string]*DataFrame{
+		"non-numeric":    df.Rolling("name", 2).Sum(),
+		"missing column": df.Rolling("nope", 2).Sum(),
+		"zero window":    df.Rolling("x", 0).Sum(),
+		"name collision": df.Rolling("x", 2).Sum().Rolling("x", 2).Sum(),
+	}
+	for name, result := range cases {
+		if result.Error() == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func BenchmarkRollingMean(b *testing.B) {
+	values := make([]float64, 100000)
+	for i := range values {
+		values[i] = float64(i % 97)
+	}
+	df, _ := NewDataFrameFromMap(map[string]any{"x": values})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		df.Rolling("x", 50).Mean()
+	}
+}
+
+func TestRollingCorr(t *testing.T) {
+	df, err := NewDataFrameFromMap(map[string]any{
+		"a": []float64{1, 2, 3, 4, 5, 6},
+		"b": []int64{2, 4, 6, 5, 3, 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := df.RollingCorr("a", "b", 3)
+	if result.Error() != nil {
+		t.Fatalf("RollingCorr error: %v", result.Error())
+	}
+	got := result.columns["a_b_rolling_corr"].Float64Slice()
+	if !math.IsNaN(got[0]) || !math.IsNaN(got[1]) {
+		t.Errorf("incomplete windows should be NaN, got %v", got[:2])
+	}
+	for i := 2; i < len(got); i++ {
+		sub := df.slice(i-2, i+1, "test")
+		want, _ := sub.calculateCorrelation("a", "b")
+		if math.Abs(got[i]-want) > 1e-9 {
+			t.Errorf("row %d: rolling corr = %v, want %v", i, got[i], want)
+		}
+	}
+
+	if df.RollingCorr("a", "b", 0).Error() == nil {
+		t.Error("expected error for window 0")
+	}
+}
+
+func TestRollingCorrInf(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	df, _ := NewDataFrameFromMap(map[string]any{
+		"x": []float64{inf, 2, inf, 4, 5, 6, 7, 8},
+		"y": []float64{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+
+	// Windows after the infinity leaves correlate again
+	assertFloatColumn(t, df.RollingCorr("x", "y", 2), "x_y_rolling_corr",
+		[]float64{nan, nan, nan, nan, 1, 1, 1, 1})
+
+	df, _ = NewDataFrameFromMap(map[string]any{
+		"x": []float64{1, 2, inf, 4, 5, 6, 7, 8},
+		"y": []float64{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+	assertFloatColumn(t, df.RollingCorr("x", "y", 2), "x_y_rolling_corr",
+		[]float64{nan, 1, nan, nan, 1, 1, 1, 1})
+}